@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"os"
+
+	"github.com/DANazavr/RATest/config"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/services"
+	"github.com/DANazavr/RATest/internal/store/sqlstore"
+)
+
+// Создаёт первого администратора с ролью superadmin в организации по
+// умолчанию. Остальные привилегированные учётные записи создаются только по
+// приглашениям.
+//
+//	BOOTSTRAP_ADMIN_PASSWORD=... go run ./cmd/bootstrap -username admin -email admin@example.com
+
+var (
+	configPath string
+	username   string
+	email      string
+)
+
+func init() {
+	flag.StringVar(&configPath, "config-path", "./config/config.json", "path to config file")
+	flag.StringVar(&username, "username", "admin", "admin username")
+	flag.StringVar(&email, "email", "", "admin email")
+}
+
+func main() {
+	flag.Parse()
+	config := config.ParseConfig(configPath)
+
+	ctx := context.Background()
+	logger := log.NewLog(ctx, &log.LogConfig{Component: "bootstrap"})
+
+	// Пароль не принимается флагом, чтобы не попадать в историю команд
+	password := os.Getenv("BOOTSTRAP_ADMIN_PASSWORD")
+	if password == "" {
+		logger.Fatal(ctx, "BOOTSTRAP_ADMIN_PASSWORD is not set")
+	}
+
+	db, err := newDB(config.DatabaseURL)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+	store := sqlstore.New(ctx, db, logger)
+
+	hasher, err := services.NewPasswordHasher(config.PasswordHash)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to configure password hashing: %v", err)
+	}
+	userService := services.NewUserService(ctx, store, logger, hasher)
+	u := &models.User{
+		Username: username,
+		Email:    email,
+		Password: password,
+	}
+	if err := userService.BootstrapAdmin(ctx, u); err != nil {
+		logger.Fatalf(ctx, "Failed to create admin: %v", err)
+	}
+	logger.Infof(ctx, "Admin %s created with id %d", u.Username, u.ID)
+}
+
+func newDB(databaseURL string) (*sql.DB, error) {
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
@@ -8,10 +8,12 @@ import (
 	"os/signal"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/DANazavr/RATest/config"
 	grpcapp "github.com/DANazavr/RATest/internal/app/grpc"
 	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/mailer"
 	"github.com/DANazavr/RATest/internal/services"
 	"github.com/DANazavr/RATest/internal/store/sqlstore"
 	"github.com/joho/godotenv"
@@ -65,12 +67,52 @@ func main() {
 	defer db.Close()
 	store := sqlstore.New(ctx, db, logger)
 
-	userService := services.NewUserService(ctx, store, logger)
-	notificationService := services.NewNotificationService(ctx, logger, store)
-	authService := services.NewAuthService(ctx, logger)
+	hasher, err := services.NewPasswordHasher(config.PasswordHash)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to configure password hashing: %v", err)
+	}
+	userService := services.NewUserService(ctx, store, logger, hasher)
+	permissionService := services.NewPermissionService(ctx, logger, store)
+	apiKeyService := services.NewAPIKeyService(ctx, logger, store)
+	keys, err := services.LoadKeyRing(config.JWTKeysDir, config.JWTActiveKID)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to load JWT keys: %v", err)
+	}
+	// Новые ключи подхватываются по SIGHUP без перезапуска
+	go func() {
+		hup := make(chan os.Signal, 1)
+		signal.Notify(hup, syscall.SIGHUP)
+		for range hup {
+			if err := keys.Reload(); err != nil {
+				logger.Errorf(ctx, "Failed to reload JWT keys: %v", err)
+				continue
+			}
+			logger.Info(ctx, "JWT keys reloaded")
+		}
+	}()
+
+	authService := services.NewAuthService(ctx, logger, store, keys)
+	inviteService := services.NewInviteService(ctx, logger, store, authService)
+	mail, err := mailer.New(config.Mail)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to configure mailer: %v", err)
+	}
+	notificationService := services.NewNotificationService(ctx, logger, store, mail)
+	passwordResetService := services.NewPasswordResetService(ctx, logger, store, userService, mail, config.PublicURL)
+	emailVerificationService := services.NewEmailVerificationService(ctx, logger, store, mail, config.PublicURL, config.EmailVerification)
+	// Отправка уведомлений, отложенных до конца тихих часов или запланированных издателем
+	go notificationService.RunDeferred(ctx, 10*time.Second, emailVerificationService.CanDeliver)
+	loginThrottleService := services.NewLoginThrottleService(ctx, logger, store)
+	oidcService := services.NewOIDCService(ctx, logger, store, userService, config.OIDC)
+	audienceService := services.NewAudienceService(ctx, logger, store)
+	organizationService := services.NewOrganizationService(ctx, logger, store)
+	dataExportService := services.NewDataExportService(ctx, logger, store)
+	templateService := services.NewTemplateService(ctx, logger, store)
+	// Сборка выгрузок данных пользователей
+	go dataExportService.Run(ctx, 10*time.Second)
 
 	go func() {
-		if err := grpcapp.Start(ctx, logger, config, store, userService, authService, notificationService); err != nil {
+		if err := grpcapp.Start(ctx, logger, config, store, userService, authService, notificationService, permissionService, apiKeyService, inviteService, passwordResetService, emailVerificationService, loginThrottleService, oidcService, audienceService, organizationService, dataExportService, templateService); err != nil {
 			logger.Fatalf(ctx, "Failed to start server: %v", err)
 		}
 	}()
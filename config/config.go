@@ -11,6 +11,81 @@ type Config struct {
 	GRPCAddr    string `json:"grpc_addr"`
 	LogLevel    string `json:"log_level"`
 	DatabaseURL string `json:"database_url"`
+	// Каталог с ключами подписи JWT (<kid>.pem) и kid ключа для подписи новых токенов
+	JWTKeysDir   string `json:"jwt_keys_dir"`
+	JWTActiveKID string `json:"jwt_active_kid"`
+	// Адрес фронтенда, на который ведут ссылки из писем
+	PublicURL string     `json:"public_url"`
+	Mail      MailConfig `json:"mail"`
+	// Что запрещено пользователям с неподтверждённым email: "login" - вход,
+	// "notifications" - доставка уведомлений через Centrifugo. Пусто - ничего.
+	EmailVerification string             `json:"email_verification"`
+	PasswordHash      PasswordHashConfig `json:"password_hash"`
+	// Внешние провайдеры входа OpenID Connect
+	OIDC []OIDCProviderConfig `json:"oidc"`
+	// TLS для gRPC сервера и его внутренних клиентов. Пустой cert_file - без TLS.
+	GRPCTLS GRPCTLSConfig `json:"grpc_tls"`
+}
+
+type GRPCTLSConfig struct {
+	CertFile string `json:"cert_file"`
+	KeyFile  string `json:"key_file"`
+	// CA для проверки сертификата сервера клиентами и имя в нём
+	CAFile     string `json:"ca_file"`
+	ServerName string `json:"server_name"`
+	// mTLS: CA клиентских сертификатов. Если require_client_cert выключен,
+	// клиенты без сертификата допускаются, но остаются без принципала.
+	ClientCAFile      string `json:"client_ca_file"`
+	RequireClientCert bool   `json:"require_client_cert"`
+	// Сертификат, которым внутренние клиенты представляются серверу
+	ClientCertFile string `json:"client_cert_file"`
+	ClientKeyFile  string `json:"client_key_file"`
+	// Идентичность клиентского сертификата (URI SAN, DNS SAN или CN) -> принципал
+	Principals map[string]PrincipalConfig `json:"principals"`
+}
+
+// PrincipalConfig - сервис, вызывающий gRPC по клиентскому сертификату.
+type PrincipalConfig struct {
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+	// Организация, от имени которой вызывает сервис. 0 - организация по умолчанию
+	OrgID int `json:"org_id"`
+}
+
+// PasswordHashConfig задаёт алгоритм и параметры для новых хешей паролей.
+// Хеши со старыми параметрами пересчитываются при следующем входе.
+type PasswordHashConfig struct {
+	Algorithm     string `json:"algorithm"` // bcrypt (по умолчанию) или argon2id
+	BcryptCost    int    `json:"bcrypt_cost"`
+	Argon2Memory  uint32 `json:"argon2_memory"` // в КиБ
+	Argon2Time    uint32 `json:"argon2_time"`
+	Argon2Threads uint8  `json:"argon2_threads"`
+}
+
+type MailConfig struct {
+	Driver       string `json:"driver"` // file или smtp
+	From         string `json:"from"`
+	Path         string `json:"path"` // для file: пустой путь - stdout
+	SMTPHost     string `json:"smtp_host"`
+	SMTPPort     int    `json:"smtp_port"`
+	SMTPUsername string `json:"smtp_username"`
+	SMTPPassword string `json:"smtp_password"`
+}
+
+type OIDCProviderConfig struct {
+	Name         string   `json:"name"` // часть пути /oidc/<name>/...
+	Issuer       string   `json:"issuer"`
+	ClientID     string   `json:"client_id"`
+	ClientSecret string   `json:"client_secret"`
+	RedirectURL  string   `json:"redirect_url"`
+	Scopes       []string `json:"scopes"` // openid добавляется всегда
+	// Роль пользователей, созданных при первом входе через провайдера
+	DefaultRole string `json:"default_role"`
+	// Организация пользователей, созданных при первом входе. 0 - организация по умолчанию
+	OrgID int `json:"org_id"`
+	// Привязывать вход к существующему пользователю с тем же email, если
+	// провайдер подтвердил адрес. Включать только для доверенных провайдеров.
+	LinkByEmail bool `json:"link_by_email"`
 }
 
 // func NewConfig() *Config {
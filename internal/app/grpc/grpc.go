@@ -14,8 +14,8 @@ import (
 	"github.com/DANazavr/RATest/internal/store"
 )
 
-func Start(ctx context.Context, logger *log.Log, config *config.Config, store store.Store, us *services.UserService, as *services.AuthService, ns *services.NotificationService) error {
-	grpcServer := server.NewServer(ctx, logger, config, as, us, ns)
+func Start(ctx context.Context, logger *log.Log, config *config.Config, store store.Store, us *services.UserService, as *services.AuthService, ns *services.NotificationService, ps *services.PermissionService, ks *services.APIKeyService, is *services.InviteService, rs *services.PasswordResetService, vs *services.EmailVerificationService, ls *services.LoginThrottleService, oc *services.OIDCService, au *services.AudienceService, os *services.OrganizationService, ds *services.DataExportService, ts *services.TemplateService) error {
+	grpcServer := server.NewServer(ctx, logger, config, as, us, ns, ps, ks, is, rs, vs, ls, oc, au, os, ds, ts)
 	grpcListener, err := net.Listen("tcp", config.GRPCAddr)
 	if err != nil {
 		logger.Fatalf(ctx, "Failed to listen on %s: %v", config.GRPCAddr, err)
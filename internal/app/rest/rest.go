@@ -11,7 +11,7 @@ import (
 	"github.com/DANazavr/RATest/internal/store"
 )
 
-func Start(ctx context.Context, store store.Store, config *config.Config, logger *log.Log, us *services.UserService, as *services.AuthService, ns *services.NotificationService) error {
-	srv := server.NewServer(ctx, store, config, logger, us, as, ns)
+func Start(ctx context.Context, store store.Store, config *config.Config, logger *log.Log, us *services.UserService, as *services.AuthService, ns *services.NotificationService, ps *services.PermissionService, ks *services.APIKeyService, is *services.InviteService, rs *services.PasswordResetService, vs *services.EmailVerificationService, ls *services.LoginThrottleService, oc *services.OIDCService, au *services.AudienceService, os *services.OrganizationService, ds *services.DataExportService, ts *services.TemplateService) error {
+	srv := server.NewServer(ctx, store, config, logger, us, as, ns, ps, ks, is, rs, vs, ls, oc, au, os, ds, ts)
 	return http.ListenAndServe(config.RestAddr, srv)
 }
@@ -4,9 +4,15 @@ type contextKey string
 
 const (
 	UserIDKey    contextKey = "userID"
+	SessionIDKey contextKey = "sessionID"
+	RoleKey      contextKey = "role"
+	OrgIDKey     contextKey = "orgID"
+	APIKeyIDKey  contextKey = "apiKeyID"
 	RequestIDKey contextKey = "requestID"
 	RootCtxKey   contextKey = "rootCtx"
 	LogKey       contextKey = "logKey"
 	ConfigKey    contextKey = "configKey"
 	ServerKey    contextKey = "serverKey"
+	PrincipalKey contextKey = "principal"
+	ScopesKey    contextKey = "scopes"
 )
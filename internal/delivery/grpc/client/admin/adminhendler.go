@@ -0,0 +1,240 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	userclient "github.com/DANazavr/RATest/internal/delivery/grpc/client/user"
+	delivery "github.com/DANazavr/RATest/internal/delivery/http"
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/protos/gen/go/admin"
+	"github.com/DANazavr/RATest/protos/gen/go/user"
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+type AdminClient struct {
+	ctx    context.Context
+	logger *log.Log
+	client admin.AdminClient
+}
+
+func NewAdminClient(ctx context.Context, logger *log.Log, creds credentials.TransportCredentials) (*AdminClient, error) {
+	conn, err := grpc.NewClient(":8081",
+		grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+	return &AdminClient{
+		ctx:    ctx,
+		logger: logger.WithComponent("grpc/client/admin"),
+		client: admin.NewAdminClient(conn),
+	}, nil
+}
+
+func (c *AdminClient) ListUsers() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		q := r.URL.Query()
+		req := &admin.ListUsersRequest{
+			Cursor:      q.Get("cursor"),
+			Sort:        q.Get("sort"),
+			Desc:        q.Get("order") == "desc",
+			Role:        q.Get("role"),
+			CreatedFrom: q.Get("created_from"),
+			CreatedTo:   q.Get("created_to"),
+			Query:       q.Get("q"),
+		}
+		if v := q.Get("limit"); v != "" {
+			limit, err := strconv.Atoi(v)
+			if err != nil {
+				delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidFilter)
+				return
+			}
+			req.Limit = int32(limit)
+		}
+		resp, err := c.client.ListUsers(ctx, req)
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to list users: %v", err)
+			delivery.HendleError(w, r, manageStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp)
+	}
+}
+
+func (c *AdminClient) SetUserRole() http.HandlerFunc {
+	type request struct {
+		Role string `json:"role"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, ok := userIDFromPath(w, r)
+		if !ok {
+			return
+		}
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		resp, err := c.client.SetUserRole(ctx, &admin.SetUserRoleRequest{Id: id, Role: req.Role})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to change role of user %d: %v", id, err)
+			delivery.HendleError(w, r, manageStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp.User)
+	}
+}
+
+func (c *AdminClient) DisableUser() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, ok := userIDFromPath(w, r)
+		if !ok {
+			return
+		}
+		resp, err := c.client.DisableUser(ctx, &admin.UserRequest{Id: id})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to disable user %d: %v", id, err)
+			delivery.HendleError(w, r, manageStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp.User)
+	}
+}
+
+func (c *AdminClient) EnableUser() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, ok := userIDFromPath(w, r)
+		if !ok {
+			return
+		}
+		resp, err := c.client.EnableUser(ctx, &admin.UserRequest{Id: id})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to enable user %d: %v", id, err)
+			delivery.HendleError(w, r, manageStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp.User)
+	}
+}
+
+func (c *AdminClient) ForcePasswordReset() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, ok := userIDFromPath(w, r)
+		if !ok {
+			return
+		}
+		resp, err := c.client.ForcePasswordReset(ctx, &admin.UserRequest{Id: id})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to reset password of user %d: %v", id, err)
+			delivery.HendleError(w, r, manageStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusAccepted, resp)
+	}
+}
+
+func (c *AdminClient) DeleteUser() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, ok := userIDFromPath(w, r)
+		if !ok {
+			return
+		}
+		resp, err := c.client.DeleteUser(ctx, &admin.UserRequest{Id: id})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to delete user %d: %v", id, err)
+			delivery.HendleError(w, r, manageStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp)
+	}
+}
+
+func userIDFromPath(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidUserID)
+		return 0, false
+	}
+	return id, true
+}
+
+func manageStatus(err error) int {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.InvalidArgument:
+		return http.StatusUnprocessableEntity
+	case codes.FailedPrecondition:
+		return http.StatusConflict
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
+
+func (c *AdminClient) ExportUser() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, ok := userIDFromPath(w, r)
+		if !ok {
+			return
+		}
+		resp, err := c.client.ExportUser(ctx, &admin.UserRequest{Id: id})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to request data export of user %d: %v", id, err)
+			delivery.HendleError(w, r, manageStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusAccepted, resp.Export)
+	}
+}
+
+func (c *AdminClient) ListUserExports() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, ok := userIDFromPath(w, r)
+		if !ok {
+			return
+		}
+		resp, err := c.client.ListUserExports(ctx, &admin.UserRequest{Id: id})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to list data exports of user %d: %v", id, err)
+			delivery.HendleError(w, r, manageStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp.Exports)
+	}
+}
+
+func (c *AdminClient) DownloadExport() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		resp, err := c.client.DownloadExport(ctx, &user.DownloadExportRequest{Id: id})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to download data export %d: %v", id, err)
+			delivery.HendleError(w, r, manageStatus(err), err)
+			return
+		}
+		userclient.WriteExport(w, resp)
+	}
+}
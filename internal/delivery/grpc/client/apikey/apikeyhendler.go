@@ -0,0 +1,99 @@
+package apikey
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	delivery "github.com/DANazavr/RATest/internal/delivery/http"
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/protos/gen/go/apikey"
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+type APIKeyClient struct {
+	ctx    context.Context
+	logger *log.Log
+	client apikey.ApiKeysClient
+}
+
+func NewAPIKeyClient(ctx context.Context, logger *log.Log, creds credentials.TransportCredentials) (*APIKeyClient, error) {
+	conn, err := grpc.NewClient(":8081",
+		grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+	return &APIKeyClient{
+		ctx:    ctx,
+		logger: logger.WithComponent("grpc/client/apikey"),
+		client: apikey.NewApiKeysClient(conn),
+	}, nil
+}
+
+func (c *APIKeyClient) Create() http.HandlerFunc {
+	type request struct {
+		Name             string   `json:"name"`
+		Scopes           []string `json:"scopes"`
+		RequireSignature bool     `json:"require_signature"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		var req request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			c.logger.Errorf(c.ctx, "Failed to decode request: %v", err)
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		resp, err := c.client.Create(ctx, &apikey.CreateRequest{
+			Name:             req.Name,
+			Scopes:           req.Scopes,
+			RequireSignature: req.RequireSignature,
+		})
+		if status.Code(err) == codes.PermissionDenied {
+			delivery.HendleError(w, r, http.StatusForbidden, err)
+			return
+		} else if err != nil {
+			c.logger.Errorf(ctx, "Failed to create API key: %v", err)
+			delivery.HendleError(w, r, http.StatusUnprocessableEntity, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusCreated, resp)
+	}
+}
+
+func (c *APIKeyClient) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		resp, err := c.client.List(ctx, &apikey.ListRequest{})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to list API keys: %v", err)
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp.ApiKeys)
+	}
+}
+
+func (c *APIKeyClient) Revoke() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		resp, err := c.client.Revoke(ctx, &apikey.RevokeRequest{Id: id})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to revoke API key: %v", err)
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp)
+	}
+}
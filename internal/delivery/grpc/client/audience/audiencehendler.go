@@ -0,0 +1,273 @@
+package audience
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	delivery "github.com/DANazavr/RATest/internal/delivery/http"
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/protos/gen/go/audience"
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+type AudienceClient struct {
+	ctx    context.Context
+	logger *log.Log
+	client audience.AudienceClient
+}
+
+func NewAudienceClient(ctx context.Context, logger *log.Log, creds credentials.TransportCredentials) (*AudienceClient, error) {
+	conn, err := grpc.NewClient(":8081",
+		grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+	return &AudienceClient{
+		ctx:    ctx,
+		logger: logger.WithComponent("grpc/client/audience"),
+		client: audience.NewAudienceClient(conn),
+	}, nil
+}
+
+func (c *AudienceClient) CreateGroup() http.HandlerFunc {
+	type request struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		var req request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		resp, err := c.client.CreateGroup(ctx, &audience.CreateGroupRequest{
+			Name:        req.Name,
+			Description: req.Description,
+		})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to create group: %v", err)
+			delivery.HendleError(w, r, audienceStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusCreated, resp.Group)
+	}
+}
+
+func (c *AudienceClient) ListGroups() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		resp, err := c.client.ListGroups(ctx, &audience.ListGroupsRequest{})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to list groups: %v", err)
+			delivery.HendleError(w, r, audienceStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp.Groups)
+	}
+}
+
+func (c *AudienceClient) DeleteGroup() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, ok := idFromPath(w, r, "id")
+		if !ok {
+			return
+		}
+		resp, err := c.client.DeleteGroup(ctx, &audience.IdRequest{Id: id})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to delete group %d: %v", id, err)
+			delivery.HendleError(w, r, audienceStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp)
+	}
+}
+
+func (c *AudienceClient) ListGroupMembers() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, ok := idFromPath(w, r, "id")
+		if !ok {
+			return
+		}
+		resp, err := c.client.ListGroupMembers(ctx, &audience.IdRequest{Id: id})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to list members of group %d: %v", id, err)
+			delivery.HendleError(w, r, audienceStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp.Users)
+	}
+}
+
+func (c *AudienceClient) AddGroupMembers() http.HandlerFunc {
+	type request struct {
+		UserIDs []int64 `json:"user_ids"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, ok := idFromPath(w, r, "id")
+		if !ok {
+			return
+		}
+		var req request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		resp, err := c.client.AddGroupMembers(ctx, &audience.AddGroupMembersRequest{Id: id, UserIds: req.UserIDs})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to add members to group %d: %v", id, err)
+			delivery.HendleError(w, r, audienceStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp)
+	}
+}
+
+func (c *AudienceClient) RemoveGroupMember() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, ok := idFromPath(w, r, "id")
+		if !ok {
+			return
+		}
+		userID, ok := idFromPath(w, r, "user_id")
+		if !ok {
+			return
+		}
+		resp, err := c.client.RemoveGroupMember(ctx, &audience.RemoveGroupMemberRequest{Id: id, UserId: userID})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to remove user %d from group %d: %v", userID, id, err)
+			delivery.HendleError(w, r, audienceStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp)
+	}
+}
+
+func (c *AudienceClient) CreateSegment() http.HandlerFunc {
+	type rules struct {
+		Role          string `json:"role"`
+		EmailVerified *bool  `json:"email_verified"`
+		CreatedFrom   string `json:"created_from"`
+		CreatedTo     string `json:"created_to"`
+		Query         string `json:"q"`
+	}
+	type request struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Rules       rules  `json:"rules"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		var req request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		pr := &audience.SegmentRules{
+			Role:        req.Rules.Role,
+			CreatedFrom: req.Rules.CreatedFrom,
+			CreatedTo:   req.Rules.CreatedTo,
+			Query:       req.Rules.Query,
+		}
+		if req.Rules.EmailVerified != nil {
+			pr.EmailVerified = audience.EmailVerified_EMAIL_VERIFIED_NO
+			if *req.Rules.EmailVerified {
+				pr.EmailVerified = audience.EmailVerified_EMAIL_VERIFIED_YES
+			}
+		}
+		resp, err := c.client.CreateSegment(ctx, &audience.CreateSegmentRequest{
+			Name:        req.Name,
+			Description: req.Description,
+			Rules:       pr,
+		})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to create segment: %v", err)
+			delivery.HendleError(w, r, audienceStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusCreated, resp.Segment)
+	}
+}
+
+func (c *AudienceClient) ListSegments() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		resp, err := c.client.ListSegments(ctx, &audience.ListSegmentsRequest{})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to list segments: %v", err)
+			delivery.HendleError(w, r, audienceStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp.Segments)
+	}
+}
+
+func (c *AudienceClient) DeleteSegment() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, ok := idFromPath(w, r, "id")
+		if !ok {
+			return
+		}
+		resp, err := c.client.DeleteSegment(ctx, &audience.IdRequest{Id: id})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to delete segment %d: %v", id, err)
+			delivery.HendleError(w, r, audienceStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp)
+	}
+}
+
+func (c *AudienceClient) ListSegmentMembers() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, ok := idFromPath(w, r, "id")
+		if !ok {
+			return
+		}
+		resp, err := c.client.ListSegmentMembers(ctx, &audience.IdRequest{Id: id})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to list members of segment %d: %v", id, err)
+			delivery.HendleError(w, r, audienceStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp.Users)
+	}
+}
+
+func idFromPath(w http.ResponseWriter, r *http.Request, name string) (int64, bool) {
+	id, err := strconv.ParseInt(mux.Vars(r)[name], 10, 64)
+	if err != nil {
+		delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+		return 0, false
+	}
+	return id, true
+}
+
+func audienceStatus(err error) int {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.InvalidArgument:
+		return http.StatusUnprocessableEntity
+	case codes.AlreadyExists:
+		return http.StatusConflict
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
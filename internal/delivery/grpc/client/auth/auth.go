@@ -10,12 +10,21 @@ import (
 
 const (
 	AuthorizationKey = "authorization"
+	APIKeyKey        = "x-api-key"
 )
 
 func AuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
+		// Интеграции авторизуются API ключом вместо токена пользователя.
+		// Подписанные запросы нужно отправлять напрямую в gRPC: шлюз меняет тело запроса.
+		if key := r.Header.Get("X-API-Key"); key != "" {
+			md := metadata.New(map[string]string{APIKeyKey: key})
+			next.ServeHTTP(w, r.WithContext(metadata.NewOutgoingContext(ctx, md)))
+			return
+		}
+
 		// Извлекаем заголовок Authorization
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
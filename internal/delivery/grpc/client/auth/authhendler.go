@@ -4,12 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	delivery "github.com/DANazavr/RATest/internal/delivery/http"
 	"github.com/DANazavr/RATest/internal/log"
 	"github.com/DANazavr/RATest/protos/gen/go/auth"
+	"github.com/gorilla/mux"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type AuthClient struct {
@@ -18,9 +24,9 @@ type AuthClient struct {
 	client auth.AuthClient
 }
 
-func NewAuthClient(ctx context.Context, logger *log.Log) (*AuthClient, error) {
+func NewAuthClient(ctx context.Context, logger *log.Log, creds credentials.TransportCredentials) (*AuthClient, error) {
 	conn, err := grpc.NewClient(":8081",
-		grpc.WithTransportCredentials(insecure.NewCredentials()))
+		grpc.WithTransportCredentials(creds))
 	if err != nil {
 		return nil, err
 	}
@@ -33,10 +39,10 @@ func NewAuthClient(ctx context.Context, logger *log.Log) (*AuthClient, error) {
 
 func (c *AuthClient) Register() http.HandlerFunc {
 	type request struct {
-		Username string `json:"username"`
-		Email    string `json:"email"`
-		Password string `json:"password"`
-		Role     string `json:"role"`
+		Username    string `json:"username"`
+		Email       string `json:"email"`
+		Password    string `json:"password"`
+		InviteToken string `json:"invite_token"`
 	}
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -46,10 +52,10 @@ func (c *AuthClient) Register() http.HandlerFunc {
 			return
 		}
 		resp, err := c.client.Register(ctx, &auth.RegisterRequest{
-			Username: req.Username,
-			Email:    req.Email,
-			Password: req.Password,
-			Role:     req.Role,
+			Username:    req.Username,
+			Email:       req.Email,
+			Password:    req.Password,
+			InviteToken: req.InviteToken,
 		})
 		if err != nil {
 			delivery.HendleError(w, r, http.StatusUnprocessableEntity, err)
@@ -66,8 +72,11 @@ func (c *AuthClient) Login() http.HandlerFunc {
 	}
 
 	type response struct {
-		AccessToken  string `json:"access_token"`
-		RefreshToken string `json:"refresh_token"`
+		AccessToken           string `json:"access_token,omitempty"`
+		RefreshToken          string `json:"refresh_token,omitempty"`
+		MFARequired           bool   `json:"mfa_required,omitempty"`
+		MFAToken              string `json:"mfa_token,omitempty"`
+		MFAEnrollmentRequired bool   `json:"mfa_enrollment_required,omitempty"`
 	}
 	return func(w http.ResponseWriter, r *http.Request) {
 		req := &request{}
@@ -75,19 +84,195 @@ func (c *AuthClient) Login() http.HandlerFunc {
 			delivery.HendleError(w, r, http.StatusBadRequest, err)
 			return
 		}
-		resp, err := c.client.Login(c.ctx, &auth.LoginRequest{
+		// Сервер считает неудачные попытки по адресу клиента, а не шлюза
+		ctx := metadata.AppendToOutgoingContext(c.ctx,
+			"x-forwarded-for", delivery.ClientIP(r),
+			"user-agent", r.UserAgent(),
+		)
+		resp, err := c.client.Login(ctx, &auth.LoginRequest{
 			Username: req.Username,
 			Password: req.Password,
 		})
 		if err != nil {
+			if throttled(w, err) {
+				delivery.HendleError(w, r, http.StatusTooManyRequests, err)
+				return
+			}
 			delivery.HendleError(w, r, http.StatusUnauthorized, err)
 			return
 		}
 		delivery.HendleRespond(w, r, http.StatusOK, &response{
-			AccessToken:  resp.AccessToken,
-			RefreshToken: resp.RefreshToken,
+			AccessToken:           resp.AccessToken,
+			RefreshToken:          resp.RefreshToken,
+			MFARequired:           resp.MfaRequired,
+			MFAToken:              resp.MfaToken,
+			MFAEnrollmentRequired: resp.MfaEnrollmentRequired,
+		})
+	}
+}
+
+func (c *AuthClient) LoginMfa() http.HandlerFunc {
+	type request struct {
+		MFAToken string `json:"mfa_token"`
+		Code     string `json:"code"`
+	}
+
+	type response struct {
+		AccessToken   string   `json:"access_token"`
+		RefreshToken  string   `json:"refresh_token"`
+		RecoveryCodes []string `json:"recovery_codes,omitempty"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		ctx := metadata.AppendToOutgoingContext(c.ctx,
+			"x-forwarded-for", delivery.ClientIP(r),
+			"user-agent", r.UserAgent(),
+		)
+		resp, err := c.client.LoginMfa(ctx, &auth.LoginMfaRequest{
+			MfaToken: req.MFAToken,
+			Code:     req.Code,
+		})
+		if err != nil {
+			if throttled(w, err) {
+				delivery.HendleError(w, r, http.StatusTooManyRequests, err)
+				return
+			}
+			delivery.HendleError(w, r, http.StatusUnauthorized, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, &response{
+			AccessToken:   resp.AccessToken,
+			RefreshToken:  resp.RefreshToken,
+			RecoveryCodes: resp.RecoveryCodes,
+		})
+	}
+}
+
+func (c *AuthClient) LoginMfaEnroll() http.HandlerFunc {
+	type request struct {
+		MFAToken string `json:"mfa_token"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		resp, err := c.client.LoginMfaEnroll(r.Context(), &auth.LoginMfaEnrollRequest{MfaToken: req.MFAToken})
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusUnauthorized, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp)
+	}
+}
+
+func (c *AuthClient) EnrollMfa() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resp, err := c.client.EnrollMfa(r.Context(), &auth.EnrollMfaRequest{})
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp)
+	}
+}
+
+func (c *AuthClient) ConfirmMfa() http.HandlerFunc {
+	type request struct {
+		Code string `json:"code"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		resp, err := c.client.ConfirmMfa(r.Context(), &auth.ConfirmMfaRequest{Code: req.Code})
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp)
+	}
+}
+
+func (c *AuthClient) DisableMfa() http.HandlerFunc {
+	type request struct {
+		Code string `json:"code"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		resp, err := c.client.DisableMfa(r.Context(), &auth.DisableMfaRequest{Code: req.Code})
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp)
+	}
+}
+
+func (c *AuthClient) OidcLogin() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		provider := mux.Vars(r)["provider"]
+		resp, err := c.client.OidcStart(r.Context(), &auth.OidcStartRequest{Provider: provider})
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				delivery.HendleError(w, r, http.StatusNotFound, err)
+				return
+			}
+			delivery.HendleError(w, r, http.StatusBadGateway, err)
+			return
+		}
+		delivery.SetOIDCStateCookie(w, r, provider, resp.State)
+		http.Redirect(w, r, resp.AuthorizationUrl, http.StatusFound)
+	}
+}
+
+func (c *AuthClient) OidcCallback() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		provider := mux.Vars(r)["provider"]
+		browserState := delivery.TakeOIDCStateCookie(w, r, provider)
+		ctx := metadata.AppendToOutgoingContext(c.ctx,
+			"x-forwarded-for", delivery.ClientIP(r),
+			"user-agent", r.UserAgent(),
+		)
+		resp, err := c.client.OidcCallback(ctx, &auth.OidcCallbackRequest{
+			Provider:     provider,
+			Code:         q.Get("code"),
+			State:        q.Get("state"),
+			BrowserState: browserState,
 		})
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusUnauthorized, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp)
+	}
+}
+
+// throttled проверяет, отклонил ли сервер вход из-за частых попыток, и
+// переносит задержку из RetryInfo в заголовок Retry-After.
+func throttled(w http.ResponseWriter, err error) bool {
+	st := status.Convert(err)
+	if st.Code() != codes.ResourceExhausted {
+		return false
+	}
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.RetryInfo); ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(info.RetryDelay.AsDuration().Seconds())+1))
+		}
 	}
+	return true
 }
 
 func (c *AuthClient) TokenRefresh() http.HandlerFunc {
@@ -118,3 +303,137 @@ func (c *AuthClient) TokenRefresh() http.HandlerFunc {
 		})
 	}
 }
+
+func (c *AuthClient) Logout() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resp, err := c.client.Logout(r.Context(), &auth.LogoutRequest{})
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusUnauthorized, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp)
+	}
+}
+
+func (c *AuthClient) LogoutAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resp, err := c.client.LogoutAll(r.Context(), &auth.LogoutAllRequest{})
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusUnauthorized, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp)
+	}
+}
+
+func (c *AuthClient) Jwks() http.HandlerFunc {
+	type jwk struct {
+		Kid string `json:"kid"`
+		Kty string `json:"kty"`
+		Alg string `json:"alg"`
+		Use string `json:"use"`
+		N   string `json:"n,omitempty"`
+		E   string `json:"e,omitempty"`
+		Crv string `json:"crv,omitempty"`
+		X   string `json:"x,omitempty"`
+	}
+	type response struct {
+		Keys []jwk `json:"keys"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		resp, err := c.client.Jwks(r.Context(), &auth.JwksRequest{})
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		keys := make([]jwk, 0, len(resp.Keys))
+		for _, k := range resp.Keys {
+			keys = append(keys, jwk{Kid: k.Kid, Kty: k.Kty, Alg: k.Alg, Use: k.Use, N: k.N, E: k.E, Crv: k.Crv, X: k.X})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		delivery.HendleRespond(w, r, http.StatusOK, &response{Keys: keys})
+	}
+}
+
+func (c *AuthClient) RequestPasswordReset() http.HandlerFunc {
+	type request struct {
+		Email string `json:"email"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		resp, err := c.client.RequestPasswordReset(r.Context(), &auth.RequestPasswordResetRequest{Email: req.Email})
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusAccepted, resp)
+	}
+}
+
+func (c *AuthClient) ConfirmPasswordReset() http.HandlerFunc {
+	type request struct {
+		Token    string `json:"token"`
+		Password string `json:"password"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		resp, err := c.client.ConfirmPasswordReset(r.Context(), &auth.ConfirmPasswordResetRequest{
+			Token:    req.Token,
+			Password: req.Password,
+		})
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp)
+	}
+}
+
+func (c *AuthClient) ConfirmEmail() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resp, err := c.client.ConfirmEmail(r.Context(), &auth.ConfirmEmailRequest{Token: r.URL.Query().Get("token")})
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp)
+	}
+}
+
+func (c *AuthClient) RequestEmailVerification() http.HandlerFunc {
+	type request struct {
+		Email string `json:"email"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		resp, err := c.client.RequestEmailVerification(r.Context(), &auth.RequestEmailVerificationRequest{Email: req.Email})
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusAccepted, resp)
+	}
+}
+
+func (c *AuthClient) ResendEmailVerification() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resp, err := c.client.ResendEmailVerification(r.Context(), &auth.ResendEmailVerificationRequest{})
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusAccepted, resp)
+	}
+}
@@ -5,8 +5,16 @@ import (
 	"net/http"
 
 	"github.com/DANazavr/RATest/config"
+	"github.com/DANazavr/RATest/internal/delivery/grpc/client/admin"
+	"github.com/DANazavr/RATest/internal/delivery/grpc/client/apikey"
+	"github.com/DANazavr/RATest/internal/delivery/grpc/client/audience"
 	"github.com/DANazavr/RATest/internal/delivery/grpc/client/auth"
+	"github.com/DANazavr/RATest/internal/delivery/grpc/client/invite"
 	"github.com/DANazavr/RATest/internal/delivery/grpc/client/notification"
+	"github.com/DANazavr/RATest/internal/delivery/grpc/client/organization"
+	"github.com/DANazavr/RATest/internal/delivery/grpc/client/template"
+	"github.com/DANazavr/RATest/internal/delivery/grpc/client/user"
+	"github.com/DANazavr/RATest/internal/delivery/grpc/transport"
 	"github.com/DANazavr/RATest/internal/log"
 	"github.com/gorilla/mux"
 	"github.com/rs/cors"
@@ -20,14 +28,54 @@ type client struct {
 	router             *mux.Router
 	authClient         *auth.AuthClient
 	notificationClient *notification.NotificationClient
+	apiKeyClient       *apikey.APIKeyClient
+	inviteClient       *invite.InviteClient
+	userClient         *user.UserClient
+	adminClient        *admin.AdminClient
+	audienceClient     *audience.AudienceClient
+	organizationClient *organization.OrganizationClient
+	templateClient     *template.TemplateClient
 }
 
 func NewAuthClient(ctx context.Context, logger *log.Log, config *config.Config) *client {
-	authClient, err := auth.NewAuthClient(ctx, logger)
+	creds, err := transport.ClientCredentials(config.GRPCTLS)
 	if err != nil {
+		logger.Errorf(ctx, "Failed to load gRPC client credentials: %v", err)
 		return nil
 	}
-	notificationClient, err := notification.NewNotificationClient(ctx, logger)
+	authClient, err := auth.NewAuthClient(ctx, logger, creds)
+	if err != nil {
+		return nil
+	}
+	notificationClient, err := notification.NewNotificationClient(ctx, logger, creds)
+	if err != nil {
+		return nil
+	}
+	apiKeyClient, err := apikey.NewAPIKeyClient(ctx, logger, creds)
+	if err != nil {
+		return nil
+	}
+	inviteClient, err := invite.NewInviteClient(ctx, logger, creds)
+	if err != nil {
+		return nil
+	}
+	userClient, err := user.NewUserClient(ctx, logger, creds)
+	if err != nil {
+		return nil
+	}
+	adminClient, err := admin.NewAdminClient(ctx, logger, creds)
+	if err != nil {
+		return nil
+	}
+	audienceClient, err := audience.NewAudienceClient(ctx, logger, creds)
+	if err != nil {
+		return nil
+	}
+	organizationClient, err := organization.NewOrganizationClient(ctx, logger, creds)
+	if err != nil {
+		return nil
+	}
+	templateClient, err := template.NewTemplateClient(ctx, logger, creds)
 	if err != nil {
 		return nil
 	}
@@ -39,6 +87,13 @@ func NewAuthClient(ctx context.Context, logger *log.Log, config *config.Config)
 		router:             mux.NewRouter(),
 		authClient:         authClient,
 		notificationClient: notificationClient,
+		apiKeyClient:       apiKeyClient,
+		inviteClient:       inviteClient,
+		userClient:         userClient,
+		adminClient:        adminClient,
+		audienceClient:     audienceClient,
+		organizationClient: organizationClient,
+		templateClient:     templateClient,
 	}
 
 	c.configureRouter()
@@ -47,28 +102,89 @@ func NewAuthClient(ctx context.Context, logger *log.Log, config *config.Config)
 }
 
 func (c *client) configureRouter() {
+	c.router.HandleFunc("/.well-known/jwks.json", c.authClient.Jwks()).Methods("GET")
 	c.router.HandleFunc("/register", c.authClient.Register()).Methods("POST")
 	c.router.HandleFunc("/login", c.authClient.Login()).Methods("POST")
+	c.router.HandleFunc("/login/mfa", c.authClient.LoginMfa()).Methods("POST")
+	c.router.HandleFunc("/login/mfa/enroll", c.authClient.LoginMfaEnroll()).Methods("POST")
+	c.router.HandleFunc("/oidc/{provider}/login", c.authClient.OidcLogin()).Methods("GET")
+	c.router.HandleFunc("/oidc/{provider}/callback", c.authClient.OidcCallback()).Methods("GET")
 	c.router.HandleFunc("/token_refresh", c.authClient.TokenRefresh()).Methods("GET")
+	c.router.HandleFunc("/password_reset/request", c.authClient.RequestPasswordReset()).Methods("POST")
+	c.router.HandleFunc("/password_reset/confirm", c.authClient.ConfirmPasswordReset()).Methods("POST")
+	c.router.HandleFunc("/email/confirm", c.authClient.ConfirmEmail()).Methods("GET")
+	c.router.HandleFunc("/email/resend", c.authClient.RequestEmailVerification()).Methods("POST")
+	c.router.Handle("/logout", auth.AuthMiddleware(c.authClient.Logout())).Methods("POST")
+	c.router.Handle("/logout_all", auth.AuthMiddleware(c.authClient.LogoutAll())).Methods("POST")
 
 	in := c.router.PathPrefix("/user").Subrouter()
 	in.Use(auth.AuthMiddleware)
 	in.HandleFunc("/getnotifications", c.notificationClient.GetNotificationsByFilter()).Methods("GET")
 	in.HandleFunc("/markasread", c.notificationClient.MarkAsRead()).Methods("POST")
-	// in.HandleFunc("/profile", c.userHendler.HandleGetUser()).Methods("GET")
+	in.HandleFunc("/notification_preferences", c.notificationClient.GetPreferences()).Methods("GET")
+	in.HandleFunc("/notification_preferences", c.notificationClient.UpdatePreferences()).Methods("PUT")
+	in.HandleFunc("/centrifugo/connection_token", c.notificationClient.CentrifugoConnectionToken()).Methods("GET")
+	in.HandleFunc("/centrifugo/subscription_token", c.notificationClient.CentrifugoSubscriptionToken()).Methods("POST")
+	in.HandleFunc("/email/resend", c.authClient.ResendEmailVerification()).Methods("POST")
+	in.HandleFunc("/mfa/enroll", c.authClient.EnrollMfa()).Methods("POST")
+	in.HandleFunc("/mfa/confirm", c.authClient.ConfirmMfa()).Methods("POST")
+	in.HandleFunc("/mfa/disable", c.authClient.DisableMfa()).Methods("POST")
+	in.HandleFunc("/profile", c.userClient.GetProfile()).Methods("GET")
+	in.HandleFunc("/password", c.userClient.ChangePassword()).Methods("POST")
+	in.HandleFunc("/email", c.userClient.ChangeEmail()).Methods("POST")
+	in.HandleFunc("/account", c.userClient.DeleteAccount()).Methods("DELETE")
+	in.HandleFunc("/exports", c.userClient.RequestExport()).Methods("POST")
+	in.HandleFunc("/exports", c.userClient.ListExports()).Methods("GET")
+	in.HandleFunc("/exports/{id:[0-9]+}/download", c.userClient.DownloadExport()).Methods("GET")
 
-	// admin := c.router.PathPrefix("/admin").Subrouter()
-	// admin.HandleFunc("/getUsers", c.userHendler.HandleGetUsers()).Methods("GET")
+	admin := c.router.PathPrefix("/admin").Subrouter()
+	admin.Use(auth.AuthMiddleware)
+	admin.HandleFunc("/getUsers", c.adminClient.ListUsers()).Methods("GET")
+	admin.HandleFunc("/users/{id:[0-9]+}/role", c.adminClient.SetUserRole()).Methods("PUT")
+	admin.HandleFunc("/users/{id:[0-9]+}/disable", c.adminClient.DisableUser()).Methods("POST")
+	admin.HandleFunc("/users/{id:[0-9]+}/enable", c.adminClient.EnableUser()).Methods("POST")
+	admin.HandleFunc("/users/{id:[0-9]+}/password_reset", c.adminClient.ForcePasswordReset()).Methods("POST")
+	admin.HandleFunc("/users/{id:[0-9]+}", c.adminClient.DeleteUser()).Methods("DELETE")
+	admin.HandleFunc("/users/{id:[0-9]+}/exports", c.adminClient.ExportUser()).Methods("POST")
+	admin.HandleFunc("/users/{id:[0-9]+}/exports", c.adminClient.ListUserExports()).Methods("GET")
+	admin.HandleFunc("/exports/{id:[0-9]+}/download", c.adminClient.DownloadExport()).Methods("GET")
+	admin.HandleFunc("/apikeys", c.apiKeyClient.Create()).Methods("POST")
+	admin.HandleFunc("/apikeys", c.apiKeyClient.List()).Methods("GET")
+	admin.HandleFunc("/apikeys/{id:[0-9]+}", c.apiKeyClient.Revoke()).Methods("DELETE")
+	admin.HandleFunc("/invites", c.inviteClient.Create()).Methods("POST")
+	admin.HandleFunc("/invites", c.inviteClient.List()).Methods("GET")
+	admin.HandleFunc("/groups", c.audienceClient.CreateGroup()).Methods("POST")
+	admin.HandleFunc("/groups", c.audienceClient.ListGroups()).Methods("GET")
+	admin.HandleFunc("/groups/{id:[0-9]+}", c.audienceClient.DeleteGroup()).Methods("DELETE")
+	admin.HandleFunc("/groups/{id:[0-9]+}/members", c.audienceClient.ListGroupMembers()).Methods("GET")
+	admin.HandleFunc("/groups/{id:[0-9]+}/members", c.audienceClient.AddGroupMembers()).Methods("POST")
+	admin.HandleFunc("/groups/{id:[0-9]+}/members/{user_id:[0-9]+}", c.audienceClient.RemoveGroupMember()).Methods("DELETE")
+	admin.HandleFunc("/segments", c.audienceClient.CreateSegment()).Methods("POST")
+	admin.HandleFunc("/segments", c.audienceClient.ListSegments()).Methods("GET")
+	admin.HandleFunc("/segments/{id:[0-9]+}", c.audienceClient.DeleteSegment()).Methods("DELETE")
+	admin.HandleFunc("/segments/{id:[0-9]+}/members", c.audienceClient.ListSegmentMembers()).Methods("GET")
+	admin.HandleFunc("/organizations", c.organizationClient.Create()).Methods("POST")
+	admin.HandleFunc("/organizations", c.organizationClient.List()).Methods("GET")
+	admin.HandleFunc("/organizations/{id:[0-9]+}/invites", c.organizationClient.CreateInvite()).Methods("POST")
+	admin.HandleFunc("/templates", c.templateClient.Create()).Methods("POST")
+	admin.HandleFunc("/templates", c.templateClient.List()).Methods("GET")
+	admin.HandleFunc("/templates/{key}", c.templateClient.Get()).Methods("GET")
+	admin.HandleFunc("/templates/{key}", c.templateClient.Update()).Methods("PUT")
+	admin.HandleFunc("/templates/{key}", c.templateClient.Delete()).Methods("DELETE")
+	admin.HandleFunc("/templates/{key}/preview", c.templateClient.Preview()).Methods("POST")
 
 	notificationRouter := c.router.PathPrefix("/notification").Subrouter()
 	notificationRouter.Use(auth.AuthMiddleware)
 	notificationRouter.HandleFunc("/broadcast", c.notificationClient.Broadcast()).Methods("POST")
 	notificationRouter.HandleFunc("/publish", c.notificationClient.Publish()).Methods("POST")
+	notificationRouter.HandleFunc("/scheduled", c.notificationClient.ListScheduled()).Methods("GET")
+	notificationRouter.HandleFunc("/scheduled/{id:[0-9]+}", c.notificationClient.Reschedule()).Methods("PUT")
+	notificationRouter.HandleFunc("/scheduled/{id:[0-9]+}", c.notificationClient.CancelScheduled()).Methods("DELETE")
 
 	co := cors.New(cors.Options{
 		AllowedOrigins:   []string{"*"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Authorization", "Content-Type"},
+		AllowedHeaders:   []string{"Authorization", "Content-Type", "X-API-Key"},
 		AllowCredentials: true,
 	})
 
@@ -0,0 +1,72 @@
+package invite
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	delivery "github.com/DANazavr/RATest/internal/delivery/http"
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/protos/gen/go/invite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+type InviteClient struct {
+	ctx    context.Context
+	logger *log.Log
+	client invite.InvitesClient
+}
+
+func NewInviteClient(ctx context.Context, logger *log.Log, creds credentials.TransportCredentials) (*InviteClient, error) {
+	conn, err := grpc.NewClient(":8081",
+		grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+	return &InviteClient{
+		ctx:    ctx,
+		logger: logger.WithComponent("grpc/client/invite"),
+		client: invite.NewInvitesClient(conn),
+	}, nil
+}
+
+func (c *InviteClient) Create() http.HandlerFunc {
+	type request struct {
+		Role  string `json:"role"`
+		Email string `json:"email"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		var req request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			c.logger.Errorf(c.ctx, "Failed to decode request: %v", err)
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		resp, err := c.client.Create(ctx, &invite.CreateRequest{
+			Role:  req.Role,
+			Email: req.Email,
+		})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to create invite: %v", err)
+			delivery.HendleError(w, r, http.StatusUnprocessableEntity, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusCreated, resp)
+	}
+}
+
+func (c *InviteClient) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		resp, err := c.client.List(ctx, &invite.ListRequest{})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to list invites: %v", err)
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp.Invites)
+	}
+}
@@ -5,24 +5,59 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	delivery "github.com/DANazavr/RATest/internal/delivery/http"
 	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
 	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/services"
 	"github.com/DANazavr/RATest/protos/gen/go/notification"
+	"github.com/gorilla/mux"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
+// protoData переводит тело уведомления в gRPC сообщение. Без тела, например
+// при публикации по шаблону, возвращает nil.
+func protoData(d *models.NotificationData) (*notification.Data, error) {
+	if d == nil {
+		return nil, nil
+	}
+	return services.ConvertToProtoData(*d)
+}
+
+// variables переводит значения переменных шаблона в google.protobuf.Struct.
+func variables(vars map[string]interface{}) (*structpb.Struct, error) {
+	if vars == nil {
+		return nil, nil
+	}
+	return structpb.NewStruct(vars)
+}
+
+// publishStatus отличает отклонённое сервером уведомление от сбоя отправки.
+func publishStatus(err error) (int, error) {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return http.StatusUnprocessableEntity, err
+	case codes.NotFound:
+		return http.StatusNotFound, err
+	}
+	return http.StatusInternalServerError, domain.ErrCentrifugePublishFailed
+}
+
 type NotificationClient struct {
 	ctx    context.Context
 	logger *log.Log
 	client notification.NotificationClient
 }
 
-func NewNotificationClient(ctx context.Context, logger *log.Log) (*NotificationClient, error) {
+func NewNotificationClient(ctx context.Context, logger *log.Log, creds credentials.TransportCredentials) (*NotificationClient, error) {
 	conn, err := grpc.NewClient(":8081",
-		grpc.WithTransportCredentials(insecure.NewCredentials()))
+		grpc.WithTransportCredentials(creds))
 	if err != nil {
 		return nil, err
 	}
@@ -34,13 +69,15 @@ func NewNotificationClient(ctx context.Context, logger *log.Log) (*NotificationC
 }
 
 func (nc *NotificationClient) Publish() http.HandlerFunc {
-	type notif struct {
-		Title   string `json:"title"`
-		Message string `json:"message"`
-	}
 	type request struct {
-		Channel string `json:"channel"`
-		Data    notif  `json:"data"`
+		Channel     string                   `json:"channel"`
+		GroupID     int64                    `json:"group_id"`
+		SegmentID   int64                    `json:"segment_id"`
+		Data        *models.NotificationData `json:"data"`
+		TemplateKey string                   `json:"template_key"`
+		Variables   map[string]interface{}   `json:"variables"`
+		Locale      string                   `json:"locale"`
+		DeliverAt   string                   `json:"deliver_at"`
 	}
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -50,17 +87,35 @@ func (nc *NotificationClient) Publish() http.HandlerFunc {
 			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
 			return
 		}
+		data, err := protoData(req.Data)
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusUnprocessableEntity, err)
+			return
+		}
+		vars, err := variables(req.Variables)
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusUnprocessableEntity, err)
+			return
+		}
 
 		resp, err := nc.client.Publish(ctx, &notification.PublishRequest{
-			Channel: req.Channel,
-			Data: &notification.Data{
-				Title:   req.Data.Title,
-				Message: req.Data.Message,
-			},
+			Channel:     req.Channel,
+			GroupId:     req.GroupID,
+			SegmentId:   req.SegmentID,
+			Data:        data,
+			TemplateKey: req.TemplateKey,
+			Variables:   vars,
+			Locale:      req.Locale,
+			DeliverAt:   req.DeliverAt,
 		})
 		if err != nil {
 			nc.logger.Errorf(ctx, "Failed to publish notification: %v", err)
-			delivery.HendleError(w, r, http.StatusInternalServerError, domain.ErrCentrifugePublishFailed)
+			code, err := publishStatus(err)
+			delivery.HendleError(w, r, code, err)
+			return
+		}
+		if req.DeliverAt != "" {
+			delivery.HendleRespond(w, r, http.StatusAccepted, resp)
 			return
 		}
 		delivery.HendleRespond(w, r, http.StatusCreated, resp)
@@ -68,12 +123,12 @@ func (nc *NotificationClient) Publish() http.HandlerFunc {
 }
 
 func (nc *NotificationClient) Broadcast() http.HandlerFunc {
-	type notif struct {
-		Title   string `json:"title"`
-		Message string `json:"message"`
-	}
 	type request struct {
-		Data notif `json:"data"`
+		Data        *models.NotificationData `json:"data"`
+		TemplateKey string                   `json:"template_key"`
+		Variables   map[string]interface{}   `json:"variables"`
+		Locale      string                   `json:"locale"`
+		DeliverAt   string                   `json:"deliver_at"`
 	}
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -83,22 +138,105 @@ func (nc *NotificationClient) Broadcast() http.HandlerFunc {
 			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
 			return
 		}
+		data, err := protoData(req.Data)
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusUnprocessableEntity, err)
+			return
+		}
+		vars, err := variables(req.Variables)
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusUnprocessableEntity, err)
+			return
+		}
 
 		resp, err := nc.client.Broadcast(ctx, &notification.BroadcastRequest{
-			Data: &notification.Data{
-				Title:   req.Data.Title,
-				Message: req.Data.Message,
-			},
+			Data:        data,
+			TemplateKey: req.TemplateKey,
+			Variables:   vars,
+			Locale:      req.Locale,
+			DeliverAt:   req.DeliverAt,
 		})
 		if err != nil {
 			nc.logger.Errorf(ctx, "Failed to broadcast notification: %v", err)
-			delivery.HendleError(w, r, http.StatusInternalServerError, domain.ErrCentrifugePublishFailed)
+			code, err := publishStatus(err)
+			delivery.HendleError(w, r, code, err)
 			return
 		}
 		delivery.HendleRespond(w, r, http.StatusCreated, resp)
 	}
 }
 
+func (nc *NotificationClient) ListScheduled() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		resp, err := nc.client.ListScheduled(ctx, &notification.ListScheduledRequest{})
+		if err != nil {
+			nc.logger.Errorf(ctx, "Failed to list scheduled notifications: %v", err)
+			delivery.HendleError(w, r, scheduleStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp.Notifications)
+	}
+}
+
+func (nc *NotificationClient) Reschedule() http.HandlerFunc {
+	type request struct {
+		DeliverAt string `json:"deliver_at"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		var req request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			nc.logger.Errorf(nc.ctx, "Failed to decode request: %v", err)
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		resp, err := nc.client.Reschedule(ctx, &notification.RescheduleRequest{Uid: id, DeliverAt: req.DeliverAt})
+		if err != nil {
+			nc.logger.Errorf(ctx, "Failed to reschedule notification: %v", err)
+			delivery.HendleError(w, r, scheduleStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp.Notification)
+	}
+}
+
+func (nc *NotificationClient) CancelScheduled() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		if _, err := nc.client.CancelScheduled(ctx, &notification.CancelScheduledRequest{Uid: id}); err != nil {
+			nc.logger.Errorf(ctx, "Failed to cancel scheduled notification: %v", err)
+			delivery.HendleError(w, r, scheduleStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusNoContent, nil)
+	}
+}
+
+func scheduleStatus(err error) int {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.InvalidArgument:
+		return http.StatusUnprocessableEntity
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
+
 func (nc *NotificationClient) MarkAsRead() http.HandlerFunc {
 	type request struct {
 		NotificationID int `json:"notification_id"`
@@ -141,3 +279,114 @@ func (nc *NotificationClient) GetNotificationsByFilter() http.HandlerFunc {
 		delivery.HendleRespond(w, r, http.StatusCreated, resp.Notifications)
 	}
 }
+
+func (nc *NotificationClient) CentrifugoConnectionToken() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		resp, err := nc.client.CentrifugoConnectionToken(ctx, &notification.CentrifugoConnectionTokenRequest{})
+		if err != nil {
+			nc.logger.Errorf(ctx, "Failed to get connection token: %v", err)
+			delivery.HendleError(w, r, http.StatusUnauthorized, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp)
+	}
+}
+
+func (nc *NotificationClient) CentrifugoSubscriptionToken() http.HandlerFunc {
+	type request struct {
+		Channel string `json:"channel"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		var req request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			nc.logger.Errorf(nc.ctx, "Failed to decode request: %v", err)
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		resp, err := nc.client.CentrifugoSubscriptionToken(ctx, &notification.CentrifugoSubscriptionTokenRequest{
+			Channel: req.Channel,
+		})
+		if err != nil {
+			nc.logger.Errorf(ctx, "Failed to get subscription token: %v", err)
+			delivery.HendleError(w, r, http.StatusForbidden, domain.ErrChannelForbidden)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp)
+	}
+}
+
+func (nc *NotificationClient) GetPreferences() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		resp, err := nc.client.GetPreferences(ctx, &notification.GetPreferencesRequest{})
+		if err != nil {
+			nc.logger.Errorf(ctx, "Failed to get notification preferences: %v", err)
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp.Preferences)
+	}
+}
+
+func (nc *NotificationClient) UpdatePreferences() http.HandlerFunc {
+	type quietHours struct {
+		Start string `json:"start"`
+		End   string `json:"end"`
+	}
+	type channels struct {
+		Realtime bool `json:"realtime"`
+		Email    bool `json:"email"`
+	}
+	type request struct {
+		MutedCategories []string    `json:"muted_categories"`
+		QuietHours      *quietHours `json:"quiet_hours"`
+		Timezone        string      `json:"timezone"`
+		Locale          string      `json:"locale"`
+		Channels        channels    `json:"channels"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		// UpdatePreferences заменяет все настройки, поэтому поля, которых нет
+		// в запросе, заполняются текущими значениями
+		current, err := nc.client.GetPreferences(ctx, &notification.GetPreferencesRequest{})
+		if err != nil {
+			nc.logger.Errorf(ctx, "Failed to get notification preferences: %v", err)
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		cp := current.GetPreferences()
+		req := &request{
+			MutedCategories: cp.GetMutedCategories(),
+			Timezone:        cp.GetTimezone(),
+			Locale:          cp.GetLocale(),
+			Channels:        channels{Realtime: cp.GetRealtime(), Email: cp.GetEmail()},
+		}
+		if qh := cp.GetQuietHours(); qh != nil {
+			req.QuietHours = &quietHours{Start: qh.Start, End: qh.End}
+		}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+
+		p := &notification.Preferences{
+			MutedCategories: req.MutedCategories,
+			Timezone:        req.Timezone,
+			Locale:          req.Locale,
+			Realtime:        req.Channels.Realtime,
+			Email:           req.Channels.Email,
+		}
+		if req.QuietHours != nil {
+			p.QuietHours = &notification.QuietHours{Start: req.QuietHours.Start, End: req.QuietHours.End}
+		}
+		resp, err := nc.client.UpdatePreferences(ctx, &notification.UpdatePreferencesRequest{Preferences: p})
+		if err != nil {
+			nc.logger.Errorf(ctx, "Failed to update notification preferences: %v", err)
+			delivery.HendleError(w, r, http.StatusUnprocessableEntity, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp.Preferences)
+	}
+}
@@ -0,0 +1,124 @@
+package organization
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	delivery "github.com/DANazavr/RATest/internal/delivery/http"
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/protos/gen/go/organization"
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+type OrganizationClient struct {
+	ctx    context.Context
+	logger *log.Log
+	client organization.OrganizationsClient
+}
+
+func NewOrganizationClient(ctx context.Context, logger *log.Log, creds credentials.TransportCredentials) (*OrganizationClient, error) {
+	conn, err := grpc.NewClient(":8081",
+		grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+	return &OrganizationClient{
+		ctx:    ctx,
+		logger: logger.WithComponent("grpc/client/organization"),
+		client: organization.NewOrganizationsClient(conn),
+	}, nil
+}
+
+func (c *OrganizationClient) Create() http.HandlerFunc {
+	type request struct {
+		Slug string `json:"slug"`
+		Name string `json:"name"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		var req request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			c.logger.Errorf(c.ctx, "Failed to decode request: %v", err)
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		resp, err := c.client.Create(ctx, &organization.CreateRequest{
+			Slug: req.Slug,
+			Name: req.Name,
+		})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to create organization: %v", err)
+			delivery.HendleError(w, r, organizationStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusCreated, resp)
+	}
+}
+
+func (c *OrganizationClient) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		resp, err := c.client.List(ctx, &organization.ListRequest{})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to list organizations: %v", err)
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp.Organizations)
+	}
+}
+
+func (c *OrganizationClient) CreateInvite() http.HandlerFunc {
+	type request struct {
+		Role  string `json:"role"`
+		Email string `json:"email"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		var req request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			c.logger.Errorf(c.ctx, "Failed to decode request: %v", err)
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		resp, err := c.client.CreateInvite(ctx, &organization.CreateInviteRequest{
+			Id:    id,
+			Role:  req.Role,
+			Email: req.Email,
+		})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to create organization invite: %v", err)
+			delivery.HendleError(w, r, organizationStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusCreated, resp)
+	}
+}
+
+func organizationStatus(err error) int {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.InvalidArgument:
+		return http.StatusUnprocessableEntity
+	case codes.AlreadyExists:
+		return http.StatusConflict
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
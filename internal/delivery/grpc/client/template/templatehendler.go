@@ -0,0 +1,204 @@
+package template
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	delivery "github.com/DANazavr/RATest/internal/delivery/http"
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/protos/gen/go/template"
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type TemplateClient struct {
+	ctx    context.Context
+	logger *log.Log
+	client template.TemplatesClient
+}
+
+func NewTemplateClient(ctx context.Context, logger *log.Log, creds credentials.TransportCredentials) (*TemplateClient, error) {
+	conn, err := grpc.NewClient(":8081",
+		grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+	return &TemplateClient{
+		ctx:    ctx,
+		logger: logger.WithComponent("grpc/client/template"),
+		client: template.NewTemplatesClient(conn),
+	}, nil
+}
+
+type variable struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Required bool   `json:"required"`
+}
+
+type content struct {
+	Title     string `json:"title"`
+	Message   string `json:"message"`
+	ActionURL string `json:"action_url"`
+}
+
+type templateRequest struct {
+	Key           string             `json:"key"`
+	Description   string             `json:"description"`
+	Category      string             `json:"category"`
+	Priority      string             `json:"priority"`
+	Icon          string             `json:"icon"`
+	Image         string             `json:"image"`
+	DefaultLocale string             `json:"default_locale"`
+	Variables     []variable         `json:"variables"`
+	Locales       map[string]content `json:"locales"`
+}
+
+func (req *templateRequest) proto() *template.Template {
+	t := &template.Template{
+		Key:           req.Key,
+		Description:   req.Description,
+		Category:      req.Category,
+		Priority:      req.Priority,
+		Icon:          req.Icon,
+		Image:         req.Image,
+		DefaultLocale: req.DefaultLocale,
+		Locales:       make(map[string]*template.Content, len(req.Locales)),
+	}
+	for _, v := range req.Variables {
+		t.Variables = append(t.Variables, &template.Variable{Name: v.Name, Type: v.Type, Required: v.Required})
+	}
+	for loc, c := range req.Locales {
+		t.Locales[loc] = &template.Content{Title: c.Title, Message: c.Message, ActionUrl: c.ActionURL}
+	}
+	return t
+}
+
+func (c *TemplateClient) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		var req templateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			c.logger.Errorf(c.ctx, "Failed to decode request: %v", err)
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		resp, err := c.client.Create(ctx, &template.TemplateRequest{Template: req.proto()})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to create template: %v", err)
+			delivery.HendleError(w, r, templateStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusCreated, resp.Template)
+	}
+}
+
+func (c *TemplateClient) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		resp, err := c.client.List(ctx, &template.ListRequest{})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to list templates: %v", err)
+			delivery.HendleError(w, r, templateStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp.Templates)
+	}
+}
+
+func (c *TemplateClient) Get() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		resp, err := c.client.Get(ctx, &template.KeyRequest{Key: mux.Vars(r)["key"]})
+		if err != nil {
+			delivery.HendleError(w, r, templateStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp.Template)
+	}
+}
+
+func (c *TemplateClient) Update() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		var req templateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			c.logger.Errorf(c.ctx, "Failed to decode request: %v", err)
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		req.Key = mux.Vars(r)["key"]
+		resp, err := c.client.Update(ctx, &template.TemplateRequest{Template: req.proto()})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to update template: %v", err)
+			delivery.HendleError(w, r, templateStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp.Template)
+	}
+}
+
+func (c *TemplateClient) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if _, err := c.client.Delete(ctx, &template.KeyRequest{Key: mux.Vars(r)["key"]}); err != nil {
+			c.logger.Errorf(ctx, "Failed to delete template: %v", err)
+			delivery.HendleError(w, r, templateStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusNoContent, nil)
+	}
+}
+
+func (c *TemplateClient) Preview() http.HandlerFunc {
+	type request struct {
+		Locale    string                 `json:"locale"`
+		Variables map[string]interface{} `json:"variables"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		var req request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			c.logger.Errorf(c.ctx, "Failed to decode request: %v", err)
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		pr := &template.PreviewRequest{Key: mux.Vars(r)["key"], Locale: req.Locale}
+		if req.Variables != nil {
+			vars, err := structpb.NewStruct(req.Variables)
+			if err != nil {
+				delivery.HendleError(w, r, http.StatusUnprocessableEntity, err)
+				return
+			}
+			pr.Variables = vars
+		}
+		resp, err := c.client.Preview(ctx, pr)
+		if err != nil {
+			delivery.HendleError(w, r, templateStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp)
+	}
+}
+
+func templateStatus(err error) int {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.InvalidArgument:
+		return http.StatusUnprocessableEntity
+	case codes.AlreadyExists:
+		return http.StatusConflict
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,189 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	delivery "github.com/DANazavr/RATest/internal/delivery/http"
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/protos/gen/go/user"
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+type UserClient struct {
+	ctx    context.Context
+	logger *log.Log
+	client user.UserClient
+}
+
+func NewUserClient(ctx context.Context, logger *log.Log, creds credentials.TransportCredentials) (*UserClient, error) {
+	conn, err := grpc.NewClient(":8081",
+		grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+	return &UserClient{
+		ctx:    ctx,
+		logger: logger.WithComponent("grpc/client/user"),
+		client: user.NewUserClient(conn),
+	}, nil
+}
+
+func (c *UserClient) GetProfile() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		resp, err := c.client.GetProfile(ctx, &user.GetProfileRequest{})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to get profile: %v", err)
+			delivery.HendleError(w, r, http.StatusNotFound, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp.User)
+	}
+}
+
+func (c *UserClient) ChangePassword() http.HandlerFunc {
+	type request struct {
+		CurrentPassword string `json:"current_password"`
+		NewPassword     string `json:"new_password"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		resp, err := c.client.ChangePassword(ctx, &user.ChangePasswordRequest{
+			CurrentPassword: req.CurrentPassword,
+			NewPassword:     req.NewPassword,
+		})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to change password: %v", err)
+			delivery.HendleError(w, r, accountStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp)
+	}
+}
+
+func (c *UserClient) ChangeEmail() http.HandlerFunc {
+	type request struct {
+		Password string `json:"password"`
+		Email    string `json:"email"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		resp, err := c.client.ChangeEmail(ctx, &user.ChangeEmailRequest{
+			Password: req.Password,
+			Email:    req.Email,
+		})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to change email: %v", err)
+			delivery.HendleError(w, r, accountStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusAccepted, resp)
+	}
+}
+
+func (c *UserClient) DeleteAccount() http.HandlerFunc {
+	type request struct {
+		Password string `json:"password"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		resp, err := c.client.DeleteAccount(ctx, &user.DeleteAccountRequest{Password: req.Password})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to delete account: %v", err)
+			delivery.HendleError(w, r, accountStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp)
+	}
+}
+
+func accountStatus(err error) int {
+	switch status.Code(err) {
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.AlreadyExists, codes.FailedPrecondition:
+		return http.StatusConflict
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	}
+	return http.StatusBadRequest
+}
+
+func (c *UserClient) RequestExport() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		resp, err := c.client.RequestExport(ctx, &user.RequestExportRequest{})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to request data export: %v", err)
+			delivery.HendleError(w, r, accountStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusAccepted, resp.Export)
+	}
+}
+
+func (c *UserClient) ListExports() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		resp, err := c.client.ListExports(ctx, &user.ListExportsRequest{})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to list data exports: %v", err)
+			delivery.HendleError(w, r, accountStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, resp.Exports)
+	}
+}
+
+func (c *UserClient) DownloadExport() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		resp, err := c.client.DownloadExport(ctx, &user.DownloadExportRequest{Id: id})
+		if err != nil {
+			c.logger.Errorf(ctx, "Failed to download data export %d: %v", id, err)
+			delivery.HendleError(w, r, accountStatus(err), err)
+			return
+		}
+		WriteExport(w, resp)
+	}
+}
+
+// WriteExport отдаёт архив выгрузки данных как вложение.
+func WriteExport(w http.ResponseWriter, resp *user.DownloadExportResponse) {
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, resp.Filename))
+	w.Header().Set("Content-Length", strconv.Itoa(len(resp.Archive)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp.Archive)
+}
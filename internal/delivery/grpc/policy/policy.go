@@ -0,0 +1,71 @@
+package policy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	policypb "github.com/DANazavr/RATest/protos/gen/go/policy"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// ServiceInfoProvider - сервер, методы которого нужно проверить (*grpc.Server).
+type ServiceInfoProvider interface {
+	GetServiceInfo() map[string]grpc.ServiceInfo
+}
+
+// Registry - правила доступа к gRPC методам. Правило объявляется в proto
+// опцией метода (ratest.policy.policy): public, authenticated или permission.
+type Registry struct {
+	rules map[string]*policypb.Policy
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		rules: make(map[string]*policypb.Policy),
+	}
+}
+
+// Load читает правила всех методов, зарегистрированных на сервере. Вызывается
+// до начала обслуживания; метод без правила - ошибка.
+func (r *Registry) Load(srv ServiceInfoProvider) error {
+	var missing []string
+	for service, info := range srv.GetServiceInfo() {
+		d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(service))
+		if err != nil {
+			return fmt.Errorf("service %s: %w", service, err)
+		}
+		sd, ok := d.(protoreflect.ServiceDescriptor)
+		if !ok {
+			return fmt.Errorf("%s is not a service", service)
+		}
+		for _, m := range info.Methods {
+			fullMethod := "/" + service + "/" + m.Name
+			md := sd.Methods().ByName(protoreflect.Name(m.Name))
+			if md == nil {
+				missing = append(missing, fullMethod)
+				continue
+			}
+			p, _ := proto.GetExtension(md.Options(), policypb.E_Policy).(*policypb.Policy)
+			if p.GetRule() == nil || (p.GetPermission() == "" && !p.GetPublic() && !p.GetAuthenticated()) {
+				missing = append(missing, fullMethod)
+				continue
+			}
+			r.rules[fullMethod] = p
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("no access policy for methods: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// Lookup возвращает правило метода по info.FullMethod.
+func (r *Registry) Lookup(fullMethod string) (*policypb.Policy, bool) {
+	p, ok := r.rules[fullMethod]
+	return p, ok
+}
@@ -0,0 +1,62 @@
+package policy_test
+
+import (
+	"testing"
+
+	"github.com/DANazavr/RATest/internal/delivery/grpc/policy"
+	"github.com/DANazavr/RATest/internal/domain"
+	adminpb "github.com/DANazavr/RATest/protos/gen/go/admin"
+	apikeypb "github.com/DANazavr/RATest/protos/gen/go/apikey"
+	audiencepb "github.com/DANazavr/RATest/protos/gen/go/audience"
+	authpb "github.com/DANazavr/RATest/protos/gen/go/auth"
+	invitepb "github.com/DANazavr/RATest/protos/gen/go/invite"
+	notificationpb "github.com/DANazavr/RATest/protos/gen/go/notification"
+	organizationpb "github.com/DANazavr/RATest/protos/gen/go/organization"
+	templatepb "github.com/DANazavr/RATest/protos/gen/go/template"
+	userpb "github.com/DANazavr/RATest/protos/gen/go/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestRegistry_Load(t *testing.T) {
+	srv := grpc.NewServer()
+	authpb.RegisterAuthServer(srv, authpb.UnimplementedAuthServer{})
+	notificationpb.RegisterNotificationServer(srv, notificationpb.UnimplementedNotificationServer{})
+	apikeypb.RegisterApiKeysServer(srv, apikeypb.UnimplementedApiKeysServer{})
+	invitepb.RegisterInvitesServer(srv, invitepb.UnimplementedInvitesServer{})
+	userpb.RegisterUserServer(srv, userpb.UnimplementedUserServer{})
+	adminpb.RegisterAdminServer(srv, adminpb.UnimplementedAdminServer{})
+	audiencepb.RegisterAudienceServer(srv, audiencepb.UnimplementedAudienceServer{})
+	organizationpb.RegisterOrganizationsServer(srv, organizationpb.UnimplementedOrganizationsServer{})
+	templatepb.RegisterTemplatesServer(srv, templatepb.UnimplementedTemplatesServer{})
+
+	r := policy.NewRegistry()
+	require.NoError(t, r.Load(srv))
+
+	p, ok := r.Lookup("/ratest.auth.Auth/TokenRefresh")
+	require.True(t, ok)
+	assert.True(t, p.GetPublic())
+
+	p, ok = r.Lookup("/ratest.auth.Auth/Logout")
+	require.True(t, ok)
+	assert.True(t, p.GetAuthenticated())
+
+	p, ok = r.Lookup("/notification.Notification/Publish")
+	require.True(t, ok)
+	assert.Equal(t, domain.PermNotificationPublish, p.GetPermission())
+
+	_, ok = r.Lookup("/ratest.auth.Auth/RefreshToken")
+	assert.False(t, ok)
+}
+
+func TestRegistry_LoadMissingPolicy(t *testing.T) {
+	srv := grpc.NewServer()
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+
+	err := policy.NewRegistry().Load(srv)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "/grpc.health.v1.Health/Check")
+}
@@ -2,38 +2,46 @@ package admin
 
 import (
 	"context"
+	"slices"
 	"strings"
 
 	"github.com/DANazavr/RATest/internal/common/meta"
+	"github.com/DANazavr/RATest/internal/delivery/grpc/policy"
+	"github.com/DANazavr/RATest/internal/delivery/grpc/transport"
 	"github.com/DANazavr/RATest/internal/log"
 	"github.com/DANazavr/RATest/internal/services"
-	"github.com/golang-jwt/jwt/v4"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 type InterceptorAdmin struct {
-	ctx         context.Context
-	logger      *log.Log
-	authService *services.AuthService
+	ctx               context.Context
+	logger            *log.Log
+	authService       *services.AuthService
+	permissionService *services.PermissionService
+	apiKeyService     *services.APIKeyService
+	policies          *policy.Registry
 }
 
-func NewInterceptorAdmin(ctx context.Context, logger *log.Log, as *services.AuthService) *InterceptorAdmin {
+func NewInterceptorAdmin(ctx context.Context, logger *log.Log, as *services.AuthService, ps *services.PermissionService, ks *services.APIKeyService, policies *policy.Registry) *InterceptorAdmin {
 	return &InterceptorAdmin{
-		ctx:         ctx,
-		logger:      logger.WithComponent("grpc/admin/InterceptorAdmin"),
-		authService: as,
+		ctx:               ctx,
+		logger:            logger.WithComponent("grpc/admin/InterceptorAdmin"),
+		authService:       as,
+		permissionService: ps,
+		apiKeyService:     ks,
+		policies:          policies,
 	}
 }
 
 func (ia *InterceptorAdmin) AdminInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-	if info.FullMethod == "/notification.Notification/MarkAsRead" ||
-		info.FullMethod == "/notification.Notification/GetNotificationsByFilter" ||
-		info.FullMethod == "/ratest.auth.Auth/Login" ||
-		info.FullMethod == "/ratest.auth.Auth/RefreshToken" ||
-		info.FullMethod == "/ratest.auth.Auth/Register" {
+	// Методы без права проверяет InterceptorAuth
+	rule, _ := ia.policies.Lookup(info.FullMethod)
+	permission := rule.GetPermission()
+	if permission == "" {
 		return handler(ctx, req)
 	}
 
@@ -43,6 +51,22 @@ func (ia *InterceptorAdmin) AdminInterceptor(ctx context.Context, req interface{
 		return nil, status.Error(codes.Unauthenticated, "metadata is not provided")
 	}
 
+	if key := md.Get("x-api-key"); len(key) > 0 {
+		return ia.authorizeAPIKey(ctx, md, req, info, handler, permission, key[0])
+	}
+
+	// Сервис с клиентским сертификатом вызывает метод от своего имени, если
+	// не передал токен пользователя
+	if p, ok := transport.PrincipalFromContext(ctx); ok && len(md.Get("authorization")) == 0 {
+		if !p.HasPermission(permission) {
+			ia.logger.Warnf(ctx, "Principal %s lacks permission %s", p.Name, permission)
+			return nil, status.Error(codes.PermissionDenied, "unauthorized access")
+		}
+		ia.logger.Infof(ctx, "Principal %s authorized for %s", p.Name, permission)
+		ctx = context.WithValue(ctx, meta.OrgIDKey, p.OrgID)
+		return handler(context.WithValue(ctx, meta.ScopesKey, p.Permissions), req)
+	}
+
 	// Получаем токен из заголовка
 	authHeader := md.Get("authorization")
 	if len(authHeader) == 0 {
@@ -55,25 +79,80 @@ func (ia *InterceptorAdmin) AdminInterceptor(ctx context.Context, req interface{
 		return nil, status.Error(codes.Unauthenticated, "invalid authorization header format")
 	}
 
-	token, err := ia.authService.ParseToken(header[1])
+	claims, err := ia.authService.ValidateAccessToken(header[1])
 	if err != nil {
-		ia.logger.Warnf(ctx, "Failed to parse token: %v", err)
+		ia.logger.Warnf(ctx, "Failed to validate token: %v", err)
 		return nil, status.Error(codes.Unauthenticated, "invalid token")
 	}
 
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		tokenType, ok := claims["type"].(string)
-		if !ok || tokenType != "access" {
-			ia.logger.Warnf(context.WithValue(ctx, meta.UserIDKey, claims["sub"]), "Invalid token type: %v", tokenType)
-			return nil, status.Error(codes.Unauthenticated, "invalid token type")
+	tokenRole, _ := claims["role"].(string)
+	allowed, err := ia.permissionService.HasPermission(tokenRole, permission)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to check permission")
+	}
+	if !allowed {
+		ia.logger.Warnf(context.WithValue(ctx, meta.UserIDKey, claims["sub"]), "Role %v lacks permission %s", tokenRole, permission)
+		return nil, status.Error(codes.PermissionDenied, "unauthorized access")
+	}
+	ctx = context.WithValue(ctx, meta.UserIDKey, claims["sub"])
+	ctx = context.WithValue(ctx, meta.SessionIDKey, claims["sid"])
+	ctx = context.WithValue(ctx, meta.RoleKey, tokenRole)
+	ctx = context.WithValue(ctx, meta.OrgIDKey, services.TokenOrgID(claims))
+	ia.logger.Infof(ctx, "User %v authorized for %s", claims["sub"], permission)
+	return handler(ctx, req)
+}
+
+// authorizeAPIKey авторизует вызов интеграции по API ключу. Если ключ требует
+// подписи, HMAC считается от "<full method>\n<детерминированно сериализованный запрос>".
+func (ia *InterceptorAdmin) authorizeAPIKey(ctx context.Context, md metadata.MD, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler, permission string, key string) (interface{}, error) {
+	k, err := ia.apiKeyService.Authenticate(key)
+	if err != nil {
+		ia.logger.Warnf(ctx, "Failed to authenticate API key: %v", err)
+		return nil, status.Error(codes.Unauthenticated, "invalid API key")
+	}
+
+	if k.RequireSignature {
+		msg, ok := req.(proto.Message)
+		if !ok {
+			return nil, status.Error(codes.Internal, "request is not a proto message")
 		}
-		tokenRole, ok := claims["role"].(string)
-		if !ok || tokenRole != "admin" {
-			ia.logger.Warnf(context.WithValue(ctx, meta.UserIDKey, claims["sub"]), "Unauthorized access attempt with role: %v", tokenRole)
-			return nil, status.Error(codes.PermissionDenied, "unauthorized access")
+		body, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to serialize request")
+		}
+		payload := append([]byte(info.FullMethod+"\n"), body...)
+		if err := ia.apiKeyService.VerifySignature(key, firstValue(md, "x-timestamp"), firstValue(md, "x-signature"), payload); err != nil {
+			ia.logger.Warnf(ctx, "Invalid signature for API key %d", k.ID)
+			return nil, status.Error(codes.Unauthenticated, "invalid request signature")
 		}
-		ctx = context.WithValue(ctx, meta.UserIDKey, claims["sub"])
-		ia.logger.Infof(ctx, "Admin authenticated with user ID: %v", claims["sub"])
 	}
+
+	if !ia.apiKeyService.HasScope(k, permission) {
+		ia.logger.Warnf(ctx, "API key %d lacks scope %s", k.ID, permission)
+		return nil, status.Error(codes.PermissionDenied, "unauthorized access")
+	}
+
+	ctx = context.WithValue(ctx, meta.APIKeyIDKey, k.ID)
+	ctx = context.WithValue(ctx, meta.OrgIDKey, k.OrgID)
+	ctx = context.WithValue(ctx, meta.ScopesKey, k.Scopes)
+	ia.logger.Infof(ctx, "API key %d (%s) authorized for %s", k.ID, k.Name, permission)
 	return handler(ctx, req)
 }
+
+// Allowed сообщает, есть ли право permission у того, от чьего имени выполняется
+// уже авторизованный вызов ctx: у API ключа или сервиса - среди их прав, у
+// пользователя - у его роли.
+func (ia *InterceptorAdmin) Allowed(ctx context.Context, permission string) (bool, error) {
+	if scopes, ok := ctx.Value(meta.ScopesKey).([]string); ok {
+		return slices.Contains(scopes, permission), nil
+	}
+	role, _ := ctx.Value(meta.RoleKey).(string)
+	return ia.permissionService.HasPermission(role, permission)
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
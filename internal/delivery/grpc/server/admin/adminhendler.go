@@ -0,0 +1,170 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/DANazavr/RATest/internal/common/meta"
+	grpcuser "github.com/DANazavr/RATest/internal/delivery/grpc/server/user"
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/services"
+	"github.com/DANazavr/RATest/protos/gen/go/admin"
+	"github.com/DANazavr/RATest/protos/gen/go/user"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type AdminServer struct {
+	ctx               context.Context
+	logger            *log.Log
+	userService       *services.UserService
+	resetService      *services.PasswordResetService
+	dataExportService *services.DataExportService
+	admin.UnimplementedAdminServer
+}
+
+func NewAdminServer(ctx context.Context, logger *log.Log, us *services.UserService, rs *services.PasswordResetService, ds *services.DataExportService) *AdminServer {
+	return &AdminServer{
+		ctx:               ctx,
+		logger:            logger.WithComponent("grpc/admin/AdminServer"),
+		userService:       us,
+		resetService:      rs,
+		dataExportService: ds,
+	}
+}
+
+func Register(gRPC *grpc.Server, adminServer *AdminServer) {
+	admin.RegisterAdminServer(gRPC, adminServer)
+}
+
+func (s *AdminServer) ListUsers(ctx context.Context, req *admin.ListUsersRequest) (*admin.ListUsersResponse, error) {
+	f := &models.UserFilter{
+		Role:   req.Role,
+		Search: req.Query,
+		SortBy: req.Sort,
+		Desc:   req.Desc,
+		Limit:  int(req.Limit),
+	}
+	var err error
+	if f.CreatedFrom, err = services.ParseFilterTime(req.CreatedFrom); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid created_from: %v", err)
+	}
+	if f.CreatedTo, err = services.ParseFilterTime(req.CreatedTo); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid created_to: %v", err)
+	}
+
+	page, err := s.orgUsers(ctx).UsersList(f, req.Cursor)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidFilter) || errors.Is(err, domain.ErrInvalidCursor) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		s.logger.Errorf(ctx, "Failed to list users: %v", err)
+		return nil, status.Errorf(codes.Internal, "Failed to list users: %v", err)
+	}
+	protoUsers := make([]*user.Profile, 0, len(page.Users))
+	for _, u := range page.Users {
+		protoUsers = append(protoUsers, grpcuser.ConvertToProtoProfile(u))
+	}
+	return &admin.ListUsersResponse{Users: protoUsers, NextCursor: page.NextCursor, Total: int64(page.Total)}, nil
+}
+
+func (s *AdminServer) SetUserRole(ctx context.Context, req *admin.SetUserRoleRequest) (*admin.UserResponse, error) {
+	u, err := s.orgUsers(ctx).ChangeRole(ctx, int(req.Id), req.Role)
+	if err != nil {
+		return nil, manageError(err)
+	}
+	return &admin.UserResponse{User: grpcuser.ConvertToProtoProfile(u)}, nil
+}
+
+func (s *AdminServer) DisableUser(ctx context.Context, req *admin.UserRequest) (*admin.UserResponse, error) {
+	u, err := s.orgUsers(ctx).SetDisabled(ctx, int(req.Id), true)
+	if err != nil {
+		return nil, manageError(err)
+	}
+	return &admin.UserResponse{User: grpcuser.ConvertToProtoProfile(u)}, nil
+}
+
+func (s *AdminServer) EnableUser(ctx context.Context, req *admin.UserRequest) (*admin.UserResponse, error) {
+	u, err := s.orgUsers(ctx).SetDisabled(ctx, int(req.Id), false)
+	if err != nil {
+		return nil, manageError(err)
+	}
+	return &admin.UserResponse{User: grpcuser.ConvertToProtoProfile(u)}, nil
+}
+
+func (s *AdminServer) ForcePasswordReset(ctx context.Context, req *admin.UserRequest) (*admin.MessageResponse, error) {
+	if _, err := s.orgUsers(ctx).UsersGetById(int(req.Id)); err != nil {
+		return nil, manageError(err)
+	}
+	if err := s.resetService.ForceReset(ctx, int(req.Id)); err != nil {
+		return nil, manageError(err)
+	}
+	return &admin.MessageResponse{Message: "Password reset, email sent to the user"}, nil
+}
+
+func (s *AdminServer) DeleteUser(ctx context.Context, req *admin.UserRequest) (*admin.MessageResponse, error) {
+	if err := s.orgUsers(ctx).DeleteUser(ctx, int(req.Id)); err != nil {
+		return nil, manageError(err)
+	}
+	return &admin.MessageResponse{Message: "User deleted"}, nil
+}
+
+func (s *AdminServer) ExportUser(ctx context.Context, req *admin.UserRequest) (*user.ExportResponse, error) {
+	var requestedBy *int
+	if userIDstr, ok := ctx.Value(meta.UserIDKey).(string); ok {
+		if userID, err := strconv.Atoi(userIDstr); err == nil {
+			requestedBy = &userID
+		}
+	}
+	e, err := s.orgExports(ctx).Request(ctx, int(req.Id), requestedBy)
+	if err != nil {
+		return nil, grpcuser.ExportError(err)
+	}
+	return &user.ExportResponse{Export: grpcuser.ConvertToProtoExport(e)}, nil
+}
+
+func (s *AdminServer) ListUserExports(ctx context.Context, req *admin.UserRequest) (*user.ListExportsResponse, error) {
+	exports, err := s.orgExports(ctx).Exports(int(req.Id))
+	if err != nil {
+		return nil, grpcuser.ExportError(err)
+	}
+	return grpcuser.ConvertToProtoExports(exports), nil
+}
+
+func (s *AdminServer) DownloadExport(ctx context.Context, req *user.DownloadExportRequest) (*user.DownloadExportResponse, error) {
+	archive, err := s.orgExports(ctx).Archive(int(req.Id))
+	if err != nil {
+		return nil, grpcuser.ExportError(err)
+	}
+	return &user.DownloadExportResponse{Filename: grpcuser.ExportFilename(int(req.Id)), Archive: archive}, nil
+}
+
+// orgExports возвращает сервис выгрузок организации администратора.
+func (s *AdminServer) orgExports(ctx context.Context) *services.DataExportService {
+	orgID, _ := ctx.Value(meta.OrgIDKey).(int)
+	return s.dataExportService.ForOrg(orgID)
+}
+
+// orgUsers возвращает сервис пользователей организации администратора.
+func (s *AdminServer) orgUsers(ctx context.Context) *services.UserService {
+	orgID, _ := ctx.Value(meta.OrgIDKey).(int)
+	return s.userService.ForOrg(orgID)
+}
+
+func manageError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrRecordNotFound), errors.Is(err, domain.ErrUserNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrUnknownRole):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrSuperAdminRole):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, domain.ErrLastAdmin):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Errorf(codes.Internal, "User update failed: %v", err)
+}
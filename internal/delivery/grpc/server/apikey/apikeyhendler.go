@@ -0,0 +1,113 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/common/meta"
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/services"
+	"github.com/DANazavr/RATest/protos/gen/go/apikey"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type APIKeyServer struct {
+	ctx           context.Context
+	logger        *log.Log
+	apiKeyService *services.APIKeyService
+	apikey.UnimplementedApiKeysServer
+}
+
+func NewAPIKeyServer(ctx context.Context, logger *log.Log, ks *services.APIKeyService) *APIKeyServer {
+	return &APIKeyServer{
+		ctx:           ctx,
+		logger:        logger.WithComponent("grpc/apikey/APIKeyServer"),
+		apiKeyService: ks,
+	}
+}
+
+func Register(gRPC *grpc.Server, apiKeyServer *APIKeyServer) {
+	apikey.RegisterApiKeysServer(gRPC, apiKeyServer)
+}
+
+func (s *APIKeyServer) Create(ctx context.Context, req *apikey.CreateRequest) (*apikey.CreateResponse, error) {
+	k := &models.APIKey{
+		Name:             req.Name,
+		Scopes:           req.Scopes,
+		RequireSignature: req.RequireSignature,
+	}
+	if userIDstr, ok := ctx.Value(meta.UserIDKey).(string); ok {
+		if userID, err := strconv.Atoi(userIDstr); err == nil {
+			k.CreatedBy = &userID
+		}
+	}
+
+	role, _ := ctx.Value(meta.RoleKey).(string)
+	key, err := s.orgAPIKeys(ctx).Create(k, role)
+	if errors.Is(err, domain.ErrScopeNotGranted) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	} else if err != nil {
+		s.logger.Errorf(ctx, "Failed to create API key: %v", err)
+		return nil, status.Errorf(codes.InvalidArgument, "Failed to create API key: %v", err)
+	}
+	return &apikey.CreateResponse{ApiKey: convertToProtoAPIKey(k), Key: key}, nil
+}
+
+func (s *APIKeyServer) List(ctx context.Context, req *apikey.ListRequest) (*apikey.ListResponse, error) {
+	keys, err := s.orgAPIKeys(ctx).Get()
+	if err != nil {
+		s.logger.Errorf(ctx, "Failed to list API keys: %v", err)
+		return nil, status.Errorf(codes.Internal, "Failed to list API keys: %v", err)
+	}
+	protoKeys := make([]*apikey.ApiKey, 0, len(keys))
+	for _, k := range keys {
+		protoKeys = append(protoKeys, convertToProtoAPIKey(k))
+	}
+	return &apikey.ListResponse{ApiKeys: protoKeys}, nil
+}
+
+func (s *APIKeyServer) Revoke(ctx context.Context, req *apikey.RevokeRequest) (*apikey.RevokeResponse, error) {
+	if err := s.orgAPIKeys(ctx).Revoke(int(req.Id)); err != nil {
+		if errors.Is(err, domain.ErrAPIKeyNotFound) {
+			return nil, status.Errorf(codes.NotFound, "API key %d not found", req.Id)
+		}
+		return nil, status.Errorf(codes.Internal, "Failed to revoke API key: %v", err)
+	}
+	return &apikey.RevokeResponse{Message: "API key revoked successfully"}, nil
+}
+
+func convertToProtoAPIKey(k *models.APIKey) *apikey.ApiKey {
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+	var createdBy int64
+	if k.CreatedBy != nil {
+		createdBy = int64(*k.CreatedBy)
+	}
+	return &apikey.ApiKey{
+		Id:               int64(k.ID),
+		Name:             k.Name,
+		Prefix:           k.Prefix,
+		Scopes:           k.Scopes,
+		RequireSignature: k.RequireSignature,
+		CreatedBy:        createdBy,
+		CreatedAt:        k.CreatedAt.Format(time.RFC3339),
+		LastUsedAt:       formatTime(k.LastUsedAt),
+		RevokedAt:        formatTime(k.RevokedAt),
+	}
+}
+
+// orgAPIKeys возвращает сервис ключей организации, от имени которой сделан запрос.
+func (s *APIKeyServer) orgAPIKeys(ctx context.Context) *services.APIKeyService {
+	orgID, _ := ctx.Value(meta.OrgIDKey).(int)
+	return s.apiKeyService.ForOrg(orgID)
+}
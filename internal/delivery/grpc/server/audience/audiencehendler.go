@@ -0,0 +1,250 @@
+package audience
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/common/meta"
+	grpcuser "github.com/DANazavr/RATest/internal/delivery/grpc/server/user"
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/services"
+	"github.com/DANazavr/RATest/protos/gen/go/audience"
+	"github.com/DANazavr/RATest/protos/gen/go/user"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type AudienceServer struct {
+	ctx             context.Context
+	logger          *log.Log
+	audienceService *services.AudienceService
+	audience.UnimplementedAudienceServer
+}
+
+func NewAudienceServer(ctx context.Context, logger *log.Log, au *services.AudienceService) *AudienceServer {
+	return &AudienceServer{
+		ctx:             ctx,
+		logger:          logger.WithComponent("grpc/audience/AudienceServer"),
+		audienceService: au,
+	}
+}
+
+func Register(gRPC *grpc.Server, audienceServer *AudienceServer) {
+	audience.RegisterAudienceServer(gRPC, audienceServer)
+}
+
+func (s *AudienceServer) CreateGroup(ctx context.Context, req *audience.CreateGroupRequest) (*audience.GroupResponse, error) {
+	g := &models.Group{
+		Name:        req.Name,
+		Description: req.Description,
+		CreatedBy:   currentUserID(ctx),
+	}
+	if err := s.orgAudience(ctx).CreateGroup(g); err != nil {
+		return nil, audienceError(err)
+	}
+	return &audience.GroupResponse{Group: convertToProtoGroup(g)}, nil
+}
+
+func (s *AudienceServer) ListGroups(ctx context.Context, req *audience.ListGroupsRequest) (*audience.ListGroupsResponse, error) {
+	groups, err := s.orgAudience(ctx).Groups()
+	if err != nil {
+		s.logger.Errorf(ctx, "Failed to list groups: %v", err)
+		return nil, status.Errorf(codes.Internal, "Failed to list groups: %v", err)
+	}
+	resp := &audience.ListGroupsResponse{Groups: make([]*audience.Group, 0, len(groups))}
+	for _, g := range groups {
+		resp.Groups = append(resp.Groups, convertToProtoGroup(g))
+	}
+	return resp, nil
+}
+
+func (s *AudienceServer) DeleteGroup(ctx context.Context, req *audience.IdRequest) (*audience.MessageResponse, error) {
+	if err := s.orgAudience(ctx).DeleteGroup(int(req.Id)); err != nil {
+		return nil, audienceError(err)
+	}
+	return &audience.MessageResponse{Message: "Group deleted"}, nil
+}
+
+func (s *AudienceServer) ListGroupMembers(ctx context.Context, req *audience.IdRequest) (*audience.MembersResponse, error) {
+	users, err := s.orgAudience(ctx).GroupMembers(int(req.Id))
+	if err != nil {
+		return nil, audienceError(err)
+	}
+	return convertToProtoMembers(users), nil
+}
+
+func (s *AudienceServer) AddGroupMembers(ctx context.Context, req *audience.AddGroupMembersRequest) (*audience.AddGroupMembersResponse, error) {
+	ids := make([]int, 0, len(req.UserIds))
+	for _, id := range req.UserIds {
+		ids = append(ids, int(id))
+	}
+	n, err := s.orgAudience(ctx).AddGroupMembers(int(req.Id), ids)
+	if err != nil {
+		return nil, audienceError(err)
+	}
+	return &audience.AddGroupMembersResponse{Added: int64(n)}, nil
+}
+
+func (s *AudienceServer) RemoveGroupMember(ctx context.Context, req *audience.RemoveGroupMemberRequest) (*audience.MessageResponse, error) {
+	if err := s.orgAudience(ctx).RemoveGroupMember(int(req.Id), int(req.UserId)); err != nil {
+		return nil, audienceError(err)
+	}
+	return &audience.MessageResponse{Message: "Member removed"}, nil
+}
+
+func (s *AudienceServer) CreateSegment(ctx context.Context, req *audience.CreateSegmentRequest) (*audience.SegmentResponse, error) {
+	rules, err := convertFromProtoRules(req.Rules)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid segment rules: %v", err)
+	}
+	seg := &models.Segment{
+		Name:        req.Name,
+		Description: req.Description,
+		Rules:       rules,
+		CreatedBy:   currentUserID(ctx),
+	}
+	if err := s.orgAudience(ctx).CreateSegment(seg); err != nil {
+		return nil, audienceError(err)
+	}
+	return &audience.SegmentResponse{Segment: convertToProtoSegment(seg)}, nil
+}
+
+func (s *AudienceServer) ListSegments(ctx context.Context, req *audience.ListSegmentsRequest) (*audience.ListSegmentsResponse, error) {
+	segments, err := s.orgAudience(ctx).Segments()
+	if err != nil {
+		s.logger.Errorf(ctx, "Failed to list segments: %v", err)
+		return nil, status.Errorf(codes.Internal, "Failed to list segments: %v", err)
+	}
+	resp := &audience.ListSegmentsResponse{Segments: make([]*audience.Segment, 0, len(segments))}
+	for _, seg := range segments {
+		resp.Segments = append(resp.Segments, convertToProtoSegment(seg))
+	}
+	return resp, nil
+}
+
+func (s *AudienceServer) DeleteSegment(ctx context.Context, req *audience.IdRequest) (*audience.MessageResponse, error) {
+	if err := s.orgAudience(ctx).DeleteSegment(int(req.Id)); err != nil {
+		return nil, audienceError(err)
+	}
+	return &audience.MessageResponse{Message: "Segment deleted"}, nil
+}
+
+func (s *AudienceServer) ListSegmentMembers(ctx context.Context, req *audience.IdRequest) (*audience.MembersResponse, error) {
+	users, err := s.orgAudience(ctx).SegmentMembers(int(req.Id))
+	if err != nil {
+		return nil, audienceError(err)
+	}
+	return convertToProtoMembers(users), nil
+}
+
+func currentUserID(ctx context.Context) *int {
+	if userIDstr, ok := ctx.Value(meta.UserIDKey).(string); ok {
+		if userID, err := strconv.Atoi(userIDstr); err == nil {
+			return &userID
+		}
+	}
+	return nil
+}
+
+func audienceError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrGroupNotFound),
+		errors.Is(err, domain.ErrSegmentNotFound),
+		errors.Is(err, domain.ErrUserNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrAudienceNameTaken):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrUnknownRole), errors.Is(err, domain.ErrInvalidFilter), errors.As(err, new(validation.Errors)):
+		return status.Errorf(codes.InvalidArgument, "Audience update failed: %v", err)
+	}
+	return status.Errorf(codes.Internal, "Audience update failed: %v", err)
+}
+
+func convertToProtoGroup(g *models.Group) *audience.Group {
+	pg := &audience.Group{
+		Id:          int64(g.ID),
+		Name:        g.Name,
+		Description: g.Description,
+		CreatedAt:   g.CreatedAt.Format(time.RFC3339),
+		Members:     int64(g.Members),
+	}
+	if g.CreatedBy != nil {
+		pg.CreatedBy = int64(*g.CreatedBy)
+	}
+	return pg
+}
+
+func convertToProtoSegment(s *models.Segment) *audience.Segment {
+	rules := &audience.SegmentRules{
+		Role:  s.Rules.Role,
+		Query: s.Rules.Search,
+	}
+	if s.Rules.EmailVerified != nil {
+		rules.EmailVerified = audience.EmailVerified_EMAIL_VERIFIED_NO
+		if *s.Rules.EmailVerified {
+			rules.EmailVerified = audience.EmailVerified_EMAIL_VERIFIED_YES
+		}
+	}
+	if s.Rules.CreatedFrom != nil {
+		rules.CreatedFrom = s.Rules.CreatedFrom.Format(time.RFC3339)
+	}
+	if s.Rules.CreatedTo != nil {
+		rules.CreatedTo = s.Rules.CreatedTo.Format(time.RFC3339)
+	}
+	ps := &audience.Segment{
+		Id:          int64(s.ID),
+		Name:        s.Name,
+		Description: s.Description,
+		Rules:       rules,
+		CreatedAt:   s.CreatedAt.Format(time.RFC3339),
+	}
+	if s.CreatedBy != nil {
+		ps.CreatedBy = int64(*s.CreatedBy)
+	}
+	return ps
+}
+
+func convertFromProtoRules(r *audience.SegmentRules) (models.SegmentRules, error) {
+	rules := models.SegmentRules{}
+	if r == nil {
+		return rules, nil
+	}
+	rules.Role = r.Role
+	rules.Search = r.Query
+	switch r.EmailVerified {
+	case audience.EmailVerified_EMAIL_VERIFIED_YES:
+		verified := true
+		rules.EmailVerified = &verified
+	case audience.EmailVerified_EMAIL_VERIFIED_NO:
+		verified := false
+		rules.EmailVerified = &verified
+	}
+	var err error
+	if rules.CreatedFrom, err = services.ParseFilterTime(r.CreatedFrom); err != nil {
+		return rules, err
+	}
+	if rules.CreatedTo, err = services.ParseFilterTime(r.CreatedTo); err != nil {
+		return rules, err
+	}
+	return rules, nil
+}
+
+func convertToProtoMembers(users []*models.User) *audience.MembersResponse {
+	resp := &audience.MembersResponse{Users: make([]*user.Profile, 0, len(users))}
+	for _, u := range users {
+		resp.Users = append(resp.Users, grpcuser.ConvertToProtoProfile(u))
+	}
+	return resp
+}
+
+// orgAudience возвращает сервис групп и сегментов организации администратора.
+func (s *AudienceServer) orgAudience(ctx context.Context) *services.AudienceService {
+	orgID, _ := ctx.Value(meta.OrgIDKey).(int)
+	return s.audienceService.ForOrg(orgID)
+}
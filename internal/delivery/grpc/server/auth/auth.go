@@ -5,9 +5,9 @@ import (
 	"strings"
 
 	"github.com/DANazavr/RATest/internal/common/meta"
+	"github.com/DANazavr/RATest/internal/delivery/grpc/policy"
 	"github.com/DANazavr/RATest/internal/log"
 	"github.com/DANazavr/RATest/internal/services"
-	"github.com/golang-jwt/jwt/v4"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -18,22 +18,26 @@ type InterceptorAuth struct {
 	ctx         context.Context
 	logger      *log.Log
 	authService *services.AuthService
+	policies    *policy.Registry
 }
 
-func NewInterceptorAuth(ctx context.Context, logger *log.Log, as *services.AuthService) *InterceptorAuth {
+func NewInterceptorAuth(ctx context.Context, logger *log.Log, as *services.AuthService, policies *policy.Registry) *InterceptorAuth {
 	return &InterceptorAuth{
 		ctx:         ctx,
 		logger:      logger.WithComponent("grpc/auth/InterceptorAuth"),
 		authService: as,
+		policies:    policies,
 	}
 }
 
 func (ia *InterceptorAuth) AuthInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-	if info.FullMethod == "/ratest.auth.Auth/Login" ||
-		info.FullMethod == "/ratest.auth.Auth/RefreshToken" ||
-		info.FullMethod == "/ratest.auth.Auth/Register" ||
-		info.FullMethod == "/notification.Notification/Publish" ||
-		info.FullMethod == "/notification.Notification/Broadcast" {
+	p, ok := ia.policies.Lookup(info.FullMethod)
+	if !ok {
+		ia.logger.Errorf(ctx, "No access policy for %s", info.FullMethod)
+		return nil, status.Error(codes.PermissionDenied, "unauthorized access")
+	}
+	// Методы с правом проверяет InterceptorAdmin
+	if p.GetPublic() || p.GetPermission() != "" {
 		return handler(ctx, req)
 	}
 
@@ -55,20 +59,16 @@ func (ia *InterceptorAuth) AuthInterceptor(ctx context.Context, req interface{},
 		return nil, status.Error(codes.Unauthenticated, "invalid authorization header format")
 	}
 
-	token, err := ia.authService.ParseToken(header[1])
+	claims, err := ia.authService.ValidateAccessToken(header[1])
 	if err != nil {
-		ia.logger.Warnf(ctx, "Failed to parse token: %v", err)
+		ia.logger.Warnf(ctx, "Failed to validate token: %v", err)
 		return nil, status.Error(codes.Unauthenticated, "invalid token")
 	}
 
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		tokenType, ok := claims["type"].(string)
-		if !ok || tokenType != "access" {
-			ia.logger.Warnf(ctx, "Invalid token type: %v", tokenType)
-			return nil, status.Error(codes.Unauthenticated, "invalid token type")
-		}
-		ctx = context.WithValue(ctx, meta.UserIDKey, claims["sub"])
-		ia.logger.Infof(ctx, "Authenticated user ID: %v", claims["sub"])
-	}
+	ctx = context.WithValue(ctx, meta.UserIDKey, claims["sub"])
+	ctx = context.WithValue(ctx, meta.SessionIDKey, claims["sid"])
+	ctx = context.WithValue(ctx, meta.RoleKey, claims["role"])
+	ctx = context.WithValue(ctx, meta.OrgIDKey, services.TokenOrgID(claims))
+	ia.logger.Infof(ctx, "Authenticated user ID: %v", claims["sub"])
 	return handler(ctx, req)
 }
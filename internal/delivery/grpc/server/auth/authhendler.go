@@ -2,43 +2,53 @@ package auth
 
 import (
 	"context"
+	"errors"
+	"net"
 	"strconv"
 
+	"github.com/DANazavr/RATest/internal/common/meta"
+	"github.com/DANazavr/RATest/internal/domain"
 	"github.com/DANazavr/RATest/internal/domain/models"
 	"github.com/DANazavr/RATest/internal/log"
 	"github.com/DANazavr/RATest/internal/services"
 	"github.com/DANazavr/RATest/protos/gen/go/auth"
-	"github.com/DANazavr/RATest/protos/gen/go/notification"
-	"github.com/golang-jwt/jwt/v4"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 type AuthServer struct {
-	ctx                context.Context
-	logger             *log.Log
-	notificationClient notification.NotificationClient
-	userService        *services.UserService
-	authService        *services.AuthService
+	ctx                 context.Context
+	logger              *log.Log
+	userService         *services.UserService
+	authService         *services.AuthService
+	inviteService       *services.InviteService
+	resetService        *services.PasswordResetService
+	verifyService       *services.EmailVerificationService
+	throttleService     *services.LoginThrottleService
+	oidcService         *services.OIDCService
+	notificationService *services.NotificationService
+	templateService     *services.TemplateService
 	auth.UnimplementedAuthServer
 }
 
-func NewAuthServer(ctx context.Context, logger *log.Log, us *services.UserService, as *services.AuthService) *AuthServer {
-	conn, err := grpc.NewClient(":8081",
-		grpc.WithTransportCredentials(insecure.NewCredentials()))
-	if err != nil {
-		logger.Fatalf(ctx, "Failed to connect to notification service: %v", err)
-	}
-	// defer conn.Close()
-	notificationClient := notification.NewNotificationClient(conn)
-
+func NewAuthServer(ctx context.Context, logger *log.Log, us *services.UserService, as *services.AuthService, is *services.InviteService, rs *services.PasswordResetService, vs *services.EmailVerificationService, ls *services.LoginThrottleService, oc *services.OIDCService, ns *services.NotificationService, ts *services.TemplateService) *AuthServer {
 	return &AuthServer{
-		ctx:                ctx,
-		logger:             logger.WithComponent("grpc/auth/AuthServer"),
-		userService:        us,
-		authService:        as,
-		notificationClient: notificationClient,
+		ctx:                 ctx,
+		logger:              logger.WithComponent("grpc/auth/AuthServer"),
+		userService:         us,
+		authService:         as,
+		inviteService:       is,
+		resetService:        rs,
+		verifyService:       vs,
+		throttleService:     ls,
+		oidcService:         oc,
+		notificationService: ns,
+		templateService:     ts,
 	}
 }
 
@@ -51,35 +61,106 @@ func (a *AuthServer) Register(ctx context.Context, req *auth.RegisterRequest) (*
 		Username: req.Username,
 		Email:    req.Email,
 		Password: req.Password,
-		Role:     req.Role,
+		Role:     domain.RoleUser,
+		OrgID:    domain.DefaultOrgID,
 	}
-	if err := a.userService.UsersCreate(ctx, u); err != nil {
+	if req.InviteToken != "" {
+		inv, err := a.inviteService.Resolve(req.InviteToken)
+		if err != nil {
+			a.logger.Warnf(ctx, "Invite rejected: %v", err)
+			return nil, status.Errorf(codes.PermissionDenied, "Invalid invite: %v", err)
+		}
+		if err := a.userService.UsersCreateByInvite(ctx, u, inv); err != nil {
+			a.logger.Errorf(ctx, "Failed to create user by invite: %v", err)
+			return nil, status.Errorf(codes.InvalidArgument, "Failed to register user: %v", err)
+		}
+	} else if err := a.userService.UsersCreate(ctx, u); err != nil {
 		a.logger.Errorf(ctx, "Failed to create user: %v", err)
 		return nil, status.Errorf(status.Code(err), "Failed to register user: %v", err)
 	}
-
-	// Publish a notification about the new user registration
-	if _, err := a.notificationClient.Publish(ctx, &notification.PublishRequest{
-		Channel: "notifications:user#" + strconv.Itoa(u.ID),
-		Data: &notification.Data{
-			Title:   "New User Registration",
-			Message: "User " + u.Username + " has Login successfully.",
-		},
-	}); err != nil {
-		a.logger.Errorf(ctx, "Failed to publish notification: %v", err)
-		return nil, status.Errorf(status.Code(err), "Failed to send notification: %v", err)
+	if err := a.verifyService.Send(ctx, u); err != nil {
+		a.logger.Errorf(ctx, "Failed to send verification email to user %d: %v", u.ID, err)
 	}
+
+	a.welcome(ctx, u)
 	return &auth.RegisterResponse{Message: "Registration successful"}, nil
 }
 
+// welcome отправляет новому пользователю уведомление user.registered по
+// шаблону его организации. Учётная запись к этому моменту уже создана,
+// поэтому ошибка только логируется.
+func (a *AuthServer) welcome(ctx context.Context, u *models.User) {
+	content, err := a.templateService.ForOrg(u.OrgID).Resolve(services.RegisteredTemplateKey, "", map[string]interface{}{"username": u.Username}, nil)
+	if err != nil {
+		a.logger.Errorf(ctx, "Failed to build registration notification for user %d: %v", u.ID, err)
+		return
+	}
+	if _, err := a.notificationService.ForOrg(u.OrgID).Fanout([]*models.User{u}, content, nil, false, nil, a.verifyService.CanDeliver); err != nil {
+		a.logger.Errorf(ctx, "Failed to publish registration notification for user %d: %v", u.ID, err)
+	}
+}
+
 func (a *AuthServer) Login(ctx context.Context, req *auth.LoginRequest) (*auth.LoginResponse, error) {
+	attempt := &models.LoginAttempt{
+		Username:  req.Username,
+		IP:        clientIP(ctx),
+		UserAgent: userAgent(ctx),
+	}
+	if retry, err := a.throttleService.Check(attempt.Username, attempt.IP); err != nil {
+		if errors.Is(err, domain.ErrTooManyLoginAttempts) {
+			attempt.Reason = models.LoginReasonThrottled
+			a.throttleService.Record(attempt)
+			st, _ := status.New(codes.ResourceExhausted, err.Error()).WithDetails(&errdetails.RetryInfo{
+				RetryDelay: durationpb.New(retry),
+			})
+			return nil, st.Err()
+		}
+		return nil, status.Errorf(codes.Internal, "Failed to check login attempts: %v", err)
+	}
+
 	u, err := a.userService.UsersGetByUsername(req.Username)
 	if err != nil || !a.userService.ComparePassword(u, req.Password) {
+		attempt.Reason = models.LoginReasonInvalidCredentials
+		a.throttleService.Record(attempt)
 		a.logger.Errorf(ctx, "Failed to login user: %v", err)
 		return nil, status.Errorf(status.Code(err), "Invalid username or password")
 	}
+	return a.completeLogin(ctx, u, attempt)
+}
+
+// completeLogin завершает вход пользователя u, личность которого уже
+// подтверждена: проверяет email и второй фактор и выдаёт токены.
+func (a *AuthServer) completeLogin(ctx context.Context, u *models.User, attempt *models.LoginAttempt) (*auth.LoginResponse, error) {
+	attempt.UserID = &u.ID
+	if err := a.userService.CheckActive(u); err != nil {
+		attempt.Reason = models.LoginReasonDisabled
+		a.throttleService.Record(attempt)
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	if err := a.verifyService.CheckLogin(u); err != nil {
+		attempt.Reason = models.LoginReasonEmailNotVerified
+		a.throttleService.Record(attempt)
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	// Со вторым фактором токены выдаются только после LoginMfa
+	enabled, err := a.userService.MFAEnabled(u)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to check two-factor authentication: %v", err)
+	}
+	if enabled || a.userService.MFARequired(u) {
+		attempt.Reason = models.LoginReasonMFARequired
+		a.throttleService.Record(attempt)
+		mfaToken, err := a.authService.GenerateMFAToken(u.ID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to generate two-factor token: %v", err)
+		}
+		return &auth.LoginResponse{MfaRequired: true, MfaToken: mfaToken, MfaEnrollmentRequired: !enabled}, nil
+	}
+	attempt.Reason = models.LoginReasonSuccess
+	a.throttleService.Record(attempt)
 
-	atoken, rtoken, err := a.authService.GenerateTokens(u.ID, u.Role)
+	atoken, rtoken, err := a.authService.GenerateTokens(u)
 	if err != nil {
 		a.logger.Errorf(ctx, "Failed to generate tokens: %v", err)
 		return nil, status.Errorf(status.Code(err), "Failed to generate tokens: %v", err)
@@ -87,38 +168,326 @@ func (a *AuthServer) Login(ctx context.Context, req *auth.LoginRequest) (*auth.L
 	return &auth.LoginResponse{AccessToken: atoken, RefreshToken: rtoken}, nil
 }
 
+func (a *AuthServer) OidcStart(ctx context.Context, req *auth.OidcStartRequest) (*auth.OidcStartResponse, error) {
+	url, state, err := a.oidcService.Start(ctx, req.Provider)
+	if err != nil {
+		return nil, oidcError(err)
+	}
+	return &auth.OidcStartResponse{AuthorizationUrl: url, State: state}, nil
+}
+
+func (a *AuthServer) OidcCallback(ctx context.Context, req *auth.OidcCallbackRequest) (*auth.LoginResponse, error) {
+	u, err := a.oidcService.Callback(ctx, req.Provider, req.Code, req.State, req.BrowserState)
+	if err != nil {
+		return nil, oidcError(err)
+	}
+	return a.completeLogin(ctx, u, &models.LoginAttempt{
+		Username:  u.Username,
+		IP:        clientIP(ctx),
+		UserAgent: userAgent(ctx),
+	})
+}
+
+func oidcError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrUnknownOIDCProvider):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidOIDCState), errors.Is(err, domain.ErrOIDCLoginFailed):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, domain.ErrOIDCEmailTaken):
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+	return status.Errorf(codes.InvalidArgument, "External login failed: %v", err)
+}
+
 func (a *AuthServer) TokenRefresh(ctx context.Context, req *auth.TokenRefreshRequest) (*auth.TokenRefreshResponse, error) {
-	var id int64
-	token, err := a.authService.ParseToken(req.RefreshToken)
+	session, err := a.authService.ValidateRefreshToken(req.RefreshToken)
 	if err != nil {
-		return nil, status.Errorf(status.Code(err), "Invalid refresh token: %v", err)
+		a.logger.Warnf(ctx, "Refresh token rejected: %v", err)
+		return nil, status.Errorf(codes.Unauthenticated, "Invalid refresh token: %v", err)
 	}
 
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		tokenType, ok := claims["type"].(string)
-		if !ok || tokenType != "refresh" {
-			return nil, status.Errorf(status.Code(err), "Invalid token type: %v", tokenType)
+	u, err := a.userService.UsersGetById(session.UserID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "User not found: %v", err)
+	}
+	if err := a.userService.CheckActive(u); err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	atoken, rtoken, err := a.authService.RotateTokens(session, u)
+	if err != nil {
+		if errors.Is(err, domain.ErrRefreshTokenReused) {
+			return nil, status.Errorf(codes.Unauthenticated, "Invalid refresh token: %v", err)
 		}
-		userID, ok := claims["sub"].(string)
-		userid, err := strconv.ParseInt(userID, 10, 64)
-		if err != nil {
-			a.logger.Info(a.ctx, "failed to parse userID from token")
-			return nil, status.Errorf(status.Code(err), "Invalid user ID in token: %v", userID)
+		return nil, status.Errorf(codes.Internal, "Failed to generate tokens: %v", err)
+	}
+	return &auth.TokenRefreshResponse{AccessToken: atoken, RefreshToken: rtoken}, nil
+}
+
+func (a *AuthServer) Logout(ctx context.Context, req *auth.LogoutRequest) (*auth.LogoutResponse, error) {
+	familyID, ok := ctx.Value(meta.SessionIDKey).(string)
+	if !ok || familyID == "" {
+		return nil, status.Error(codes.Unauthenticated, "Session is not provided")
+	}
+	if err := a.authService.Logout(familyID); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to logout: %v", err)
+	}
+	return &auth.LogoutResponse{Message: "Logged out successfully"}, nil
+}
+
+func (a *AuthServer) LogoutAll(ctx context.Context, req *auth.LogoutAllRequest) (*auth.LogoutAllResponse, error) {
+	userIDstr, ok := ctx.Value(meta.UserIDKey).(string)
+	if !ok || userIDstr == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid user ID in context: %v", userIDstr)
+	}
+	userID, err := strconv.Atoi(userIDstr)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid user ID: %v", userIDstr)
+	}
+	if err := a.authService.LogoutAll(userID); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to logout: %v", err)
+	}
+	return &auth.LogoutAllResponse{Message: "Logged out of all sessions successfully"}, nil
+}
+
+func (a *AuthServer) Jwks(ctx context.Context, req *auth.JwksRequest) (*auth.JwksResponse, error) {
+	set := a.authService.JWKS()
+	keys := make([]*auth.Jwk, 0, len(set.Keys))
+	for _, k := range set.Keys {
+		keys = append(keys, &auth.Jwk{
+			Kid: k.Kid,
+			Kty: k.Kty,
+			Alg: k.Alg,
+			Use: k.Use,
+			N:   k.N,
+			E:   k.E,
+			Crv: k.Crv,
+			X:   k.X,
+		})
+	}
+	return &auth.JwksResponse{Keys: keys}, nil
+}
+
+func (a *AuthServer) RequestPasswordReset(ctx context.Context, req *auth.RequestPasswordResetRequest) (*auth.RequestPasswordResetResponse, error) {
+	if err := a.resetService.RequestReset(ctx, req.Email); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to request password reset: %v", err)
+	}
+	return &auth.RequestPasswordResetResponse{Message: "If the email is registered, a reset link has been sent"}, nil
+}
+
+func (a *AuthServer) ConfirmPasswordReset(ctx context.Context, req *auth.ConfirmPasswordResetRequest) (*auth.ConfirmPasswordResetResponse, error) {
+	if err := a.resetService.ConfirmReset(ctx, req.Token, req.Password); err != nil {
+		if errors.Is(err, domain.ErrInvalidResetToken) {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid reset token: %v", err)
+		}
+		return nil, status.Errorf(codes.InvalidArgument, "Failed to reset password: %v", err)
+	}
+	return &auth.ConfirmPasswordResetResponse{Message: "Password reset successfully"}, nil
+}
+
+func (a *AuthServer) ConfirmEmail(ctx context.Context, req *auth.ConfirmEmailRequest) (*auth.ConfirmEmailResponse, error) {
+	if err := a.verifyService.Confirm(ctx, req.Token); err != nil {
+		if errors.Is(err, domain.ErrInvalidVerificationToken) {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid verification token: %v", err)
 		}
-		if !ok {
-			return nil, status.Errorf(status.Code(err), "Invalid token claims: %v", claims)
+		if errors.Is(err, domain.ErrEmailTaken) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
 		}
-		id = userid
+		return nil, status.Errorf(codes.Internal, "Failed to confirm email: %v", err)
 	}
+	return &auth.ConfirmEmailResponse{Message: "Email confirmed successfully"}, nil
+}
 
-	u, err := a.userService.UsersGetById(int(id))
+func (a *AuthServer) RequestEmailVerification(ctx context.Context, req *auth.RequestEmailVerificationRequest) (*auth.RequestEmailVerificationResponse, error) {
+	if err := a.verifyService.ResendByEmail(ctx, req.Email); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to request verification email: %v", err)
+	}
+	return &auth.RequestEmailVerificationResponse{Message: "If the email is registered and not yet confirmed, a verification link has been sent"}, nil
+}
+
+func (a *AuthServer) ResendEmailVerification(ctx context.Context, req *auth.ResendEmailVerificationRequest) (*auth.ResendEmailVerificationResponse, error) {
+	userIDstr, ok := ctx.Value(meta.UserIDKey).(string)
+	if !ok || userIDstr == "" {
+		return nil, status.Error(codes.Unauthenticated, "User ID is not provided")
+	}
+	userID, err := strconv.Atoi(userIDstr)
 	if err != nil {
-		return nil, status.Errorf(status.Code(err), "User not found: %v", err)
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid user ID: %v", userIDstr)
 	}
+	u, err := a.userService.UsersGetById(userID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "User not found: %v", err)
+	}
+	if err := a.verifyService.Send(ctx, u); err != nil {
+		if errors.Is(err, domain.ErrEmailAlreadyVerified) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "Failed to send verification email: %v", err)
+	}
+	return &auth.ResendEmailVerificationResponse{Message: "Verification email sent"}, nil
+}
 
-	atoken, rtoken, err := a.authService.GenerateTokens(u.ID, u.Role)
+func (a *AuthServer) LoginMfa(ctx context.Context, req *auth.LoginMfaRequest) (*auth.LoginMfaResponse, error) {
+	userID, err := a.authService.ValidateMFAToken(req.MfaToken)
 	if err != nil {
-		return nil, status.Errorf(status.Code(err), "Failed to generate tokens: %v", err)
+		return nil, status.Error(codes.Unauthenticated, err.Error())
 	}
-	return &auth.TokenRefreshResponse{AccessToken: atoken, RefreshToken: rtoken}, nil
+	u, err := a.userService.UsersGetById(userID)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, domain.ErrInvalidMFAToken.Error())
+	}
+	if err := a.userService.CheckActive(u); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	attempt := &models.LoginAttempt{
+		Username:  u.Username,
+		UserID:    &u.ID,
+		IP:        clientIP(ctx),
+		UserAgent: userAgent(ctx),
+	}
+	if retry, err := a.throttleService.Check(attempt.Username, attempt.IP); err != nil {
+		if errors.Is(err, domain.ErrTooManyLoginAttempts) {
+			attempt.Reason = models.LoginReasonThrottled
+			a.throttleService.Record(attempt)
+			st, _ := status.New(codes.ResourceExhausted, err.Error()).WithDetails(&errdetails.RetryInfo{
+				RetryDelay: durationpb.New(retry),
+			})
+			return nil, st.Err()
+		}
+		return nil, status.Errorf(codes.Internal, "Failed to check login attempts: %v", err)
+	}
+
+	recoveryCodes, err := a.userService.CompleteMFA(u, req.Code)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidMFACode) {
+			attempt.Reason = models.LoginReasonInvalidMFACode
+			a.throttleService.Record(attempt)
+		}
+		return nil, mfaError(err)
+	}
+	attempt.Reason = models.LoginReasonSuccess
+	a.throttleService.Record(attempt)
+
+	atoken, rtoken, err := a.authService.GenerateTokens(u)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to generate tokens: %v", err)
+	}
+	return &auth.LoginMfaResponse{AccessToken: atoken, RefreshToken: rtoken, RecoveryCodes: recoveryCodes}, nil
+}
+
+func (a *AuthServer) LoginMfaEnroll(ctx context.Context, req *auth.LoginMfaEnrollRequest) (*auth.EnrollMfaResponse, error) {
+	userID, err := a.authService.ValidateMFAToken(req.MfaToken)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	u, err := a.userService.UsersGetById(userID)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, domain.ErrInvalidMFAToken.Error())
+	}
+	if err := a.userService.CheckActive(u); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	secret, uri, err := a.userService.EnrollTOTP(u)
+	if err != nil {
+		return nil, mfaError(err)
+	}
+	return &auth.EnrollMfaResponse{Secret: secret, OtpauthUri: uri}, nil
+}
+
+func (a *AuthServer) EnrollMfa(ctx context.Context, req *auth.EnrollMfaRequest) (*auth.EnrollMfaResponse, error) {
+	u, err := a.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	secret, uri, err := a.userService.EnrollTOTP(u)
+	if err != nil {
+		return nil, mfaError(err)
+	}
+	return &auth.EnrollMfaResponse{Secret: secret, OtpauthUri: uri}, nil
+}
+
+func (a *AuthServer) ConfirmMfa(ctx context.Context, req *auth.ConfirmMfaRequest) (*auth.ConfirmMfaResponse, error) {
+	u, err := a.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	recoveryCodes, err := a.userService.ConfirmTOTP(u, req.Code)
+	if err != nil {
+		return nil, mfaError(err)
+	}
+	return &auth.ConfirmMfaResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+func (a *AuthServer) DisableMfa(ctx context.Context, req *auth.DisableMfaRequest) (*auth.DisableMfaResponse, error) {
+	u, err := a.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.userService.DisableTOTP(u, req.Code); err != nil {
+		return nil, mfaError(err)
+	}
+	return &auth.DisableMfaResponse{Message: "Two-factor authentication disabled"}, nil
+}
+
+// currentUser загружает владельца access токена из контекста вызова.
+func (a *AuthServer) currentUser(ctx context.Context) (*models.User, error) {
+	userIDstr, ok := ctx.Value(meta.UserIDKey).(string)
+	if !ok || userIDstr == "" {
+		return nil, status.Error(codes.Unauthenticated, "User ID is not provided")
+	}
+	userID, err := strconv.Atoi(userIDstr)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid user ID: %v", userIDstr)
+	}
+	u, err := a.userService.UsersGetById(userID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "User not found: %v", err)
+	}
+	return u, nil
+}
+
+func mfaError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidMFACode):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, domain.ErrMFAAlreadyEnabled):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrMFANotEnabled):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrMFARequired):
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return status.Errorf(codes.Internal, "Two-factor authentication failed: %v", err)
+}
+
+// clientIP возвращает адрес клиента. X-Forwarded-For принимается только от
+// локального шлюза, остальным клиентам он не доверяется.
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if forwarded := md.Get("x-forwarded-for"); len(forwarded) > 0 && forwarded[0] != "" {
+				return forwarded[0]
+			}
+		}
+	}
+	return host
+}
+
+func userAgent(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ua := md.Get("user-agent"); len(ua) > 0 {
+			return ua[0]
+		}
+	}
+	return ""
 }
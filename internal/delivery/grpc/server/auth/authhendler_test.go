@@ -0,0 +1,93 @@
+package auth_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DANazavr/RATest/config"
+	"github.com/DANazavr/RATest/internal/delivery/grpc/server/auth"
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/mailer"
+	"github.com/DANazavr/RATest/internal/services"
+	"github.com/DANazavr/RATest/internal/store/sqlstore"
+	authpb "github.com/DANazavr/RATest/protos/gen/go/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var databaseURL string
+
+func TestMain(m *testing.M) {
+	databaseURL = os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		databaseURL = "host=localhost port=5432 user=postgres password=0123 dbname=restapi_test sslmode=disable"
+	}
+
+	os.Exit(m.Run())
+}
+
+func TestAuthServer_RegisterByInviteIntoOrganization(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("user_notifications", "invites", "email_verifications")
+
+	ctx := t.Context()
+	logger := log.NewLog(ctx, &log.LogConfig{Component: "grpc", LogLevel: "debug"})
+	s := sqlstore.New(ctx, db, logger)
+	// Шаблон user.registered создаётся вместе с организацией
+	o := &models.Organization{Slug: "acme", Name: "Acme"}
+	require.NoError(t, services.NewOrganizationService(ctx, logger, s).Create(o))
+	defer func() {
+		db.Exec("DELETE FROM notification_templates WHERE org_id = $1", o.ID)
+		db.Exec("DELETE FROM users WHERE org_id = $1", o.ID)
+		db.Exec("DELETE FROM organizations WHERE id = $1", o.ID)
+	}()
+
+	dir := t.TempDir()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "key.pem"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+	keys, err := services.LoadKeyRing(dir, "key")
+	require.NoError(t, err)
+	mail, err := mailer.NewFileMailer("noreply@example.com", filepath.Join(dir, "mail.log"))
+	require.NoError(t, err)
+	hasher, err := services.NewPasswordHasher(config.PasswordHashConfig{})
+	require.NoError(t, err)
+
+	us := services.NewUserService(ctx, s, logger, hasher)
+	as := services.NewAuthService(ctx, logger, s, keys)
+	is := services.NewInviteService(ctx, logger, s, as)
+	// Без подтверждённого email уведомление остаётся в истории и не уходит в Centrifugo
+	vs := services.NewEmailVerificationService(ctx, logger, s, mail, "http://localhost", services.EmailVerificationNotifications)
+	a := auth.NewAuthServer(ctx, logger, us, as, is,
+		services.NewPasswordResetService(ctx, logger, s, us, mail, "http://localhost"), vs,
+		services.NewLoginThrottleService(ctx, logger, s), services.NewOIDCService(ctx, logger, s, us, nil),
+		services.NewNotificationService(ctx, logger, s, mail), services.NewTemplateService(ctx, logger, s))
+
+	token, err := is.ForOrg(o.ID).Create(&models.Invite{Role: domain.RoleUser})
+	require.NoError(t, err)
+	_, err = a.Register(ctx, &authpb.RegisterRequest{
+		Username:    "alice",
+		Email:       "alice@example.com",
+		Password:    "Str0ng-password",
+		InviteToken: token,
+	})
+	require.NoError(t, err)
+
+	u, err := s.User().GetByUsername("alice")
+	require.NoError(t, err)
+	assert.Equal(t, o.ID, u.OrgID)
+	inbox, err := s.Tenant(o.ID).Notification().GetByUserId(u.ID)
+	assert.NoError(t, err)
+	if assert.Len(t, inbox, 1) {
+		assert.Equal(t, "Welcome, alice", inbox[0].Notification.Title)
+	}
+}
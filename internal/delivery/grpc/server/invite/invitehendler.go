@@ -0,0 +1,97 @@
+package invite
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/common/meta"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/services"
+	"github.com/DANazavr/RATest/protos/gen/go/invite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type InviteServer struct {
+	ctx           context.Context
+	logger        *log.Log
+	inviteService *services.InviteService
+	invite.UnimplementedInvitesServer
+}
+
+func NewInviteServer(ctx context.Context, logger *log.Log, is *services.InviteService) *InviteServer {
+	return &InviteServer{
+		ctx:           ctx,
+		logger:        logger.WithComponent("grpc/invite/InviteServer"),
+		inviteService: is,
+	}
+}
+
+func Register(gRPC *grpc.Server, inviteServer *InviteServer) {
+	invite.RegisterInvitesServer(gRPC, inviteServer)
+}
+
+func (s *InviteServer) Create(ctx context.Context, req *invite.CreateRequest) (*invite.CreateResponse, error) {
+	i := &models.Invite{
+		Role:  req.Role,
+		Email: req.Email,
+	}
+	if userIDstr, ok := ctx.Value(meta.UserIDKey).(string); ok {
+		if userID, err := strconv.Atoi(userIDstr); err == nil {
+			i.CreatedBy = &userID
+		}
+	}
+
+	token, err := s.orgInvites(ctx).Create(i)
+	if err != nil {
+		s.logger.Errorf(ctx, "Failed to create invite: %v", err)
+		return nil, status.Errorf(codes.InvalidArgument, "Failed to create invite: %v", err)
+	}
+	return &invite.CreateResponse{Invite: convertToProtoInvite(i), Token: token}, nil
+}
+
+func (s *InviteServer) List(ctx context.Context, req *invite.ListRequest) (*invite.ListResponse, error) {
+	invites, err := s.orgInvites(ctx).Get()
+	if err != nil {
+		s.logger.Errorf(ctx, "Failed to list invites: %v", err)
+		return nil, status.Errorf(codes.Internal, "Failed to list invites: %v", err)
+	}
+	protoInvites := make([]*invite.Invite, 0, len(invites))
+	for _, i := range invites {
+		protoInvites = append(protoInvites, convertToProtoInvite(i))
+	}
+	return &invite.ListResponse{Invites: protoInvites}, nil
+}
+
+func convertToProtoInvite(i *models.Invite) *invite.Invite {
+	var createdBy, usedBy int64
+	if i.CreatedBy != nil {
+		createdBy = int64(*i.CreatedBy)
+	}
+	if i.UsedBy != nil {
+		usedBy = int64(*i.UsedBy)
+	}
+	var usedAt string
+	if i.UsedAt != nil {
+		usedAt = i.UsedAt.Format(time.RFC3339)
+	}
+	return &invite.Invite{
+		Id:        i.ID,
+		Role:      i.Role,
+		Email:     i.Email,
+		CreatedBy: createdBy,
+		CreatedAt: i.CreatedAt.Format(time.RFC3339),
+		ExpiresAt: i.ExpiresAt.Format(time.RFC3339),
+		UsedAt:    usedAt,
+		UsedBy:    usedBy,
+	}
+}
+
+// orgInvites возвращает сервис приглашений в организацию администратора.
+func (s *InviteServer) orgInvites(ctx context.Context) *services.InviteService {
+	orgID, _ := ctx.Value(meta.OrgIDKey).(int)
+	return s.inviteService.ForOrg(orgID)
+}
@@ -2,9 +2,13 @@ package notification
 
 import (
 	"context"
+	"errors"
 	"strconv"
+	"time"
 
 	"github.com/DANazavr/RATest/internal/common/meta"
+	"github.com/DANazavr/RATest/internal/delivery/grpc/server/admin"
+	"github.com/DANazavr/RATest/internal/domain"
 	"github.com/DANazavr/RATest/internal/domain/models"
 	"github.com/DANazavr/RATest/internal/log"
 	"github.com/DANazavr/RATest/internal/services"
@@ -12,22 +16,33 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 type NotificationServer struct {
 	ctx                 context.Context
 	logger              *log.Log
 	userService         *services.UserService
+	authService         *services.AuthService
 	notificationService *services.NotificationService
+	verifyService       *services.EmailVerificationService
+	audienceService     *services.AudienceService
+	templateService     *services.TemplateService
+	adminInterceptor    *admin.InterceptorAdmin
 	notification.UnimplementedNotificationServer
 }
 
-func NewNotificationServer(ctx context.Context, logger *log.Log, us *services.UserService, ns *services.NotificationService) *NotificationServer {
+func NewNotificationServer(ctx context.Context, logger *log.Log, us *services.UserService, as *services.AuthService, ns *services.NotificationService, vs *services.EmailVerificationService, au *services.AudienceService, ts *services.TemplateService, ia *admin.InterceptorAdmin) *NotificationServer {
 	return &NotificationServer{
 		ctx:                 ctx,
 		logger:              logger.WithComponent("grpc/notification/notificationServer"),
 		userService:         us,
+		authService:         as,
 		notificationService: ns,
+		verifyService:       vs,
+		audienceService:     au,
+		templateService:     ts,
+		adminInterceptor:    ia,
 	}
 }
 
@@ -36,6 +51,21 @@ func Register(gRPC *grpc.Server, notificationServer *NotificationServer) {
 }
 
 func (ns *NotificationServer) Publish(ctx context.Context, req *notification.PublishRequest) (*notification.PublishResponse, error) {
+	content, err := ns.content(ctx, req.Data, req.TemplateKey, req.Locale, req.Variables)
+	if err != nil {
+		return nil, err
+	}
+	at, err := deliverAt(req.DeliverAt)
+	if err != nil {
+		return nil, err
+	}
+	if req.GroupId != 0 || req.SegmentId != 0 {
+		if req.Channel != "" {
+			return nil, status.Error(codes.InvalidArgument, domain.ErrInvalidAudience.Error())
+		}
+		return ns.publishAudience(ctx, req, content, at)
+	}
+
 	var userID int
 	for i, v := range req.Channel {
 		if v == '#' {
@@ -52,36 +82,58 @@ func (ns *NotificationServer) Publish(ctx context.Context, req *notification.Pub
 		return nil, status.Errorf(codes.InvalidArgument, "Invalid user ID provided in channel")
 	}
 
-	if _, err := ns.userService.UsersGetById(userID); err != nil {
+	// Издатель видит только пользователей своей организации
+	orgID, _ := ctx.Value(meta.OrgIDKey).(int)
+	notifications := ns.notificationService.ForOrg(orgID)
+	user, err := ns.userService.ForOrg(orgID).UsersGetById(userID)
+	if err != nil {
 		ns.logger.Errorf(ns.ctx, "Failed to get user: %v", err)
 		return nil, status.Errorf(codes.NotFound, "User with ID %d not found", userID)
 	}
+	// Уведомление уходит в персональный канал получателя в его организации
+	channel := notifications.UserChannel(user.OrgID, user.ID)
 
-	notificationMap := map[string]interface{}{
-		"title":   req.Data.Title,
-		"message": req.Data.Message,
-	}
 	n := &models.UserNotification{
 		UserID:       userID,
-		Notification: notificationMap,
+		Notification: content(user),
+	}
+	if apiKeyID, ok := ctx.Value(meta.APIKeyIDKey).(int); ok {
+		n.APIKeyID = &apiKeyID
 	}
 
-	if err := ns.notificationService.NotificationCreate(n); err != nil {
+	if at != nil {
+		if err := notifications.Schedule(n, *at); err != nil {
+			ns.logger.Errorf(ns.ctx, "Failed to schedule notification: %v", err)
+			return nil, status.Errorf(codes.Internal, "Failed to schedule notification: %v", err)
+		}
+		return &notification.PublishResponse{Uid: int64(n.UID)}, nil
+	}
+
+	if err := notifications.NotificationCreate(n); err != nil {
 		ns.logger.Errorf(ns.ctx, "Failed to create notification: %v", err)
 		return nil, status.Errorf(codes.Internal, "Failed to create notification: %v", err)
 	}
 	ns.logger.Infof(ns.ctx, "Notification created for user %d: %v", userID, n.UID)
 
-	presence, err := ns.notificationService.Presence(req.Channel)
+	// Неподтверждённым адресам уведомление остаётся только в истории
+	if !ns.verifyService.CanDeliver(user) {
+		ns.logger.Infof(ns.ctx, "User %d has unverified email, notification %d is not pushed", userID, n.UID)
+		return &notification.PublishResponse{Uid: int64(n.UID)}, nil
+	}
+
+	presence, err := notifications.Presence(channel)
 	if err != nil {
 		ns.logger.Errorf(ns.ctx, "Failed to get presence: %v", err)
 		return nil, status.Errorf(codes.Internal, "Failed to get presence: %v", err)
 	}
 	if len(presence.Presence) == 0 {
-		ns.logger.Infof(ns.ctx, "No users online in channel %s", req.Channel)
+		ns.logger.Infof(ns.ctx, "No users online in channel %s", channel)
 	}
 
-	publish, err := ns.notificationService.Publish(n, req.Channel)
+	publish, err := notifications.Publish(n, channel)
+	if errors.Is(err, domain.ErrPushSuppressed) || errors.Is(err, domain.ErrPushDeferred) {
+		return &notification.PublishResponse{Uid: int64(n.UID)}, nil
+	}
 	if err != nil {
 		ns.logger.Errorf(ns.ctx, "Failed to publish notification: %v", err)
 		return nil, status.Errorf(codes.Internal, "Failed to publish notification: %v", err)
@@ -93,16 +145,101 @@ func (ns *NotificationServer) Publish(ctx context.Context, req *notification.Pub
 			ns.logger.Errorf(ns.ctx, "Failed to convert user ID from presence: %v", err)
 			return nil, status.Errorf(codes.Internal, "Failed to convert user ID from presence: %v", err)
 		}
-		if err := ns.notificationService.MarkAsSend(n, userid); err != nil {
+		if err := notifications.MarkAsSend(n, userid); err != nil {
 			ns.logger.Errorf(ns.ctx, "Failed to mark notification as sent: %v", err)
 			return nil, status.Errorf(codes.Internal, "Failed to mark notification as sent: %v", err)
 		}
 	}
-	return &notification.PublishResponse{Offset: publish.Offset, Epoch: publish.Epoch}, nil
+	return &notification.PublishResponse{Offset: publish.Offset, Epoch: publish.Epoch, Uid: int64(n.UID)}, nil
+}
+
+// publishAudience сохраняет и отправляет уведомление каждому участнику группы
+// или сегмента из запроса в организации издателя. Если задан at, уведомления
+// планируются на это время. Группа или сегмент могут охватить всю организацию,
+// поэтому нужно право рассылки.
+func (ns *NotificationServer) publishAudience(ctx context.Context, req *notification.PublishRequest, content services.NotificationContent, at *time.Time) (*notification.PublishResponse, error) {
+	allowed, err := ns.adminInterceptor.Allowed(ctx, domain.PermNotificationBroadcast)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to check permission")
+	}
+	if !allowed {
+		return nil, status.Error(codes.PermissionDenied, "publishing to a group or segment requires "+domain.PermNotificationBroadcast)
+	}
+	orgID, _ := ctx.Value(meta.OrgIDKey).(int)
+	users, err := ns.audienceService.ForOrg(orgID).Recipients(int(req.GroupId), int(req.SegmentId))
+	if err != nil {
+		ns.logger.Errorf(ns.ctx, "Failed to resolve audience: %v", err)
+		switch {
+		case errors.Is(err, domain.ErrGroupNotFound), errors.Is(err, domain.ErrSegmentNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, domain.ErrInvalidAudience):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "Failed to resolve audience: %v", err)
+	}
+	var apiKeyID *int
+	if id, ok := ctx.Value(meta.APIKeyIDKey).(int); ok {
+		apiKeyID = &id
+	}
+	res, err := ns.notificationService.ForOrg(orgID).Fanout(users, content, apiKeyID, true, at, ns.verifyService.CanDeliver)
+	if err != nil {
+		ns.logger.Errorf(ns.ctx, "Failed to publish notification to audience: %v", err)
+		return nil, status.Errorf(codes.Internal, "Failed to publish notification: %v", err)
+	}
+	return &notification.PublishResponse{Recipients: int64(res.Recipients), Pushed: int64(res.Pushed), Failed: int64(res.Failed)}, nil
+}
+
+// content возвращает содержимое публикации по шаблону организации издателя
+// или готовое data.
+func (ns *NotificationServer) content(ctx context.Context, d *notification.Data, key, locale string, variables *structpb.Struct) (services.NotificationContent, error) {
+	var data *models.NotificationData
+	if d != nil {
+		converted := services.ConvertFromProtoData(d)
+		data = &converted
+	}
+	var vars map[string]interface{}
+	if variables != nil {
+		vars = variables.AsMap()
+	}
+	orgID, _ := ctx.Value(meta.OrgIDKey).(int)
+	content, err := ns.templateService.ForOrg(orgID).Resolve(key, locale, vars, data)
+	if errors.Is(err, domain.ErrTemplateNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return content, nil
+}
+
+// deliverAt разбирает время отправки по расписанию. Пустая строка означает
+// отправку сразу.
+func deliverAt(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	at, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid deliver_at: %v", err)
+	}
+	if err := services.ValidateDeliverAt(&at); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &at, nil
 }
 
 func (ns *NotificationServer) Broadcast(ctx context.Context, req *notification.BroadcastRequest) (*notification.BroadcastResponse, error) {
-	users, err := ns.userService.UsersGet()
+	content, err := ns.content(ctx, req.Data, req.TemplateKey, req.Locale, req.Variables)
+	if err != nil {
+		return nil, err
+	}
+	at, err := deliverAt(req.DeliverAt)
+	if err != nil {
+		return nil, err
+	}
+	// Рассылка ограничена организацией издателя
+	orgID, _ := ctx.Value(meta.OrgIDKey).(int)
+	notifications := ns.notificationService.ForOrg(orgID)
+	users, err := ns.userService.ForOrg(orgID).UsersGet()
 	if err != nil {
 		ns.logger.Errorf(ns.ctx, "Failed to get users: %v", err)
 		return nil, status.Errorf(codes.Internal, "Failed to get users: %v", err)
@@ -112,28 +249,42 @@ func (ns *NotificationServer) Broadcast(ctx context.Context, req *notification.B
 			ns.logger.Infof(ns.ctx, "Skipping user %d with role %s", user.ID, user.Role)
 			continue
 		}
-		notificationMap := map[string]interface{}{
-			"title":   req.Data.Title,
-			"message": req.Data.Message,
-		}
 		n := &models.UserNotification{
 			UserID:       user.ID,
-			Notification: notificationMap,
+			Notification: content(user),
+			Broadcast:    true,
+		}
+		if apiKeyID, ok := ctx.Value(meta.APIKeyIDKey).(int); ok {
+			n.APIKeyID = &apiKeyID
 		}
 
-		channel := "notifications:user#" + strconv.Itoa(user.ID)
+		channel := notifications.UserChannel(user.OrgID, user.ID)
 
-		if err := ns.notificationService.NotificationCreate(n); err != nil {
+		if at != nil {
+			if err := notifications.Schedule(n, *at); err != nil {
+				ns.logger.Errorf(ns.ctx, "Failed to schedule notification: %v", err)
+				return nil, status.Errorf(codes.Internal, "Failed to schedule notification: %v", err)
+			}
+			continue
+		}
+		if err := notifications.NotificationCreate(n); err != nil {
 			ns.logger.Errorf(ns.ctx, "Failed to create notification: %v", err)
 			return nil, status.Errorf(codes.Internal, "Failed to create notification: %v", err)
 		}
 		ns.logger.Infof(ns.ctx, "Notification created for user %d: %v", user.ID, n.UID)
-		_, err := ns.notificationService.Publish(n, channel)
+		if !ns.verifyService.CanDeliver(user) {
+			ns.logger.Infof(ns.ctx, "User %d has unverified email, notification %d is not pushed", user.ID, n.UID)
+			continue
+		}
+		_, err := notifications.Publish(n, channel)
+		if errors.Is(err, domain.ErrPushSuppressed) || errors.Is(err, domain.ErrPushDeferred) {
+			continue
+		}
 		if err != nil {
 			ns.logger.Errorf(ns.ctx, "Failed to publish notification: %v", err)
 			return nil, status.Errorf(codes.Internal, "Failed to publish notification: %v", err)
 		}
-		presence, err := ns.notificationService.Presence(channel)
+		presence, err := notifications.Presence(channel)
 		if err != nil {
 			ns.logger.Errorf(ns.ctx, "Failed to get presence: %v", err)
 			return nil, status.Errorf(codes.Internal, "Failed to get presence: %v", err)
@@ -148,13 +299,13 @@ func (ns *NotificationServer) Broadcast(ctx context.Context, req *notification.B
 				ns.logger.Errorf(ns.ctx, "Failed to convert user ID from presence: %v", err)
 				return nil, status.Errorf(codes.Internal, "Failed to convert user ID from presence: %v", err)
 			}
-			if err := ns.notificationService.MarkAsSend(n, userid); err != nil {
+			if err := notifications.MarkAsSend(n, userid); err != nil {
 				ns.logger.Errorf(ns.ctx, "Failed to mark notification as sent: %v", err)
 				return nil, status.Errorf(codes.Internal, "Failed to mark notification as sent: %v", err)
 			}
 		}
 	}
-	ns.logger.Infof(ctx, "Broadcast notification sent: %v", req.Data)
+	ns.logger.Infof(ctx, "Broadcast notification sent to %d users", len(users))
 	return &notification.BroadcastResponse{Message: "Broadcast notification sent successfully"}, nil
 }
 
@@ -241,3 +392,189 @@ func (ns *NotificationServer) GetNotificationsByFilter(ctx context.Context, req
 
 	return &notification.GetNotificationsByFilterResponse{Notifications: protoNotifications}, nil
 }
+
+func (ns *NotificationServer) CentrifugoConnectionToken(ctx context.Context, req *notification.CentrifugoConnectionTokenRequest) (*notification.CentrifugoTokenResponse, error) {
+	userIDstr, ok := ctx.Value(meta.UserIDKey).(string)
+	if !ok || userIDstr == "" {
+		ns.logger.Errorf(ctx, "Invalid user ID in context: %v", userIDstr)
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid user ID in context: %v", userIDstr)
+	}
+	userID, err := strconv.Atoi(userIDstr)
+	if err != nil {
+		ns.logger.Errorf(ns.ctx, "Failed to convert user ID to int: %v", err)
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid user ID: %v", userIDstr)
+	}
+
+	user, err := ns.userService.UsersGetById(userID)
+	if err != nil {
+		ns.logger.Errorf(ns.ctx, "Failed to get user: %v", err)
+		return nil, status.Errorf(codes.NotFound, "User with ID %d not found", userID)
+	}
+	if !ns.verifyService.CanDeliver(user) {
+		return nil, status.Error(codes.PermissionDenied, domain.ErrEmailNotVerified.Error())
+	}
+
+	token, err := ns.authService.GenerateCentrifugoConnectionToken(userID)
+	if err != nil {
+		ns.logger.Errorf(ns.ctx, "Failed to generate connection token: %v", err)
+		return nil, status.Errorf(codes.Internal, "Failed to generate connection token: %v", err)
+	}
+	return &notification.CentrifugoTokenResponse{Token: token}, nil
+}
+
+func (ns *NotificationServer) CentrifugoSubscriptionToken(ctx context.Context, req *notification.CentrifugoSubscriptionTokenRequest) (*notification.CentrifugoTokenResponse, error) {
+	userIDstr, ok := ctx.Value(meta.UserIDKey).(string)
+	if !ok || userIDstr == "" {
+		ns.logger.Errorf(ctx, "Invalid user ID in context: %v", userIDstr)
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid user ID in context: %v", userIDstr)
+	}
+	userID, err := strconv.Atoi(userIDstr)
+	if err != nil {
+		ns.logger.Errorf(ns.ctx, "Failed to convert user ID to int: %v", err)
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid user ID: %v", userIDstr)
+	}
+
+	orgID, _ := ctx.Value(meta.OrgIDKey).(int)
+	if !ns.notificationService.CanSubscribe(orgID, userID, req.Channel) {
+		ns.logger.Warnf(ns.ctx, "User %d is not allowed to subscribe to %s", userID, req.Channel)
+		return nil, status.Errorf(codes.PermissionDenied, "Access to channel %s denied", req.Channel)
+	}
+
+	token, err := ns.authService.GenerateCentrifugoSubscriptionToken(userID, req.Channel)
+	if err != nil {
+		ns.logger.Errorf(ns.ctx, "Failed to generate subscription token: %v", err)
+		return nil, status.Errorf(codes.Internal, "Failed to generate subscription token: %v", err)
+	}
+	return &notification.CentrifugoTokenResponse{Token: token}, nil
+}
+
+func (ns *NotificationServer) GetPreferences(ctx context.Context, req *notification.GetPreferencesRequest) (*notification.PreferencesResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p, err := ns.notificationService.Preferences(userID)
+	if err != nil {
+		ns.logger.Errorf(ns.ctx, "Failed to get notification preferences: %v", err)
+		return nil, status.Errorf(codes.Internal, "Failed to get notification preferences: %v", err)
+	}
+	return &notification.PreferencesResponse{Preferences: convertToProtoPreferences(p)}, nil
+}
+
+func (ns *NotificationServer) UpdatePreferences(ctx context.Context, req *notification.UpdatePreferencesRequest) (*notification.PreferencesResponse, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pp := req.GetPreferences()
+	p := &models.NotificationPreferences{
+		UserID:          userID,
+		MutedCategories: pp.GetMutedCategories(),
+		Timezone:        pp.GetTimezone(),
+		Locale:          pp.GetLocale(),
+		Channels: models.DeliveryChannels{
+			Realtime: pp.GetRealtime(),
+			Email:    pp.GetEmail(),
+		},
+	}
+	if qh := pp.GetQuietHours(); qh != nil {
+		p.QuietHours = &models.QuietHours{Start: qh.Start, End: qh.End}
+	}
+	if err := ns.notificationService.UpdatePreferences(p); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid notification preferences: %v", err)
+	}
+	return &notification.PreferencesResponse{Preferences: convertToProtoPreferences(p)}, nil
+}
+
+func currentUserID(ctx context.Context) (int, error) {
+	userIDstr, ok := ctx.Value(meta.UserIDKey).(string)
+	if !ok || userIDstr == "" {
+		return 0, status.Error(codes.Unauthenticated, "User ID is not provided")
+	}
+	userID, err := strconv.Atoi(userIDstr)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "Invalid user ID: %v", userIDstr)
+	}
+	return userID, nil
+}
+
+func convertToProtoPreferences(p *models.NotificationPreferences) *notification.Preferences {
+	pp := &notification.Preferences{
+		MutedCategories: p.MutedCategories,
+		Timezone:        p.Timezone,
+		Locale:          p.Locale,
+		Realtime:        p.Channels.Realtime,
+		Email:           p.Channels.Email,
+	}
+	if p.QuietHours != nil {
+		pp.QuietHours = &notification.QuietHours{Start: p.QuietHours.Start, End: p.QuietHours.End}
+	}
+	if p.UpdatedAt != nil {
+		pp.UpdatedAt = p.UpdatedAt.Format(time.RFC3339)
+	}
+	return pp
+}
+
+func (ns *NotificationServer) ListScheduled(ctx context.Context, req *notification.ListScheduledRequest) (*notification.ListScheduledResponse, error) {
+	broadcast, err := ns.adminInterceptor.Allowed(ctx, domain.PermNotificationBroadcast)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to check permission")
+	}
+	orgID, _ := ctx.Value(meta.OrgIDKey).(int)
+	notifications, err := ns.notificationService.ForOrg(orgID).Scheduled(broadcast)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to get scheduled notifications: %v", err)
+	}
+	resp := &notification.ListScheduledResponse{Notifications: make([]*notification.Notification, 0, len(notifications))}
+	for _, n := range notifications {
+		pn, err := ns.notificationService.ConvertToProtoNotification(n)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to convert notification: %v", err)
+		}
+		resp.Notifications = append(resp.Notifications, pn)
+	}
+	return resp, nil
+}
+
+func (ns *NotificationServer) Reschedule(ctx context.Context, req *notification.RescheduleRequest) (*notification.RescheduleResponse, error) {
+	at, err := time.Parse(time.RFC3339, req.DeliverAt)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid deliver_at: %v", err)
+	}
+	broadcast, err := ns.adminInterceptor.Allowed(ctx, domain.PermNotificationBroadcast)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to check permission")
+	}
+	orgID, _ := ctx.Value(meta.OrgIDKey).(int)
+	n, err := ns.notificationService.ForOrg(orgID).Reschedule(int(req.Uid), at, broadcast)
+	if err != nil {
+		return nil, scheduleError(err)
+	}
+	pn, err := ns.notificationService.ConvertToProtoNotification(n)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to convert notification: %v", err)
+	}
+	return &notification.RescheduleResponse{Notification: pn}, nil
+}
+
+func (ns *NotificationServer) CancelScheduled(ctx context.Context, req *notification.CancelScheduledRequest) (*notification.CancelScheduledResponse, error) {
+	broadcast, err := ns.adminInterceptor.Allowed(ctx, domain.PermNotificationBroadcast)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to check permission")
+	}
+	orgID, _ := ctx.Value(meta.OrgIDKey).(int)
+	if err := ns.notificationService.ForOrg(orgID).CancelScheduled(int(req.Uid), broadcast); err != nil {
+		return nil, scheduleError(err)
+	}
+	return &notification.CancelScheduledResponse{Message: "Scheduled notification canceled"}, nil
+}
+
+func scheduleError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrScheduledNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidDeliverAt):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Errorf(codes.Internal, "Failed to change scheduled notification: %v", err)
+}
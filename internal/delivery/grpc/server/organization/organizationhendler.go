@@ -0,0 +1,111 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/common/meta"
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/services"
+	"github.com/DANazavr/RATest/protos/gen/go/organization"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type OrganizationServer struct {
+	ctx                 context.Context
+	logger              *log.Log
+	organizationService *services.OrganizationService
+	inviteService       *services.InviteService
+	organization.UnimplementedOrganizationsServer
+}
+
+func NewOrganizationServer(ctx context.Context, logger *log.Log, os *services.OrganizationService, is *services.InviteService) *OrganizationServer {
+	return &OrganizationServer{
+		ctx:                 ctx,
+		logger:              logger.WithComponent("grpc/organization/OrganizationServer"),
+		organizationService: os,
+		inviteService:       is,
+	}
+}
+
+func Register(gRPC *grpc.Server, organizationServer *OrganizationServer) {
+	organization.RegisterOrganizationsServer(gRPC, organizationServer)
+}
+
+func (s *OrganizationServer) Create(ctx context.Context, req *organization.CreateRequest) (*organization.Organization, error) {
+	o := &models.Organization{
+		Slug: req.Slug,
+		Name: req.Name,
+	}
+	if err := s.organizationService.Create(o); err != nil {
+		return nil, organizationError(err)
+	}
+	return convertToProtoOrganization(o), nil
+}
+
+func (s *OrganizationServer) List(ctx context.Context, req *organization.ListRequest) (*organization.ListResponse, error) {
+	orgs, err := s.organizationService.Get()
+	if err != nil {
+		s.logger.Errorf(ctx, "Failed to list organizations: %v", err)
+		return nil, status.Errorf(codes.Internal, "Failed to list organizations: %v", err)
+	}
+	protoOrgs := make([]*organization.Organization, 0, len(orgs))
+	for _, o := range orgs {
+		protoOrgs = append(protoOrgs, convertToProtoOrganization(o))
+	}
+	return &organization.ListResponse{Organizations: protoOrgs}, nil
+}
+
+func (s *OrganizationServer) CreateInvite(ctx context.Context, req *organization.CreateInviteRequest) (*organization.CreateInviteResponse, error) {
+	if _, err := s.organizationService.Organization(int(req.Id)); err != nil {
+		return nil, organizationError(err)
+	}
+	i := &models.Invite{
+		Role:  req.Role,
+		Email: req.Email,
+	}
+	if userIDstr, ok := ctx.Value(meta.UserIDKey).(string); ok {
+		if userID, err := strconv.Atoi(userIDstr); err == nil {
+			i.CreatedBy = &userID
+		}
+	}
+
+	token, err := s.inviteService.ForOrg(int(req.Id)).Create(i)
+	if err != nil {
+		s.logger.Errorf(ctx, "Failed to create invite into organization %d: %v", req.Id, err)
+		return nil, status.Errorf(codes.InvalidArgument, "Failed to create invite: %v", err)
+	}
+	return &organization.CreateInviteResponse{
+		InviteId:       i.ID,
+		OrganizationId: req.Id,
+		Role:           i.Role,
+		Email:          i.Email,
+		ExpiresAt:      i.ExpiresAt.Format(time.RFC3339),
+		Token:          token,
+	}, nil
+}
+
+func convertToProtoOrganization(o *models.Organization) *organization.Organization {
+	return &organization.Organization{
+		Id:        int64(o.ID),
+		Slug:      o.Slug,
+		Name:      o.Name,
+		CreatedAt: o.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func organizationError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrOrganizationNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrOrganizationSlugTaken):
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+	return status.Error(codes.InvalidArgument, err.Error())
+}
@@ -4,38 +4,73 @@ import (
 	"context"
 
 	"github.com/DANazavr/RATest/config"
+	"github.com/DANazavr/RATest/internal/delivery/grpc/policy"
 	"github.com/DANazavr/RATest/internal/delivery/grpc/server/admin"
+	"github.com/DANazavr/RATest/internal/delivery/grpc/server/apikey"
+	"github.com/DANazavr/RATest/internal/delivery/grpc/server/audience"
 	"github.com/DANazavr/RATest/internal/delivery/grpc/server/auth"
+	"github.com/DANazavr/RATest/internal/delivery/grpc/server/invite"
 	"github.com/DANazavr/RATest/internal/delivery/grpc/server/notification"
+	"github.com/DANazavr/RATest/internal/delivery/grpc/server/organization"
+	"github.com/DANazavr/RATest/internal/delivery/grpc/server/template"
+	"github.com/DANazavr/RATest/internal/delivery/grpc/server/user"
+	"github.com/DANazavr/RATest/internal/delivery/grpc/transport"
 	"github.com/DANazavr/RATest/internal/log"
 	"github.com/DANazavr/RATest/internal/services"
 	"google.golang.org/grpc"
 )
 
 type Server struct {
-	ctx                 context.Context
-	logger              *log.Log
-	config              *config.Config
-	adminInterceptor    *admin.InterceptorAdmin
-	authInterceptor     *auth.InterceptorAuth
-	authHendler         *auth.AuthServer
-	notificationHendler *notification.NotificationServer
-	gRPCServer          *grpc.Server
+	ctx                  context.Context
+	logger               *log.Log
+	config               *config.Config
+	policies             *policy.Registry
+	principalInterceptor *transport.InterceptorPrincipal
+	adminInterceptor     *admin.InterceptorAdmin
+	authInterceptor      *auth.InterceptorAuth
+	authHendler          *auth.AuthServer
+	notificationHendler  *notification.NotificationServer
+	apiKeyHendler        *apikey.APIKeyServer
+	inviteHendler        *invite.InviteServer
+	userHendler          *user.UserServer
+	adminHendler         *admin.AdminServer
+	audienceHendler      *audience.AudienceServer
+	organizationHendler  *organization.OrganizationServer
+	templateHendler      *template.TemplateServer
+	gRPCServer           *grpc.Server
 }
 
-func NewServer(ctx context.Context, logger *log.Log, config *config.Config, as *services.AuthService, us *services.UserService, ns *services.NotificationService) *Server {
+func NewServer(ctx context.Context, logger *log.Log, config *config.Config, as *services.AuthService, us *services.UserService, ns *services.NotificationService, ps *services.PermissionService, ks *services.APIKeyService, is *services.InviteService, rs *services.PasswordResetService, vs *services.EmailVerificationService, ls *services.LoginThrottleService, oc *services.OIDCService, au *services.AudienceService, os *services.OrganizationService, ds *services.DataExportService, ts *services.TemplateService) *Server {
+	serverCreds, err := transport.ServerCredentials(config.GRPCTLS)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to load gRPC server credentials: %v", err)
+	}
+
+	policies := policy.NewRegistry()
+	adminInterceptor := admin.NewInterceptorAdmin(ctx, logger, as, ps, ks, policies)
 	s := &Server{
-		ctx:                 ctx,
-		logger:              logger.WithComponent("grpc/server/Server"),
-		config:              config,
-		adminInterceptor:    admin.NewInterceptorAdmin(ctx, logger, as),
-		authInterceptor:     auth.NewInterceptorAuth(ctx, logger, as),
-		authHendler:         auth.NewAuthServer(ctx, logger, us, as),
-		notificationHendler: notification.NewNotificationServer(ctx, logger, us, ns),
+		ctx:                  ctx,
+		logger:               logger.WithComponent("grpc/server/Server"),
+		config:               config,
+		policies:             policies,
+		principalInterceptor: transport.NewInterceptorPrincipal(ctx, logger, config.GRPCTLS.Principals),
+		adminInterceptor:     adminInterceptor,
+		authInterceptor:      auth.NewInterceptorAuth(ctx, logger, as, policies),
+		authHendler:          auth.NewAuthServer(ctx, logger, us, as, is, rs, vs, ls, oc, ns, ts),
+		notificationHendler:  notification.NewNotificationServer(ctx, logger, us, as, ns, vs, au, ts, adminInterceptor),
+		apiKeyHendler:        apikey.NewAPIKeyServer(ctx, logger, ks),
+		inviteHendler:        invite.NewInviteServer(ctx, logger, is),
+		userHendler:          user.NewUserServer(ctx, logger, us, vs, ds),
+		adminHendler:         admin.NewAdminServer(ctx, logger, us, rs, ds),
+		audienceHendler:      audience.NewAudienceServer(ctx, logger, au),
+		organizationHendler:  organization.NewOrganizationServer(ctx, logger, os, is),
+		templateHendler:      template.NewTemplateServer(ctx, logger, ts),
 	}
 
 	s.gRPCServer = grpc.NewServer(
+		grpc.Creds(serverCreds),
 		grpc.ChainUnaryInterceptor(
+			s.principalInterceptor.PrincipalInterceptor,
 			s.adminInterceptor.AdminInterceptor,
 			s.authInterceptor.AuthInterceptor,
 		),
@@ -43,6 +78,18 @@ func NewServer(ctx context.Context, logger *log.Log, config *config.Config, as *
 
 	auth.Register(s.gRPCServer, s.authHendler)
 	notification.Register(s.gRPCServer, s.notificationHendler)
+	apikey.Register(s.gRPCServer, s.apiKeyHendler)
+	invite.Register(s.gRPCServer, s.inviteHendler)
+	user.Register(s.gRPCServer, s.userHendler)
+	admin.Register(s.gRPCServer, s.adminHendler)
+	audience.Register(s.gRPCServer, s.audienceHendler)
+	organization.Register(s.gRPCServer, s.organizationHendler)
+	template.Register(s.gRPCServer, s.templateHendler)
+
+	// Каждый метод обязан объявить правило доступа в proto
+	if err := policies.Load(s.gRPCServer); err != nil {
+		logger.Fatalf(ctx, "Invalid gRPC access policies: %v", err)
+	}
 
 	return s
 }
@@ -0,0 +1,171 @@
+package template
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/common/meta"
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/services"
+	"github.com/DANazavr/RATest/protos/gen/go/template"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type TemplateServer struct {
+	ctx             context.Context
+	logger          *log.Log
+	templateService *services.TemplateService
+	template.UnimplementedTemplatesServer
+}
+
+func NewTemplateServer(ctx context.Context, logger *log.Log, ts *services.TemplateService) *TemplateServer {
+	return &TemplateServer{
+		ctx:             ctx,
+		logger:          logger.WithComponent("grpc/template/TemplateServer"),
+		templateService: ts,
+	}
+}
+
+func Register(gRPC *grpc.Server, templateServer *TemplateServer) {
+	template.RegisterTemplatesServer(gRPC, templateServer)
+}
+
+func (s *TemplateServer) Create(ctx context.Context, req *template.TemplateRequest) (*template.TemplateResponse, error) {
+	t := convertFromProtoTemplate(req.Template)
+	if userIDstr, ok := ctx.Value(meta.UserIDKey).(string); ok {
+		if userID, err := strconv.Atoi(userIDstr); err == nil {
+			t.CreatedBy = &userID
+		}
+	}
+	if err := s.orgTemplates(ctx).Create(t); err != nil {
+		return nil, templateError(err)
+	}
+	return &template.TemplateResponse{Template: convertToProtoTemplate(t)}, nil
+}
+
+func (s *TemplateServer) List(ctx context.Context, req *template.ListRequest) (*template.ListResponse, error) {
+	templates, err := s.orgTemplates(ctx).Templates()
+	if err != nil {
+		s.logger.Errorf(ctx, "Failed to list templates: %v", err)
+		return nil, status.Errorf(codes.Internal, "Failed to list templates: %v", err)
+	}
+	resp := &template.ListResponse{Templates: make([]*template.Template, 0, len(templates))}
+	for _, t := range templates {
+		resp.Templates = append(resp.Templates, convertToProtoTemplate(t))
+	}
+	return resp, nil
+}
+
+func (s *TemplateServer) Get(ctx context.Context, req *template.KeyRequest) (*template.TemplateResponse, error) {
+	t, err := s.orgTemplates(ctx).Template(req.Key)
+	if err != nil {
+		return nil, templateError(err)
+	}
+	return &template.TemplateResponse{Template: convertToProtoTemplate(t)}, nil
+}
+
+func (s *TemplateServer) Update(ctx context.Context, req *template.TemplateRequest) (*template.TemplateResponse, error) {
+	t := convertFromProtoTemplate(req.Template)
+	if err := s.orgTemplates(ctx).Update(t); err != nil {
+		return nil, templateError(err)
+	}
+	return &template.TemplateResponse{Template: convertToProtoTemplate(t)}, nil
+}
+
+func (s *TemplateServer) Delete(ctx context.Context, req *template.KeyRequest) (*template.MessageResponse, error) {
+	if err := s.orgTemplates(ctx).Delete(req.Key); err != nil {
+		return nil, templateError(err)
+	}
+	return &template.MessageResponse{Message: "Template deleted"}, nil
+}
+
+func (s *TemplateServer) Preview(ctx context.Context, req *template.PreviewRequest) (*template.PreviewResponse, error) {
+	var vars map[string]interface{}
+	if req.Variables != nil {
+		vars = req.Variables.AsMap()
+	}
+	p, err := s.orgTemplates(ctx).Preview(req.Key, req.Locale, vars)
+	if err != nil {
+		return nil, templateError(err)
+	}
+	return &template.PreviewResponse{
+		Locale:    p.Locale,
+		Title:     p.Notification.Title,
+		Message:   p.Notification.Message,
+		Category:  p.Notification.Category,
+		Priority:  p.Notification.Priority,
+		Icon:      p.Notification.Icon,
+		ActionUrl: p.Notification.ActionURL,
+		Image:     p.Notification.Image,
+	}, nil
+}
+
+func templateError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrTemplateNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrTemplateKeyTaken):
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+	return status.Errorf(codes.InvalidArgument, "Invalid template: %v", err)
+}
+
+func convertFromProtoTemplate(pt *template.Template) *models.NotificationTemplate {
+	t := &models.NotificationTemplate{
+		Key:           pt.GetKey(),
+		Description:   pt.GetDescription(),
+		Category:      pt.GetCategory(),
+		Priority:      pt.GetPriority(),
+		Icon:          pt.GetIcon(),
+		Image:         pt.GetImage(),
+		DefaultLocale: pt.GetDefaultLocale(),
+		Variables:     make([]models.TemplateVariable, 0, len(pt.GetVariables())),
+		Locales:       make(map[string]models.TemplateContent, len(pt.GetLocales())),
+	}
+	for _, v := range pt.GetVariables() {
+		t.Variables = append(t.Variables, models.TemplateVariable{Name: v.Name, Type: v.Type, Required: v.Required})
+	}
+	for loc, c := range pt.GetLocales() {
+		t.Locales[loc] = models.TemplateContent{Title: c.GetTitle(), Message: c.GetMessage(), ActionURL: c.GetActionUrl()}
+	}
+	return t
+}
+
+func convertToProtoTemplate(t *models.NotificationTemplate) *template.Template {
+	pt := &template.Template{
+		Id:            int64(t.ID),
+		Key:           t.Key,
+		Description:   t.Description,
+		Category:      t.Category,
+		Priority:      t.Priority,
+		Icon:          t.Icon,
+		Image:         t.Image,
+		DefaultLocale: t.DefaultLocale,
+		Variables:     make([]*template.Variable, 0, len(t.Variables)),
+		Locales:       make(map[string]*template.Content, len(t.Locales)),
+		CreatedAt:     t.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     t.UpdatedAt.Format(time.RFC3339),
+	}
+	for _, v := range t.Variables {
+		pt.Variables = append(pt.Variables, &template.Variable{Name: v.Name, Type: v.Type, Required: v.Required})
+	}
+	for loc, c := range t.Locales {
+		pt.Locales[loc] = &template.Content{Title: c.Title, Message: c.Message, ActionUrl: c.ActionURL}
+	}
+	if t.CreatedBy != nil {
+		pt.CreatedBy = int64(*t.CreatedBy)
+	}
+	return pt
+}
+
+// orgTemplates возвращает сервис шаблонов организации администратора.
+func (s *TemplateServer) orgTemplates(ctx context.Context) *services.TemplateService {
+	orgID, _ := ctx.Value(meta.OrgIDKey).(int)
+	return s.templateService.ForOrg(orgID)
+}
@@ -0,0 +1,105 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/protos/gen/go/user"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *UserServer) RequestExport(ctx context.Context, req *user.RequestExportRequest) (*user.ExportResponse, error) {
+	u, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	e, err := s.dataExportService.ForOrg(u.OrgID).Request(ctx, u.ID, &u.ID)
+	if err != nil {
+		return nil, ExportError(err)
+	}
+	return &user.ExportResponse{Export: ConvertToProtoExport(e)}, nil
+}
+
+func (s *UserServer) ListExports(ctx context.Context, req *user.ListExportsRequest) (*user.ListExportsResponse, error) {
+	u, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	exports, err := s.dataExportService.ForOrg(u.OrgID).Exports(u.ID)
+	if err != nil {
+		return nil, ExportError(err)
+	}
+	return ConvertToProtoExports(exports), nil
+}
+
+func (s *UserServer) DownloadExport(ctx context.Context, req *user.DownloadExportRequest) (*user.DownloadExportResponse, error) {
+	u, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	exports := s.dataExportService.ForOrg(u.OrgID)
+	e, err := exports.Export(int(req.Id))
+	if err == nil && e.UserID != u.ID {
+		err = domain.ErrDataExportNotFound
+	}
+	if err != nil {
+		return nil, ExportError(err)
+	}
+	archive, err := exports.Archive(e.ID)
+	if err != nil {
+		return nil, ExportError(err)
+	}
+	return &user.DownloadExportResponse{Filename: ExportFilename(e.ID), Archive: archive}, nil
+}
+
+func ExportFilename(id int) string {
+	return fmt.Sprintf("export-%d.zip", id)
+}
+
+func ConvertToProtoExport(e *models.DataExport) *user.Export {
+	var requestedBy int64
+	if e.RequestedBy != nil {
+		requestedBy = int64(*e.RequestedBy)
+	}
+	var completedAt, expiresAt string
+	if e.CompletedAt != nil {
+		completedAt = e.CompletedAt.Format(time.RFC3339)
+	}
+	if e.ExpiresAt != nil {
+		expiresAt = e.ExpiresAt.Format(time.RFC3339)
+	}
+	return &user.Export{
+		Id:          int64(e.ID),
+		UserId:      int64(e.UserID),
+		RequestedBy: requestedBy,
+		Status:      e.Status,
+		Error:       e.Error,
+		CreatedAt:   e.CreatedAt.Format(time.RFC3339),
+		CompletedAt: completedAt,
+		ExpiresAt:   expiresAt,
+	}
+}
+
+func ConvertToProtoExports(exports []*models.DataExport) *user.ListExportsResponse {
+	protoExports := make([]*user.Export, 0, len(exports))
+	for _, e := range exports {
+		protoExports = append(protoExports, ConvertToProtoExport(e))
+	}
+	return &user.ListExportsResponse{Exports: protoExports}
+}
+
+func ExportError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrDataExportNotFound),
+		errors.Is(err, domain.ErrUserNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrDataExportNotReady):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Errorf(codes.Internal, "Data export failed: %v", err)
+}
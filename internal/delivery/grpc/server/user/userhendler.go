@@ -0,0 +1,142 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/common/meta"
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/services"
+	"github.com/DANazavr/RATest/protos/gen/go/user"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type UserServer struct {
+	ctx               context.Context
+	logger            *log.Log
+	userService       *services.UserService
+	verifyService     *services.EmailVerificationService
+	dataExportService *services.DataExportService
+	user.UnimplementedUserServer
+}
+
+func NewUserServer(ctx context.Context, logger *log.Log, us *services.UserService, vs *services.EmailVerificationService, ds *services.DataExportService) *UserServer {
+	return &UserServer{
+		ctx:               ctx,
+		logger:            logger.WithComponent("grpc/user/UserServer"),
+		userService:       us,
+		verifyService:     vs,
+		dataExportService: ds,
+	}
+}
+
+func Register(gRPC *grpc.Server, userServer *UserServer) {
+	user.RegisterUserServer(gRPC, userServer)
+}
+
+func (s *UserServer) GetProfile(ctx context.Context, req *user.GetProfileRequest) (*user.GetProfileResponse, error) {
+	u, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &user.GetProfileResponse{User: ConvertToProtoProfile(u)}, nil
+}
+
+func (s *UserServer) ChangePassword(ctx context.Context, req *user.ChangePasswordRequest) (*user.ChangePasswordResponse, error) {
+	u, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userService.ChangePassword(ctx, u, req.CurrentPassword, req.NewPassword, sessionID(ctx)); err != nil {
+		return nil, accountError(err)
+	}
+	return &user.ChangePasswordResponse{Message: "Password changed, all sessions revoked"}, nil
+}
+
+func (s *UserServer) ChangeEmail(ctx context.Context, req *user.ChangeEmailRequest) (*user.ChangeEmailResponse, error) {
+	u, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userService.Reauthenticate(u, req.Password, sessionID(ctx)); err != nil {
+		return nil, accountError(err)
+	}
+	if err := s.verifyService.RequestChange(ctx, u, req.Email); err != nil {
+		return nil, accountError(err)
+	}
+	return &user.ChangeEmailResponse{Message: "Confirmation email sent to the new address"}, nil
+}
+
+func (s *UserServer) DeleteAccount(ctx context.Context, req *user.DeleteAccountRequest) (*user.DeleteAccountResponse, error) {
+	u, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userService.DeleteAccount(ctx, u, req.Password, sessionID(ctx)); err != nil {
+		return nil, accountError(err)
+	}
+	return &user.DeleteAccountResponse{Message: "Account deleted"}, nil
+}
+
+// currentUser загружает владельца access токена из контекста вызова.
+func (s *UserServer) currentUser(ctx context.Context) (*models.User, error) {
+	userIDstr, ok := ctx.Value(meta.UserIDKey).(string)
+	if !ok || userIDstr == "" {
+		return nil, status.Error(codes.Unauthenticated, "User ID is not provided")
+	}
+	userID, err := strconv.Atoi(userIDstr)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid user ID: %v", userIDstr)
+	}
+	u, err := s.userService.UsersGetById(userID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "User not found: %v", err)
+	}
+	return u, nil
+}
+
+// sessionID возвращает семейство сессий, которым выдан access токен вызова.
+func sessionID(ctx context.Context) string {
+	sid, _ := ctx.Value(meta.SessionIDKey).(string)
+	return sid
+}
+
+func accountError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrIncorrectPassword), errors.Is(err, domain.ErrReauthenticationRequired):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, domain.ErrEmailTaken),
+		errors.Is(err, domain.ErrEmailAlreadyVerified):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrLastAdmin):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrUserNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Errorf(codes.InvalidArgument, "Account update failed: %v", err)
+}
+
+func ConvertToProtoProfile(u *models.User) *user.Profile {
+	var verifiedAt, disabledAt string
+	if u.EmailVerifiedAt != nil {
+		verifiedAt = u.EmailVerifiedAt.Format(time.RFC3339)
+	}
+	if u.DisabledAt != nil {
+		disabledAt = u.DisabledAt.Format(time.RFC3339)
+	}
+	return &user.Profile{
+		Id:              int64(u.ID),
+		Username:        u.Username,
+		Email:           u.Email,
+		EmailVerifiedAt: verifiedAt,
+		Role:            u.Role,
+		CreatedAt:       u.CreatedAt,
+		DisabledAt:      disabledAt,
+	}
+}
@@ -0,0 +1,94 @@
+package transport
+
+import (
+	"context"
+	"crypto/x509"
+	"slices"
+
+	"github.com/DANazavr/RATest/config"
+	"github.com/DANazavr/RATest/internal/common/meta"
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// Principal - сервис, опознанный по проверенному клиентскому сертификату.
+type Principal struct {
+	Name        string
+	Identity    string
+	Permissions []string
+	// Организация, в которой действует сервис
+	OrgID int
+}
+
+func (p *Principal) HasPermission(permission string) bool {
+	return slices.Contains(p.Permissions, permission)
+}
+
+// PrincipalFromContext возвращает принципал вызова, если клиент предъявил
+// сертификат, известный серверу.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(meta.PrincipalKey).(*Principal)
+	return p, ok
+}
+
+type InterceptorPrincipal struct {
+	ctx        context.Context
+	logger     *log.Log
+	principals map[string]config.PrincipalConfig
+}
+
+func NewInterceptorPrincipal(ctx context.Context, logger *log.Log, principals map[string]config.PrincipalConfig) *InterceptorPrincipal {
+	return &InterceptorPrincipal{
+		ctx:        ctx,
+		logger:     logger.WithComponent("grpc/transport/InterceptorPrincipal"),
+		principals: principals,
+	}
+}
+
+// PrincipalInterceptor кладёт в контекст принципал клиентского сертификата.
+// Сертификат без сопоставленного принципала вызов не запрещает.
+func (ip *InterceptorPrincipal) PrincipalInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if p, ok := ip.principalFromPeer(ctx); ok {
+		ctx = context.WithValue(ctx, meta.PrincipalKey, p)
+		ip.logger.Debugf(ctx, "Call %s from principal %s (%s)", info.FullMethod, p.Name, p.Identity)
+	}
+	return handler(ctx, req)
+}
+
+func (ip *InterceptorPrincipal) principalFromPeer(ctx context.Context) (*Principal, bool) {
+	pr, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+	tlsInfo, ok := pr.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil, false
+	}
+	for _, id := range identities(tlsInfo.State.VerifiedChains[0][0]) {
+		if cfg, ok := ip.principals[id]; ok {
+			p := &Principal{Name: cfg.Name, Identity: id, Permissions: cfg.Permissions, OrgID: cfg.OrgID}
+			if p.OrgID == 0 {
+				p.OrgID = domain.DefaultOrgID
+			}
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// identities перечисляет идентичности сертификата в порядке приоритета:
+// URI SAN (например, SPIFFE ID), DNS SAN, затем CN.
+func identities(cert *x509.Certificate) []string {
+	ids := make([]string, 0, len(cert.URIs)+len(cert.DNSNames)+1)
+	for _, u := range cert.URIs {
+		ids = append(ids, u.String())
+	}
+	ids = append(ids, cert.DNSNames...)
+	if cert.Subject.CommonName != "" {
+		ids = append(ids, cert.Subject.CommonName)
+	}
+	return ids
+}
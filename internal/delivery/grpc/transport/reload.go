@@ -0,0 +1,120 @@
+package transport
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// keyPair - сертификат с ключом, которые перечитываются с диска, как только
+// меняется время изменения любого из файлов. Проверка идёт при каждом
+// рукопожатии, поэтому обновлённый сертификат применяется без перезапуска.
+type keyPair struct {
+	certFile string
+	keyFile  string
+
+	mu      sync.Mutex
+	modTime time.Time
+	cert    *tls.Certificate
+}
+
+func newKeyPair(certFile string, keyFile string) (*keyPair, error) {
+	kp := &keyPair{certFile: certFile, keyFile: keyFile}
+	if _, err := kp.get(); err != nil {
+		return nil, err
+	}
+	return kp, nil
+}
+
+// get возвращает актуальный сертификат. Если новые файлы не читаются
+// (например, записаны наполовину), продолжает отдавать прежний.
+func (kp *keyPair) get() (*tls.Certificate, error) {
+	kp.mu.Lock()
+	defer kp.mu.Unlock()
+
+	mod, err := latestModTime(kp.certFile, kp.keyFile)
+	if err != nil {
+		if kp.cert != nil {
+			return kp.cert, nil
+		}
+		return nil, err
+	}
+	if kp.cert != nil && mod.Equal(kp.modTime) {
+		return kp.cert, nil
+	}
+	cert, err := tls.LoadX509KeyPair(kp.certFile, kp.keyFile)
+	if err != nil {
+		if kp.cert != nil {
+			return kp.cert, nil
+		}
+		return nil, fmt.Errorf("load key pair %s: %w", kp.certFile, err)
+	}
+	kp.cert, kp.modTime = &cert, mod
+	return kp.cert, nil
+}
+
+// certPool - набор доверенных CA из PEM файла, перечитываемый при изменении.
+type certPool struct {
+	file string
+
+	mu      sync.Mutex
+	modTime time.Time
+	pool    *x509.CertPool
+}
+
+func newCertPool(file string) (*certPool, error) {
+	cp := &certPool{file: file}
+	if _, err := cp.get(); err != nil {
+		return nil, err
+	}
+	return cp, nil
+}
+
+func (cp *certPool) get() (*x509.CertPool, error) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	mod, err := latestModTime(cp.file)
+	if err != nil {
+		if cp.pool != nil {
+			return cp.pool, nil
+		}
+		return nil, err
+	}
+	if cp.pool != nil && mod.Equal(cp.modTime) {
+		return cp.pool, nil
+	}
+	data, err := os.ReadFile(cp.file)
+	if err != nil {
+		if cp.pool != nil {
+			return cp.pool, nil
+		}
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		if cp.pool != nil {
+			return cp.pool, nil
+		}
+		return nil, fmt.Errorf("no certificates in %s", cp.file)
+	}
+	cp.pool, cp.modTime = pool, mod
+	return cp.pool, nil
+}
+
+func latestModTime(files ...string) (time.Time, error) {
+	var latest time.Time
+	for _, f := range files {
+		info, err := os.Stat(f)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
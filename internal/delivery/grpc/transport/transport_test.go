@@ -0,0 +1,164 @@
+package transport_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DANazavr/RATest/config"
+	"github.com/DANazavr/RATest/internal/delivery/grpc/transport"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T, dir string) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	writePEM(t, filepath.Join(dir, "ca.pem"), "CERTIFICATE", der)
+	return &testCA{cert: cert, key: key}
+}
+
+// issue выпускает сертификат и записывает его в <name>.pem и <name>-key.pem.
+func (ca *testCA) issue(t *testing.T, dir string, name string, serial int64, dnsName string, uri string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{dnsName},
+	}
+	if uri != "" {
+		u, err := url.Parse(uri)
+		require.NoError(t, err)
+		tmpl.URIs = []*url.URL{u}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	writePEM(t, filepath.Join(dir, name+".pem"), "CERTIFICATE", der)
+	writePEM(t, filepath.Join(dir, name+"-key.pem"), "PRIVATE KEY", keyDER)
+}
+
+func writePEM(t *testing.T, path string, typ string, der []byte) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600))
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, dir)
+	ca.issue(t, dir, "server", 2, "grpc.internal", "")
+	ca.issue(t, dir, "gateway", 3, "gateway.internal", "spiffe://ratest/gateway")
+
+	cfg := config.GRPCTLSConfig{
+		CertFile:          filepath.Join(dir, "server.pem"),
+		KeyFile:           filepath.Join(dir, "server-key.pem"),
+		CAFile:            filepath.Join(dir, "ca.pem"),
+		ServerName:        "grpc.internal",
+		ClientCAFile:      filepath.Join(dir, "ca.pem"),
+		RequireClientCert: true,
+		ClientCertFile:    filepath.Join(dir, "gateway.pem"),
+		ClientKeyFile:     filepath.Join(dir, "gateway-key.pem"),
+		Principals: map[string]config.PrincipalConfig{
+			"spiffe://ratest/gateway": {Name: "gateway", Permissions: []string{"notification:publish"}},
+		},
+	}
+
+	serverCreds, err := transport.ServerCredentials(cfg)
+	require.NoError(t, err)
+	principals := transport.NewInterceptorPrincipal(t.Context(), log.NewLog(t.Context(), &log.LogConfig{Component: "test"}), cfg.Principals)
+
+	var got *transport.Principal
+	srv := grpc.NewServer(grpc.Creds(serverCreds), grpc.ChainUnaryInterceptor(
+		principals.PrincipalInterceptor,
+		func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			got, _ = transport.PrincipalFromContext(ctx)
+			return handler(ctx, req)
+		},
+	))
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	call := func(creds credentials.TransportCredentials) (*x509.Certificate, error) {
+		conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(creds))
+		require.NoError(t, err)
+		defer conn.Close()
+		var p peer.Peer
+		_, err = healthpb.NewHealthClient(conn).Check(t.Context(), &healthpb.HealthCheckRequest{}, grpc.Peer(&p))
+		if err != nil {
+			return nil, err
+		}
+		return p.AuthInfo.(credentials.TLSInfo).State.PeerCertificates[0], nil
+	}
+
+	clientCreds, err := transport.ClientCredentials(cfg)
+	require.NoError(t, err)
+	serverCert, err := call(clientCreds)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), serverCert.SerialNumber.Int64())
+	require.NotNil(t, got)
+	assert.Equal(t, "gateway", got.Name)
+	assert.True(t, got.HasPermission("notification:publish"))
+
+	// Без клиентского сертификата сервер соединение не принимает
+	noCert := cfg
+	noCert.ClientCertFile = ""
+	noCertCreds, err := transport.ClientCredentials(noCert)
+	require.NoError(t, err)
+	_, err = call(noCertCreds)
+	assert.Error(t, err)
+
+	// Новый сертификат сервера подхватывается без перезапуска
+	time.Sleep(10 * time.Millisecond)
+	ca.issue(t, dir, "server", 4, "grpc.internal", "")
+	now := time.Now().Add(time.Second)
+	require.NoError(t, os.Chtimes(cfg.CertFile, now, now))
+	serverCert, err = call(clientCreds)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), serverCert.SerialNumber.Int64())
+}
@@ -1,8 +1,11 @@
 package admin
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/DANazavr/RATest/internal/common/meta"
@@ -10,29 +13,40 @@ import (
 	"github.com/DANazavr/RATest/internal/domain"
 	"github.com/DANazavr/RATest/internal/log"
 	"github.com/DANazavr/RATest/internal/services"
-	"github.com/golang-jwt/jwt/v4"
 )
 
 type MiddlewareAdmin struct {
-	ctx         context.Context
-	logger      *log.Log
-	authService *services.AuthService
+	ctx               context.Context
+	logger            *log.Log
+	authService       *services.AuthService
+	permissionService *services.PermissionService
+	apiKeyService     *services.APIKeyService
 }
 
-func NewMiddlewareAdmin(ctx context.Context, logger *log.Log, as *services.AuthService) *MiddlewareAdmin {
+func NewMiddlewareAdmin(ctx context.Context, logger *log.Log, as *services.AuthService, ps *services.PermissionService, ks *services.APIKeyService) *MiddlewareAdmin {
 	return &MiddlewareAdmin{
-		ctx:         ctx,
-		logger:      logger.WithComponent("auth/adminMiddleware"),
-		authService: as,
+		ctx:               ctx,
+		logger:            logger.WithComponent("auth/adminMiddleware"),
+		authService:       as,
+		permissionService: ps,
+		apiKeyService:     ks,
 	}
 }
 
-func (ma *MiddlewareAdmin) Admin(next http.Handler) http.Handler {
-	return ma.AdminMiddleware(next)
+// Require пропускает запрос, только если роль владельца токена имеет право permission.
+func (ma *MiddlewareAdmin) Require(permission string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return ma.PermissionMiddleware(permission, next)
+	}
 }
 
-func (ma *MiddlewareAdmin) AdminMiddleware(next http.Handler) http.Handler {
+func (ma *MiddlewareAdmin) PermissionMiddleware(permission string, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if key := r.Header.Get("X-API-Key"); key != "" {
+			ma.serveAPIKey(w, r, permission, key, next)
+			return
+		}
+
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
 			delivery.HendleError(w, r, http.StatusUnauthorized, domain.ErrEmptyToken)
@@ -47,31 +61,82 @@ func (ma *MiddlewareAdmin) AdminMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
-		token, err := ma.authService.ParseToken(tokenParts[1])
+		claims, err := ma.authService.ValidateAccessToken(tokenParts[1])
 		if err != nil {
-			delivery.HendleError(w, r, http.StatusUnauthorized, err)
-			ma.logger.Warnf(r.Context(), "Failed to parse token: %v", err)
+			delivery.HendleError(w, r, http.StatusUnauthorized, domain.ErrInvalidToken)
+			ma.logger.Warnf(r.Context(), "Failed to validate token: %v", err)
 			return
 		}
 
-		if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-			tokenType, ok := claims["type"].(string)
-			if !ok || tokenType != "access" {
-				delivery.HendleError(w, r, http.StatusUnauthorized, domain.ErrInvalidToken)
-				ma.logger.Warnf(context.WithValue(r.Context(), meta.UserIDKey, claims["sub"]), "Invalid token type: %v", tokenType)
-				return
-			}
-			tokenRole, ok := claims["role"].(string)
-			if !ok || tokenRole != "admin" {
-				delivery.HendleError(w, r, http.StatusUnauthorized, domain.ErrInvalidToken)
-				ma.logger.Warnf(context.WithValue(r.Context(), meta.UserIDKey, claims["sub"]), "Unauthorized access attempt with role: %v", tokenRole)
-				return
-			}
-			ctx := context.WithValue(r.Context(), meta.UserIDKey, claims["sub"])
-			r = r.WithContext(ctx)
-			ma.logger.Infof(ctx, "Admin authenticated with user ID: %v", claims["sub"])
+		tokenRole, _ := claims["role"].(string)
+		allowed, err := ma.permissionService.HasPermission(tokenRole, permission)
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
 		}
+		if !allowed {
+			delivery.HendleError(w, r, http.StatusForbidden, domain.ErrPermissionDenied)
+			ma.logger.Warnf(context.WithValue(r.Context(), meta.UserIDKey, claims["sub"]), "Role %v lacks permission %s", tokenRole, permission)
+			return
+		}
+		ctx := context.WithValue(r.Context(), meta.UserIDKey, claims["sub"])
+		ctx = context.WithValue(ctx, meta.SessionIDKey, claims["sid"])
+		ctx = context.WithValue(ctx, meta.RoleKey, tokenRole)
+		ctx = context.WithValue(ctx, meta.OrgIDKey, services.TokenOrgID(claims))
+		r = r.WithContext(ctx)
+		ma.logger.Infof(ctx, "User %v authorized for %s", claims["sub"], permission)
 
 		next.ServeHTTP(w, r)
 	})
 }
+
+// serveAPIKey авторизует запрос интеграции по API ключу. Если ключ требует
+// подписи, HMAC считается от "<method>\n<request uri>\n<body>".
+func (ma *MiddlewareAdmin) serveAPIKey(w http.ResponseWriter, r *http.Request, permission string, key string, next http.Handler) {
+	k, err := ma.apiKeyService.Authenticate(key)
+	if err != nil {
+		delivery.HendleError(w, r, http.StatusUnauthorized, domain.ErrInvalidAPIKey)
+		ma.logger.Warnf(r.Context(), "Failed to authenticate API key: %v", err)
+		return
+	}
+
+	if k.RequireSignature {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		payload := []byte(r.Method + "\n" + r.URL.RequestURI() + "\n")
+		payload = append(payload, body...)
+		if err := ma.apiKeyService.VerifySignature(key, r.Header.Get("X-Timestamp"), r.Header.Get("X-Signature"), payload); err != nil {
+			delivery.HendleError(w, r, http.StatusUnauthorized, err)
+			ma.logger.Warnf(r.Context(), "Invalid signature for API key %d", k.ID)
+			return
+		}
+	}
+
+	if !ma.apiKeyService.HasScope(k, permission) {
+		delivery.HendleError(w, r, http.StatusForbidden, domain.ErrPermissionDenied)
+		ma.logger.Warnf(r.Context(), "API key %d lacks scope %s", k.ID, permission)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), meta.APIKeyIDKey, k.ID)
+	ctx = context.WithValue(ctx, meta.OrgIDKey, k.OrgID)
+	ctx = context.WithValue(ctx, meta.ScopesKey, k.Scopes)
+	ma.logger.Infof(ctx, "API key %d (%s) authorized for %s", k.ID, k.Name, permission)
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// Allowed сообщает, есть ли право permission у того, от чьего имени выполняется
+// уже авторизованный запрос ctx: у API ключа - среди его прав, у пользователя -
+// у его роли.
+func (ma *MiddlewareAdmin) Allowed(ctx context.Context, permission string) (bool, error) {
+	if scopes, ok := ctx.Value(meta.ScopesKey).([]string); ok {
+		return slices.Contains(scopes, permission), nil
+	}
+	role, _ := ctx.Value(meta.RoleKey).(string)
+	return ma.permissionService.HasPermission(role, permission)
+}
@@ -0,0 +1,27 @@
+package admin_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DANazavr/RATest/internal/common/meta"
+	"github.com/DANazavr/RATest/internal/delivery/http/admin"
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddlewareAdmin_AllowedAPIKeyScopes(t *testing.T) {
+	ma := admin.NewMiddlewareAdmin(t.Context(), log.NewLog(t.Context(), &log.LogConfig{Component: "http", LogLevel: "debug"}), nil, nil, nil)
+
+	// Ключу с правом публикации рассылка группе или сегменту недоступна
+	ctx := context.WithValue(t.Context(), meta.ScopesKey, []string{domain.PermNotificationPublish})
+	allowed, err := ma.Allowed(ctx, domain.PermNotificationBroadcast)
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+
+	ctx = context.WithValue(t.Context(), meta.ScopesKey, []string{domain.PermNotificationPublish, domain.PermNotificationBroadcast})
+	allowed, err = ma.Allowed(ctx, domain.PermNotificationBroadcast)
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+}
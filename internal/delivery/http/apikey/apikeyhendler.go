@@ -0,0 +1,107 @@
+package apikey
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/DANazavr/RATest/internal/common/meta"
+	delivery "github.com/DANazavr/RATest/internal/delivery/http"
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/services"
+	"github.com/gorilla/mux"
+)
+
+type APIKeyHendler struct {
+	ctx           context.Context
+	logger        *log.Log
+	apiKeyService *services.APIKeyService
+}
+
+func NewAPIKeyHendler(ctx context.Context, logger *log.Log, ks *services.APIKeyService) *APIKeyHendler {
+	return &APIKeyHendler{
+		ctx:           ctx,
+		logger:        logger.WithComponent("apikey/apiKeyHendler"),
+		apiKeyService: ks,
+	}
+}
+
+func (h *APIKeyHendler) HandleCreate() http.HandlerFunc {
+	type request struct {
+		Name             string   `json:"name"`
+		Scopes           []string `json:"scopes"`
+		RequireSignature bool     `json:"require_signature"`
+	}
+	type response struct {
+		APIKey *models.APIKey `json:"api_key"`
+		Key    string         `json:"key"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		k := &models.APIKey{
+			Name:             req.Name,
+			Scopes:           req.Scopes,
+			RequireSignature: req.RequireSignature,
+		}
+		if userIDstr, ok := r.Context().Value(meta.UserIDKey).(string); ok {
+			if userID, err := strconv.Atoi(userIDstr); err == nil {
+				k.CreatedBy = &userID
+			}
+		}
+
+		role, _ := r.Context().Value(meta.RoleKey).(string)
+		key, err := h.orgAPIKeys(r).Create(k, role)
+		if errors.Is(err, domain.ErrScopeNotGranted) {
+			delivery.HendleError(w, r, http.StatusForbidden, err)
+			return
+		} else if err != nil {
+			delivery.HendleError(w, r, http.StatusUnprocessableEntity, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusCreated, response{APIKey: k, Key: key})
+	}
+}
+
+func (h *APIKeyHendler) HandleList() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		keys, err := h.orgAPIKeys(r).Get()
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, keys)
+	}
+}
+
+func (h *APIKeyHendler) HandleRevoke() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		if err := h.orgAPIKeys(r).Revoke(id); err != nil {
+			if errors.Is(err, domain.ErrAPIKeyNotFound) {
+				delivery.HendleError(w, r, http.StatusNotFound, err)
+				return
+			}
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusNoContent, nil)
+	}
+}
+
+// orgAPIKeys возвращает сервис ключей организации, от имени которой сделан запрос.
+func (h *APIKeyHendler) orgAPIKeys(r *http.Request) *services.APIKeyService {
+	orgID, _ := r.Context().Value(meta.OrgIDKey).(int)
+	return h.apiKeyService.ForOrg(orgID)
+}
@@ -0,0 +1,244 @@
+package audience
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/DANazavr/RATest/internal/common/meta"
+	delivery "github.com/DANazavr/RATest/internal/delivery/http"
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/services"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/gorilla/mux"
+)
+
+type AudienceHendler struct {
+	ctx             context.Context
+	logger          *log.Log
+	audienceService *services.AudienceService
+}
+
+func NewAudienceHendler(ctx context.Context, logger *log.Log, au *services.AudienceService) *AudienceHendler {
+	return &AudienceHendler{
+		ctx:             ctx,
+		logger:          logger.WithComponent("audience/audienceHendler"),
+		audienceService: au,
+	}
+}
+
+func (h *AudienceHendler) HandleCreateGroup() http.HandlerFunc {
+	type request struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		g := &models.Group{
+			Name:        req.Name,
+			Description: req.Description,
+			CreatedBy:   currentUserID(r),
+		}
+		if err := h.orgAudience(r).CreateGroup(g); err != nil {
+			delivery.HendleError(w, r, audienceErrorStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusCreated, g)
+	}
+}
+
+func (h *AudienceHendler) HandleListGroups() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		groups, err := h.orgAudience(r).Groups()
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, groups)
+	}
+}
+
+func (h *AudienceHendler) HandleDeleteGroup() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := idFromPath(w, r, "id")
+		if !ok {
+			return
+		}
+		if err := h.orgAudience(r).DeleteGroup(id); err != nil {
+			delivery.HendleError(w, r, audienceErrorStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusNoContent, nil)
+	}
+}
+
+func (h *AudienceHendler) HandleListGroupMembers() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := idFromPath(w, r, "id")
+		if !ok {
+			return
+		}
+		users, err := h.orgAudience(r).GroupMembers(id)
+		if err != nil {
+			delivery.HendleError(w, r, audienceErrorStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, users)
+	}
+}
+
+func (h *AudienceHendler) HandleAddGroupMembers() http.HandlerFunc {
+	type request struct {
+		UserIDs []int `json:"user_ids"`
+	}
+	type response struct {
+		Added int `json:"added"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := idFromPath(w, r, "id")
+		if !ok {
+			return
+		}
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		n, err := h.orgAudience(r).AddGroupMembers(id, req.UserIDs)
+		if err != nil {
+			delivery.HendleError(w, r, audienceErrorStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, response{Added: n})
+	}
+}
+
+func (h *AudienceHendler) HandleRemoveGroupMember() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := idFromPath(w, r, "id")
+		if !ok {
+			return
+		}
+		userID, ok := idFromPath(w, r, "user_id")
+		if !ok {
+			return
+		}
+		if err := h.orgAudience(r).RemoveGroupMember(id, userID); err != nil {
+			delivery.HendleError(w, r, audienceErrorStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusNoContent, nil)
+	}
+}
+
+func (h *AudienceHendler) HandleCreateSegment() http.HandlerFunc {
+	type request struct {
+		Name        string              `json:"name"`
+		Description string              `json:"description"`
+		Rules       models.SegmentRules `json:"rules"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		s := &models.Segment{
+			Name:        req.Name,
+			Description: req.Description,
+			Rules:       req.Rules,
+			CreatedBy:   currentUserID(r),
+		}
+		if err := h.orgAudience(r).CreateSegment(s); err != nil {
+			delivery.HendleError(w, r, audienceErrorStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusCreated, s)
+	}
+}
+
+func (h *AudienceHendler) HandleListSegments() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		segments, err := h.orgAudience(r).Segments()
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, segments)
+	}
+}
+
+func (h *AudienceHendler) HandleListSegmentMembers() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := idFromPath(w, r, "id")
+		if !ok {
+			return
+		}
+		users, err := h.orgAudience(r).SegmentMembers(id)
+		if err != nil {
+			delivery.HendleError(w, r, audienceErrorStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, users)
+	}
+}
+
+func (h *AudienceHendler) HandleDeleteSegment() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := idFromPath(w, r, "id")
+		if !ok {
+			return
+		}
+		if err := h.orgAudience(r).DeleteSegment(id); err != nil {
+			delivery.HendleError(w, r, audienceErrorStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusNoContent, nil)
+	}
+}
+
+func currentUserID(r *http.Request) *int {
+	if userIDstr, ok := r.Context().Value(meta.UserIDKey).(string); ok {
+		if userID, err := strconv.Atoi(userIDstr); err == nil {
+			return &userID
+		}
+	}
+	return nil
+}
+
+func idFromPath(w http.ResponseWriter, r *http.Request, name string) (int, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)[name])
+	if err != nil {
+		delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+		return 0, false
+	}
+	return id, true
+}
+
+func audienceErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrGroupNotFound),
+		errors.Is(err, domain.ErrSegmentNotFound),
+		errors.Is(err, domain.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrAudienceNameTaken):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrUnknownRole), errors.Is(err, domain.ErrInvalidFilter), errors.As(err, new(validation.Errors)):
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusInternalServerError
+}
+
+// orgAudience возвращает сервис групп и сегментов организации администратора.
+func (h *AudienceHendler) orgAudience(r *http.Request) *services.AudienceService {
+	orgID, _ := r.Context().Value(meta.OrgIDKey).(int)
+	return h.audienceService.ForOrg(orgID)
+}
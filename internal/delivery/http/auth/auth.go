@@ -10,7 +10,6 @@ import (
 	"github.com/DANazavr/RATest/internal/domain"
 	"github.com/DANazavr/RATest/internal/log"
 	"github.com/DANazavr/RATest/internal/services"
-	"github.com/golang-jwt/jwt/v4"
 )
 
 type MiddlewareAuth struct {
@@ -47,24 +46,19 @@ func (am *MiddlewareAuth) AuthMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
-		token, err := am.authService.ParseToken(tokenParts[1])
+		claims, err := am.authService.ValidateAccessToken(tokenParts[1])
 		if err != nil {
-			delivery.HendleError(w, r, http.StatusUnauthorized, err)
-			am.logger.Warnf(r.Context(), "Failed to parse token: %v", err)
+			delivery.HendleError(w, r, http.StatusUnauthorized, domain.ErrInvalidToken)
+			am.logger.Warnf(r.Context(), "Failed to validate token: %v", err)
 			return
 		}
 
-		if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-			tokenType, ok := claims["type"].(string)
-			if !ok || tokenType != "access" {
-				delivery.HendleError(w, r, http.StatusUnauthorized, domain.ErrInvalidToken)
-				am.logger.Warnf(r.Context(), "Invalid token type: %v", tokenType)
-				return
-			}
-			ctxWithUser := context.WithValue(r.Context(), meta.UserIDKey, claims["sub"])
-			r = r.WithContext(ctxWithUser)
-			am.logger.Infof(ctxWithUser, "Authenticated user ID: %v", claims["sub"])
-		}
+		ctxWithUser := context.WithValue(r.Context(), meta.UserIDKey, claims["sub"])
+		ctxWithUser = context.WithValue(ctxWithUser, meta.SessionIDKey, claims["sid"])
+		ctxWithUser = context.WithValue(ctxWithUser, meta.RoleKey, claims["role"])
+		ctxWithUser = context.WithValue(ctxWithUser, meta.OrgIDKey, services.TokenOrgID(claims))
+		r = r.WithContext(ctxWithUser)
+		am.logger.Infof(ctxWithUser, "Authenticated user ID: %v", claims["sub"])
 		next.ServeHTTP(w, r)
 	})
 }
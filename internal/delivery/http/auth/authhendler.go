@@ -3,39 +3,51 @@ package auth
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
+	"github.com/DANazavr/RATest/internal/common/meta"
 	delivery "github.com/DANazavr/RATest/internal/delivery/http"
 	"github.com/DANazavr/RATest/internal/domain"
 	"github.com/DANazavr/RATest/internal/domain/models"
 	"github.com/DANazavr/RATest/internal/log"
 	"github.com/DANazavr/RATest/internal/services"
-	"github.com/golang-jwt/jwt/v4"
+	"github.com/gorilla/mux"
 )
 
 type AuthHendler struct {
-	ctx         context.Context
-	logger      *log.Log
-	userService *services.UserService
-	authService *services.AuthService
+	ctx             context.Context
+	logger          *log.Log
+	userService     *services.UserService
+	authService     *services.AuthService
+	inviteService   *services.InviteService
+	resetService    *services.PasswordResetService
+	verifyService   *services.EmailVerificationService
+	throttleService *services.LoginThrottleService
+	oidcService     *services.OIDCService
 }
 
-func NewAuthHendler(ctx context.Context, logger *log.Log, us *services.UserService, as *services.AuthService) *AuthHendler {
+func NewAuthHendler(ctx context.Context, logger *log.Log, us *services.UserService, as *services.AuthService, is *services.InviteService, rs *services.PasswordResetService, vs *services.EmailVerificationService, ls *services.LoginThrottleService, oc *services.OIDCService) *AuthHendler {
 	return &AuthHendler{
-		ctx:         ctx,
-		logger:      logger.WithComponent("auth/authHendler"),
-		userService: us,
-		authService: as,
+		ctx:             ctx,
+		logger:          logger.WithComponent("auth/authHendler"),
+		userService:     us,
+		authService:     as,
+		inviteService:   is,
+		resetService:    rs,
+		verifyService:   vs,
+		throttleService: ls,
+		oidcService:     oc,
 	}
 }
 
 func (h *AuthHendler) HandleRegister() http.HandlerFunc {
 	type request struct {
-		Username string `json:"username"`
-		Email    string `json:"email"`
-		Password string `json:"password"`
-		Role     string `json:"role"`
+		Username    string `json:"username"`
+		Email       string `json:"email"`
+		Password    string `json:"password"`
+		InviteToken string `json:"invite_token"`
 	}
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -48,12 +60,26 @@ func (h *AuthHendler) HandleRegister() http.HandlerFunc {
 			Username: req.Username,
 			Email:    req.Email,
 			Password: req.Password,
-			Role:     req.Role,
+			Role:     domain.RoleUser,
+			OrgID:    domain.DefaultOrgID,
 		}
-		if err := h.userService.UsersCreate(ctx, u); err != nil {
+		if req.InviteToken != "" {
+			inv, err := h.inviteService.Resolve(req.InviteToken)
+			if err != nil {
+				delivery.HendleError(w, r, http.StatusForbidden, err)
+				return
+			}
+			if err := h.userService.UsersCreateByInvite(ctx, u, inv); err != nil {
+				delivery.HendleError(w, r, http.StatusUnprocessableEntity, err)
+				return
+			}
+		} else if err := h.userService.UsersCreate(ctx, u); err != nil {
 			delivery.HendleError(w, r, http.StatusUnprocessableEntity, err)
 			return
 		}
+		if err := h.verifyService.Send(ctx, u); err != nil {
+			h.logger.Errorf(ctx, "Failed to send verification email to user %d: %v", u.ID, err)
+		}
 		delivery.HendleRespond(w, r, http.StatusCreated, u)
 	}
 }
@@ -64,36 +90,370 @@ func (h *AuthHendler) HandleLogin() http.HandlerFunc {
 		Password string `json:"password"`
 	}
 
-	type response struct {
-		AccessToken  string `json:"access_token"`
-		RefreshToken string `json:"refresh_token"`
-	}
-
 	return func(w http.ResponseWriter, r *http.Request) {
 		req := &request{}
 		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
 			delivery.HendleError(w, r, http.StatusBadRequest, err)
 			return
 		}
+		attempt := &models.LoginAttempt{
+			Username:  req.Username,
+			IP:        delivery.ClientIP(r),
+			UserAgent: r.UserAgent(),
+		}
+		if retry, err := h.throttleService.Check(attempt.Username, attempt.IP); err != nil {
+			if errors.Is(err, domain.ErrTooManyLoginAttempts) {
+				attempt.Reason = models.LoginReasonThrottled
+				h.throttleService.Record(attempt)
+				w.Header().Set("Retry-After", strconv.Itoa(int(retry.Seconds())+1))
+				delivery.HendleError(w, r, http.StatusTooManyRequests, err)
+				return
+			}
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+
 		u, err := h.userService.UsersGetByUsername(req.Username)
 		if err != nil || !h.userService.ComparePassword(u, req.Password) {
+			attempt.Reason = models.LoginReasonInvalidCredentials
+			h.throttleService.Record(attempt)
 			delivery.HendleError(w, r, http.StatusUnauthorized, domain.ErrIncorectUsernameOrPassword)
 			return
 		}
+		h.completeLogin(w, r, u, attempt)
+	}
+}
+
+// completeLogin завершает вход пользователя u, личность которого уже
+// подтверждена: проверяет email и второй фактор и выдаёт токены.
+func (h *AuthHendler) completeLogin(w http.ResponseWriter, r *http.Request, u *models.User, attempt *models.LoginAttempt) {
+	type response struct {
+		AccessToken           string `json:"access_token,omitempty"`
+		RefreshToken          string `json:"refresh_token,omitempty"`
+		MFARequired           bool   `json:"mfa_required,omitempty"`
+		MFAToken              string `json:"mfa_token,omitempty"`
+		MFAEnrollmentRequired bool   `json:"mfa_enrollment_required,omitempty"`
+	}
+
+	attempt.UserID = &u.ID
+	if err := h.userService.CheckActive(u); err != nil {
+		attempt.Reason = models.LoginReasonDisabled
+		h.throttleService.Record(attempt)
+		delivery.HendleError(w, r, http.StatusForbidden, err)
+		return
+	}
+	if err := h.verifyService.CheckLogin(u); err != nil {
+		attempt.Reason = models.LoginReasonEmailNotVerified
+		h.throttleService.Record(attempt)
+		delivery.HendleError(w, r, http.StatusForbidden, err)
+		return
+	}
 
-		atoken, rtoken, err := h.authService.GenerateTokens(u.ID, u.Role)
+	// Со вторым фактором токены выдаются только после проверки кода в /login/mfa
+	enabled, err := h.userService.MFAEnabled(u)
+	if err != nil {
+		delivery.HendleError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	if enabled || h.userService.MFARequired(u) {
+		attempt.Reason = models.LoginReasonMFARequired
+		h.throttleService.Record(attempt)
+		mfaToken, err := h.authService.GenerateMFAToken(u.ID)
 		if err != nil {
 			delivery.HendleError(w, r, http.StatusInternalServerError, err)
 			return
 		}
+		delivery.HendleRespond(w, r, http.StatusOK, response{
+			MFARequired:           true,
+			MFAToken:              mfaToken,
+			MFAEnrollmentRequired: !enabled,
+		})
+		return
+	}
+	attempt.Reason = models.LoginReasonSuccess
+	h.throttleService.Record(attempt)
+
+	atoken, rtoken, err := h.authService.GenerateTokens(u)
+	if err != nil {
+		delivery.HendleError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	delivery.HendleRespond(w, r, http.StatusOK, response{
+		AccessToken:  atoken,
+		RefreshToken: rtoken,
+	})
+}
 
+// HandleOIDCLogin перенаправляет пользователя на страницу входа провайдера.
+func (h *AuthHendler) HandleOIDCLogin() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		provider := mux.Vars(r)["provider"]
+		url, state, err := h.oidcService.Start(r.Context(), provider)
+		if err != nil {
+			if errors.Is(err, domain.ErrUnknownOIDCProvider) {
+				delivery.HendleError(w, r, http.StatusNotFound, err)
+				return
+			}
+			delivery.HendleError(w, r, http.StatusBadGateway, err)
+			return
+		}
+		delivery.SetOIDCStateCookie(w, r, provider, state)
+		http.Redirect(w, r, url, http.StatusFound)
+	}
+}
+
+// HandleOIDCCallback принимает пользователя, вернувшегося от провайдера, и
+// выдаёт токены так же, как /login.
+func (h *AuthHendler) HandleOIDCCallback() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		provider := mux.Vars(r)["provider"]
+		q := r.URL.Query()
+		browserState := delivery.TakeOIDCStateCookie(w, r, provider)
+		if e := q.Get("error"); e != "" {
+			h.logger.Warnf(r.Context(), "Provider %s returned error %s: %s", provider, e, q.Get("error_description"))
+			delivery.HendleError(w, r, http.StatusUnauthorized, domain.ErrOIDCLoginFailed)
+			return
+		}
+		u, err := h.oidcService.Callback(r.Context(), provider, q.Get("code"), q.Get("state"), browserState)
+		if err != nil {
+			delivery.HendleError(w, r, oidcErrorStatus(err), err)
+			return
+		}
+		h.completeLogin(w, r, u, &models.LoginAttempt{
+			Username:  u.Username,
+			IP:        delivery.ClientIP(r),
+			UserAgent: r.UserAgent(),
+		})
+	}
+}
+
+func oidcErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrUnknownOIDCProvider):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrInvalidOIDCState), errors.Is(err, domain.ErrOIDCLoginFailed):
+		return http.StatusUnauthorized
+	case errors.Is(err, domain.ErrOIDCEmailTaken):
+		return http.StatusConflict
+	}
+	return http.StatusUnprocessableEntity
+}
+
+// HandleLoginMFA завершает вход кодом второго фактора или кодом восстановления.
+func (h *AuthHendler) HandleLoginMFA() http.HandlerFunc {
+	type request struct {
+		MFAToken string `json:"mfa_token"`
+		Code     string `json:"code"`
+	}
+
+	type response struct {
+		AccessToken   string   `json:"access_token"`
+		RefreshToken  string   `json:"refresh_token"`
+		RecoveryCodes []string `json:"recovery_codes,omitempty"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		userID, err := h.authService.ValidateMFAToken(req.MFAToken)
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusUnauthorized, err)
+			return
+		}
+		u, err := h.userService.UsersGetById(userID)
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusUnauthorized, domain.ErrInvalidMFAToken)
+			return
+		}
+		if err := h.userService.CheckActive(u); err != nil {
+			delivery.HendleError(w, r, http.StatusForbidden, err)
+			return
+		}
+
+		attempt := &models.LoginAttempt{
+			Username:  u.Username,
+			UserID:    &u.ID,
+			IP:        delivery.ClientIP(r),
+			UserAgent: r.UserAgent(),
+		}
+		if retry, err := h.throttleService.Check(attempt.Username, attempt.IP); err != nil {
+			if errors.Is(err, domain.ErrTooManyLoginAttempts) {
+				attempt.Reason = models.LoginReasonThrottled
+				h.throttleService.Record(attempt)
+				w.Header().Set("Retry-After", strconv.Itoa(int(retry.Seconds())+1))
+				delivery.HendleError(w, r, http.StatusTooManyRequests, err)
+				return
+			}
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+
+		recoveryCodes, err := h.userService.CompleteMFA(u, req.Code)
+		if err != nil {
+			if errors.Is(err, domain.ErrInvalidMFACode) {
+				attempt.Reason = models.LoginReasonInvalidMFACode
+				h.throttleService.Record(attempt)
+				delivery.HendleError(w, r, http.StatusUnauthorized, err)
+				return
+			}
+			delivery.HendleError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		attempt.Reason = models.LoginReasonSuccess
+		h.throttleService.Record(attempt)
+
+		atoken, rtoken, err := h.authService.GenerateTokens(u)
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
 		delivery.HendleRespond(w, r, http.StatusOK, response{
-			AccessToken:  atoken,
-			RefreshToken: rtoken,
+			AccessToken:   atoken,
+			RefreshToken:  rtoken,
+			RecoveryCodes: recoveryCodes,
 		})
 	}
 }
 
+// HandleLoginMFAEnroll выдаёт секрет TOTP пользователю, для которого второй
+// фактор обязателен, но ещё не подключён. Подключение подтверждает /login/mfa.
+func (h *AuthHendler) HandleLoginMFAEnroll() http.HandlerFunc {
+	type request struct {
+		MFAToken string `json:"mfa_token"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		userID, err := h.authService.ValidateMFAToken(req.MFAToken)
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusUnauthorized, err)
+			return
+		}
+		u, err := h.userService.UsersGetById(userID)
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusUnauthorized, domain.ErrInvalidMFAToken)
+			return
+		}
+		if err := h.userService.CheckActive(u); err != nil {
+			delivery.HendleError(w, r, http.StatusForbidden, err)
+			return
+		}
+		h.enrollTOTP(w, r, u)
+	}
+}
+
+func (h *AuthHendler) HandleMFAEnroll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		u, ok := h.currentUser(w, r)
+		if !ok {
+			return
+		}
+		h.enrollTOTP(w, r, u)
+	}
+}
+
+func (h *AuthHendler) HandleMFAConfirm() http.HandlerFunc {
+	type request struct {
+		Code string `json:"code"`
+	}
+	type response struct {
+		RecoveryCodes []string `json:"recovery_codes"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		u, ok := h.currentUser(w, r)
+		if !ok {
+			return
+		}
+		codes, err := h.userService.ConfirmTOTP(u, req.Code)
+		if err != nil {
+			delivery.HendleError(w, r, mfaErrorStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, response{RecoveryCodes: codes})
+	}
+}
+
+func (h *AuthHendler) HandleMFADisable() http.HandlerFunc {
+	type request struct {
+		Code string `json:"code"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		u, ok := h.currentUser(w, r)
+		if !ok {
+			return
+		}
+		if err := h.userService.DisableTOTP(u, req.Code); err != nil {
+			delivery.HendleError(w, r, mfaErrorStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusNoContent, nil)
+	}
+}
+
+func (h *AuthHendler) enrollTOTP(w http.ResponseWriter, r *http.Request, u *models.User) {
+	type response struct {
+		Secret     string `json:"secret"`
+		OtpauthURI string `json:"otpauth_uri"`
+	}
+	secret, uri, err := h.userService.EnrollTOTP(u)
+	if err != nil {
+		delivery.HendleError(w, r, mfaErrorStatus(err), err)
+		return
+	}
+	delivery.HendleRespond(w, r, http.StatusOK, response{Secret: secret, OtpauthURI: uri})
+}
+
+// currentUser загружает владельца access токена. При ошибке ответ уже записан.
+func (h *AuthHendler) currentUser(w http.ResponseWriter, r *http.Request) (*models.User, bool) {
+	userIDstr, ok := r.Context().Value(meta.UserIDKey).(string)
+	if !ok || userIDstr == "" {
+		delivery.HendleError(w, r, http.StatusUnauthorized, domain.ErrInvalidUserID)
+		return nil, false
+	}
+	userID, err := strconv.Atoi(userIDstr)
+	if err != nil {
+		delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidUserID)
+		return nil, false
+	}
+	u, err := h.userService.UsersGetById(userID)
+	if err != nil {
+		delivery.HendleError(w, r, http.StatusNotFound, err)
+		return nil, false
+	}
+	return u, true
+}
+
+func mfaErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidMFACode):
+		return http.StatusUnauthorized
+	case errors.Is(err, domain.ErrMFAAlreadyEnabled):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrMFANotEnabled):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrMFARequired):
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
+
 func (h *AuthHendler) HandleTokensRefresh() http.HandlerFunc {
 	type request struct {
 		RefreshToken string `json:"refresh_token"`
@@ -111,46 +471,189 @@ func (h *AuthHendler) HandleTokensRefresh() http.HandlerFunc {
 			return
 		}
 
-		token, err := h.authService.ParseToken(req.RefreshToken)
+		session, err := h.authService.ValidateRefreshToken(req.RefreshToken)
 		if err != nil {
+			h.logger.Warnf(r.Context(), "Refresh token rejected: %v", err)
 			delivery.HendleError(w, r, http.StatusUnauthorized, domain.ErrInvalidToken)
 			return
 		}
 
-		if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-			tokenType, ok := claims["type"].(string)
-			if !ok || tokenType != "refresh" {
-				delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidToken)
-				return
-			}
-			userID, ok := claims["sub"].(string)
-			userid, err := strconv.ParseInt(userID, 10, 64)
-			if err != nil {
-				h.logger.Info(h.ctx, "failed to parse userID from token")
+		u, err := h.userService.UsersGetById(session.UserID)
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusNotFound, err)
+			return
+		}
+		if err := h.userService.CheckActive(u); err != nil {
+			delivery.HendleError(w, r, http.StatusUnauthorized, err)
+			return
+		}
+
+		atoken, rtoken, err := h.authService.RotateTokens(session, u)
+		if err != nil {
+			if errors.Is(err, domain.ErrRefreshTokenReused) {
 				delivery.HendleError(w, r, http.StatusUnauthorized, domain.ErrInvalidToken)
 				return
 			}
-			if !ok {
-				delivery.HendleError(w, r, http.StatusUnauthorized, domain.ErrInvalidToken)
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+
+		delivery.HendleRespond(w, r, http.StatusOK, response{
+			AccessToken:  atoken,
+			RefreshToken: rtoken,
+		})
+	}
+}
+
+func (h *AuthHendler) HandleLogout() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		familyID, ok := ctx.Value(meta.SessionIDKey).(string)
+		if !ok || familyID == "" {
+			delivery.HendleError(w, r, http.StatusUnauthorized, domain.ErrInvalidToken)
+			return
+		}
+		if err := h.authService.Logout(familyID); err != nil {
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		h.logger.Infof(ctx, "User logged out of session %s", familyID)
+		delivery.HendleRespond(w, r, http.StatusNoContent, nil)
+	}
+}
+
+func (h *AuthHendler) HandleLogoutAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userIDstr, ok := ctx.Value(meta.UserIDKey).(string)
+		if !ok || userIDstr == "" {
+			delivery.HendleError(w, r, http.StatusUnauthorized, domain.ErrInvalidUserID)
+			return
+		}
+		userID, err := strconv.Atoi(userIDstr)
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidUserID)
+			return
+		}
+		if err := h.authService.LogoutAll(userID); err != nil {
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		h.logger.Infof(ctx, "User %d logged out of all sessions", userID)
+		delivery.HendleRespond(w, r, http.StatusNoContent, nil)
+	}
+}
+
+func (h *AuthHendler) HandlePasswordResetRequest() http.HandlerFunc {
+	type request struct {
+		Email string `json:"email"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		if err := h.resetService.RequestReset(r.Context(), req.Email); err != nil {
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusAccepted, nil)
+	}
+}
+
+func (h *AuthHendler) HandlePasswordResetConfirm() http.HandlerFunc {
+	type request struct {
+		Token    string `json:"token"`
+		Password string `json:"password"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		if err := h.resetService.ConfirmReset(r.Context(), req.Token, req.Password); err != nil {
+			if errors.Is(err, domain.ErrInvalidResetToken) {
+				delivery.HendleError(w, r, http.StatusBadRequest, err)
 				return
 			}
+			delivery.HendleError(w, r, http.StatusUnprocessableEntity, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusNoContent, nil)
+	}
+}
 
-			u, err := h.userService.UsersGetById(int(userid))
-			if err != nil {
-				delivery.HendleError(w, r, http.StatusNotFound, err)
+func (h *AuthHendler) HandleEmailConfirm() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.verifyService.Confirm(r.Context(), r.URL.Query().Get("token")); err != nil {
+			if errors.Is(err, domain.ErrInvalidVerificationToken) {
+				delivery.HendleError(w, r, http.StatusBadRequest, err)
 				return
 			}
-
-			atoken, rtoken, err := h.authService.GenerateTokens(u.ID, u.Role)
-			if err != nil {
-				delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			if errors.Is(err, domain.ErrEmailTaken) {
+				delivery.HendleError(w, r, http.StatusConflict, err)
 				return
 			}
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusNoContent, nil)
+	}
+}
 
-			delivery.HendleRespond(w, r, http.StatusOK, response{
-				AccessToken:  atoken,
-				RefreshToken: rtoken,
-			})
+func (h *AuthHendler) HandleEmailResendByEmail() http.HandlerFunc {
+	type request struct {
+		Email string `json:"email"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
 		}
+		if err := h.verifyService.ResendByEmail(r.Context(), req.Email); err != nil {
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusAccepted, nil)
+	}
+}
+
+func (h *AuthHendler) HandleEmailResend() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userIDstr, ok := ctx.Value(meta.UserIDKey).(string)
+		if !ok || userIDstr == "" {
+			delivery.HendleError(w, r, http.StatusUnauthorized, domain.ErrInvalidUserID)
+			return
+		}
+		userID, err := strconv.Atoi(userIDstr)
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidUserID)
+			return
+		}
+		u, err := h.userService.UsersGetById(userID)
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusNotFound, err)
+			return
+		}
+		if err := h.verifyService.Send(ctx, u); err != nil {
+			if errors.Is(err, domain.ErrEmailAlreadyVerified) {
+				delivery.HendleError(w, r, http.StatusConflict, err)
+				return
+			}
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusAccepted, nil)
+	}
+}
+
+func (h *AuthHendler) HandleJWKS() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		delivery.HendleRespond(w, r, http.StatusOK, h.authService.JWKS())
 	}
 }
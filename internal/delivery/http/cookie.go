@@ -0,0 +1,38 @@
+package delivery
+
+import (
+	"net/http"
+)
+
+const oidcStateCookie = "oidc_state"
+
+// SetOIDCStateCookie привязывает начатый вход через провайдера к браузеру:
+// state сохраняется в HttpOnly cookie, видимой только путям /oidc/{provider}.
+func SetOIDCStateCookie(w http.ResponseWriter, r *http.Request, provider string, state string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state,
+		Path:     "/oidc/" + provider,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		// Lax, чтобы cookie пришла при возврате от провайдера
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// TakeOIDCStateCookie возвращает state из cookie браузера и удаляет её.
+func TakeOIDCStateCookie(w http.ResponseWriter, r *http.Request, provider string) string {
+	c, err := r.Cookie(oidcStateCookie)
+	if err != nil {
+		return ""
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Path:     "/oidc/" + provider,
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return c.Value
+}
@@ -0,0 +1,187 @@
+package dataexport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/DANazavr/RATest/internal/common/meta"
+	delivery "github.com/DANazavr/RATest/internal/delivery/http"
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/services"
+	"github.com/gorilla/mux"
+)
+
+type DataExportHendler struct {
+	ctx               context.Context
+	logger            *log.Log
+	dataExportService *services.DataExportService
+}
+
+func NewDataExportHendler(ctx context.Context, logger *log.Log, ds *services.DataExportService) *DataExportHendler {
+	return &DataExportHendler{
+		ctx:               ctx,
+		logger:            logger.WithComponent("dataexport/dataExportHendler"),
+		dataExportService: ds,
+	}
+}
+
+// HandleRequest ставит в очередь выгрузку данных владельца токена.
+func (h *DataExportHendler) HandleRequest() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := currentUserID(w, r)
+		if !ok {
+			return
+		}
+		e, err := h.orgExports(r).Request(r.Context(), userID, &userID)
+		if err != nil {
+			delivery.HendleError(w, r, exportErrorStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusAccepted, e)
+	}
+}
+
+func (h *DataExportHendler) HandleList() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := currentUserID(w, r)
+		if !ok {
+			return
+		}
+		exports, err := h.orgExports(r).Exports(userID)
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, exports)
+	}
+}
+
+// HandleDownload отдаёт архив выгрузки, если она принадлежит владельцу токена.
+func (h *DataExportHendler) HandleDownload() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := currentUserID(w, r)
+		if !ok {
+			return
+		}
+		id, ok := idFromPath(w, r, "id")
+		if !ok {
+			return
+		}
+		exports := h.orgExports(r)
+		e, err := exports.Export(id)
+		if err == nil && e.UserID != userID {
+			err = domain.ErrDataExportNotFound
+		}
+		if err != nil {
+			delivery.HendleError(w, r, exportErrorStatus(err), err)
+			return
+		}
+		h.download(w, r, exports, id)
+	}
+}
+
+// HandleAdminRequest ставит в очередь выгрузку данных пользователя из пути.
+func (h *DataExportHendler) HandleAdminRequest() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := idFromPath(w, r, "id")
+		if !ok {
+			return
+		}
+		var requestedBy *int
+		if userIDstr, ok := r.Context().Value(meta.UserIDKey).(string); ok {
+			if userID, err := strconv.Atoi(userIDstr); err == nil {
+				requestedBy = &userID
+			}
+		}
+		e, err := h.orgExports(r).Request(r.Context(), id, requestedBy)
+		if err != nil {
+			delivery.HendleError(w, r, exportErrorStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusAccepted, e)
+	}
+}
+
+func (h *DataExportHendler) HandleAdminList() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := idFromPath(w, r, "id")
+		if !ok {
+			return
+		}
+		exports, err := h.orgExports(r).Exports(id)
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, exports)
+	}
+}
+
+func (h *DataExportHendler) HandleAdminDownload() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := idFromPath(w, r, "id")
+		if !ok {
+			return
+		}
+		h.download(w, r, h.orgExports(r), id)
+	}
+}
+
+func (h *DataExportHendler) download(w http.ResponseWriter, r *http.Request, exports *services.DataExportService, id int) {
+	archive, err := exports.Archive(id)
+	if err != nil {
+		delivery.HendleError(w, r, exportErrorStatus(err), err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="export-%d.zip"`, id))
+	w.Header().Set("Content-Length", strconv.Itoa(len(archive)))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(archive); err != nil {
+		h.logger.Errorf(r.Context(), "Failed to write data export %d: %v", id, err)
+	}
+}
+
+// orgExports возвращает сервис выгрузок организации, от имени которой сделан запрос.
+func (h *DataExportHendler) orgExports(r *http.Request) *services.DataExportService {
+	orgID, _ := r.Context().Value(meta.OrgIDKey).(int)
+	return h.dataExportService.ForOrg(orgID)
+}
+
+func currentUserID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	userIDstr, ok := r.Context().Value(meta.UserIDKey).(string)
+	if !ok || userIDstr == "" {
+		delivery.HendleError(w, r, http.StatusUnauthorized, domain.ErrInvalidUserID)
+		return 0, false
+	}
+	userID, err := strconv.Atoi(userIDstr)
+	if err != nil {
+		delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidUserID)
+		return 0, false
+	}
+	return userID, true
+}
+
+func idFromPath(w http.ResponseWriter, r *http.Request, name string) (int, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)[name])
+	if err != nil {
+		delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+		return 0, false
+	}
+	return id, true
+}
+
+func exportErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrDataExportNotFound),
+		errors.Is(err, domain.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrDataExportNotReady):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,80 @@
+package invite
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/DANazavr/RATest/internal/common/meta"
+	delivery "github.com/DANazavr/RATest/internal/delivery/http"
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/services"
+)
+
+type InviteHendler struct {
+	ctx           context.Context
+	logger        *log.Log
+	inviteService *services.InviteService
+}
+
+func NewInviteHendler(ctx context.Context, logger *log.Log, is *services.InviteService) *InviteHendler {
+	return &InviteHendler{
+		ctx:           ctx,
+		logger:        logger.WithComponent("invite/inviteHendler"),
+		inviteService: is,
+	}
+}
+
+func (h *InviteHendler) HandleCreate() http.HandlerFunc {
+	type request struct {
+		Role  string `json:"role"`
+		Email string `json:"email"`
+	}
+	type response struct {
+		Invite *models.Invite `json:"invite"`
+		Token  string         `json:"token"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		i := &models.Invite{
+			Role:  req.Role,
+			Email: req.Email,
+		}
+		if userIDstr, ok := r.Context().Value(meta.UserIDKey).(string); ok {
+			if userID, err := strconv.Atoi(userIDstr); err == nil {
+				i.CreatedBy = &userID
+			}
+		}
+
+		token, err := h.orgInvites(r).Create(i)
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusUnprocessableEntity, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusCreated, response{Invite: i, Token: token})
+	}
+}
+
+func (h *InviteHendler) HandleList() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		invites, err := h.orgInvites(r).Get()
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, invites)
+	}
+}
+
+// orgInvites возвращает сервис приглашений в организацию администратора.
+func (h *InviteHendler) orgInvites(r *http.Request) *services.InviteService {
+	orgID, _ := r.Context().Value(meta.OrgIDKey).(int)
+	return h.inviteService.ForOrg(orgID)
+}
@@ -3,41 +3,59 @@ package notification
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/DANazavr/RATest/internal/common/meta"
 	delivery "github.com/DANazavr/RATest/internal/delivery/http"
+	"github.com/DANazavr/RATest/internal/delivery/http/admin"
 	"github.com/DANazavr/RATest/internal/domain"
 	"github.com/DANazavr/RATest/internal/domain/models"
 	"github.com/DANazavr/RATest/internal/log"
 	"github.com/DANazavr/RATest/internal/services"
+	"github.com/gorilla/mux"
 )
 
 type NotificationHandler struct {
 	ctx                 context.Context
 	logger              *log.Log
 	userService         *services.UserService
+	authService         *services.AuthService
 	notificationService *services.NotificationService
+	verifyService       *services.EmailVerificationService
+	audienceService     *services.AudienceService
+	templateService     *services.TemplateService
+	adminMiddleware     *admin.MiddlewareAdmin
 }
 
-func NewNotificationHandler(ctx context.Context, logger *log.Log, us *services.UserService, cs *services.NotificationService) *NotificationHandler {
+func NewNotificationHandler(ctx context.Context, logger *log.Log, us *services.UserService, as *services.AuthService, cs *services.NotificationService, vs *services.EmailVerificationService, au *services.AudienceService, ts *services.TemplateService, ma *admin.MiddlewareAdmin) *NotificationHandler {
 	return &NotificationHandler{
 		ctx:                 ctx,
 		logger:              logger.WithComponent("rest/notification/notificationHandler"),
 		userService:         us,
+		authService:         as,
 		notificationService: cs,
+		verifyService:       vs,
+		audienceService:     au,
+		templateService:     ts,
+		adminMiddleware:     ma,
 	}
 }
 
 func (nh *NotificationHandler) Publish() http.HandlerFunc {
-	type notification struct {
-		Title   string `json:"title"`
-		Message string `json:"message"`
-	}
 	type request struct {
-		Channel string       `json:"channel"`
-		Data    notification `json:"data"`
+		Channel   string                   `json:"channel"`
+		GroupID   int                      `json:"group_id"`
+		SegmentID int                      `json:"segment_id"`
+		Data      *models.NotificationData `json:"data"`
+		// Вместо data можно указать шаблон и значения его переменных
+		TemplateKey string                 `json:"template_key"`
+		Variables   map[string]interface{} `json:"variables"`
+		Locale      string                 `json:"locale"`
+		// Время отправки по расписанию, по умолчанию сразу
+		DeliverAt *time.Time `json:"deliver_at"`
 	}
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -47,6 +65,27 @@ func (nh *NotificationHandler) Publish() http.HandlerFunc {
 			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
 			return
 		}
+		// Издатель видит только пользователей и шаблоны своей организации
+		orgID, _ := ctx.Value(meta.OrgIDKey).(int)
+		notifications := nh.notificationService.ForOrg(orgID)
+		content, err := nh.templateService.ForOrg(orgID).Resolve(req.TemplateKey, req.Locale, req.Variables, req.Data)
+		if err != nil {
+			delivery.HendleError(w, r, contentErrorStatus(err), err)
+			return
+		}
+		if err := services.ValidateDeliverAt(req.DeliverAt); err != nil {
+			delivery.HendleError(w, r, http.StatusUnprocessableEntity, err)
+			return
+		}
+
+		if req.GroupID != 0 || req.SegmentID != 0 {
+			if req.Channel != "" {
+				delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidAudience)
+				return
+			}
+			nh.publishAudience(w, r, req.GroupID, req.SegmentID, content, req.DeliverAt)
+			return
+		}
 
 		var userID int
 		for i, v := range req.Channel {
@@ -66,42 +105,65 @@ func (nh *NotificationHandler) Publish() http.HandlerFunc {
 			return
 		}
 
-		if _, err := nh.userService.UsersGetById(userID); err != nil {
+		user, err := nh.userService.ForOrg(orgID).UsersGetById(userID)
+		if err != nil {
 			nh.logger.Errorf(nh.ctx, "Failed to get user: %v", err)
 			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrUserNotFound)
 			return
 		}
 		ctx = context.WithValue(ctx, meta.UserIDKey, userID)
+		// Уведомление уходит в персональный канал получателя в его организации
+		channel := notifications.UserChannel(user.OrgID, user.ID)
 
-		notificationMap := map[string]interface{}{
-			"title":   req.Data.Title,
-			"message": req.Data.Message,
-		}
 		n := &models.UserNotification{
 			UserID:       userID,
-			Notification: notificationMap,
+			Notification: content(user),
+		}
+		if apiKeyID, ok := ctx.Value(meta.APIKeyIDKey).(int); ok {
+			n.APIKeyID = &apiKeyID
+		}
+
+		if req.DeliverAt != nil {
+			if err := notifications.Schedule(n, *req.DeliverAt); err != nil {
+				nh.logger.Errorf(nh.ctx, "Failed to schedule notification: %v", err)
+				delivery.HendleError(w, r, http.StatusInternalServerError, domain.ErrCentrifugeNotificationCreateFailed)
+				return
+			}
+			delivery.HendleRespond(w, r, http.StatusAccepted, n)
+			return
 		}
 
-		if err := nh.notificationService.NotificationCreate(n); err != nil {
+		if err := notifications.NotificationCreate(n); err != nil {
 			nh.logger.Errorf(nh.ctx, "Failed to create notification: %v", err)
 			delivery.HendleError(w, r, http.StatusInternalServerError, domain.ErrCentrifugeNotificationCreateFailed)
 			return
 		}
 		nh.logger.Infof(nh.ctx, "Notification created for user %d: %v", userID, n.UID)
 
-		presence, err := nh.notificationService.Presence(req.Channel)
+		// Неподтверждённым адресам уведомление остаётся только в истории
+		if !nh.verifyService.CanDeliver(user) {
+			nh.logger.Infof(nh.ctx, "User %d has unverified email, notification %d is not pushed", userID, n.UID)
+			delivery.HendleRespond(w, r, http.StatusAccepted, n)
+			return
+		}
+
+		presence, err := notifications.Presence(channel)
 		if err != nil {
 			nh.logger.Errorf(nh.ctx, "Failed to get presence: %v", err)
 			delivery.HendleError(w, r, http.StatusInternalServerError, domain.ErrCentrifugePresenceFailed)
 			return
 		}
 		if len(presence.Presence) == 0 {
-			nh.logger.Infof(nh.ctx, "No users online in channel %s", req.Channel)
+			nh.logger.Infof(nh.ctx, "No users online in channel %s", channel)
 			delivery.HendleError(w, r, http.StatusNotFound, domain.ErrCentrifugePresenceFailed)
 			return
 		}
 
-		publish, err := nh.notificationService.Publish(n, req.Channel)
+		publish, err := notifications.Publish(n, channel)
+		if errors.Is(err, domain.ErrPushSuppressed) || errors.Is(err, domain.ErrPushDeferred) {
+			delivery.HendleRespond(w, r, http.StatusAccepted, n)
+			return
+		}
 		if err != nil {
 			nh.logger.Errorf(nh.ctx, "Failed to publish notification: %v", err)
 			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrCentrifugePublishFailed)
@@ -115,7 +177,7 @@ func (nh *NotificationHandler) Publish() http.HandlerFunc {
 				delivery.HendleError(w, r, http.StatusInternalServerError, domain.ErrCentrifugeNotification)
 				return
 			}
-			if err := nh.notificationService.MarkAsSend(n, userid); err != nil {
+			if err := notifications.MarkAsSend(n, userid); err != nil {
 				nh.logger.Errorf(nh.ctx, "Failed to mark notification as sent: %v", err)
 				delivery.HendleError(w, r, http.StatusInternalServerError, domain.ErrCentrifugeNotification)
 				return
@@ -134,13 +196,68 @@ func (nh *NotificationHandler) Publish() http.HandlerFunc {
 	}
 }
 
-func (nh *NotificationHandler) Broadcast() http.HandlerFunc {
-	type notification struct {
-		Title   string `json:"title"`
-		Message string `json:"message"`
+// publishAudience сохраняет и отправляет уведомление каждому участнику группы
+// groupID или сегмента segmentID организации издателя. Участники выбираются в
+// момент публикации, в том числе для отправки по расписанию deliverAt. Группа
+// или сегмент могут охватить всю организацию, поэтому нужно право рассылки.
+func (nh *NotificationHandler) publishAudience(w http.ResponseWriter, r *http.Request, groupID, segmentID int, content services.NotificationContent, deliverAt *time.Time) {
+	allowed, err := nh.adminMiddleware.Allowed(r.Context(), domain.PermNotificationBroadcast)
+	if err != nil {
+		delivery.HendleError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	if !allowed {
+		delivery.HendleError(w, r, http.StatusForbidden, domain.ErrPermissionDenied)
+		return
+	}
+	orgID, _ := r.Context().Value(meta.OrgIDKey).(int)
+	users, err := nh.audienceService.ForOrg(orgID).Recipients(groupID, segmentID)
+	if err != nil {
+		nh.logger.Errorf(nh.ctx, "Failed to resolve audience: %v", err)
+		delivery.HendleError(w, r, audienceErrorStatus(err), err)
+		return
+	}
+	var apiKeyID *int
+	if id, ok := r.Context().Value(meta.APIKeyIDKey).(int); ok {
+		apiKeyID = &id
+	}
+	res, err := nh.notificationService.ForOrg(orgID).Fanout(users, content, apiKeyID, true, deliverAt, nh.verifyService.CanDeliver)
+	if err != nil {
+		nh.logger.Errorf(nh.ctx, "Failed to publish notification to audience: %v", err)
+		delivery.HendleError(w, r, http.StatusInternalServerError, domain.ErrCentrifugePublishFailed)
+		return
+	}
+	if deliverAt != nil {
+		delivery.HendleRespond(w, r, http.StatusAccepted, res)
+		return
+	}
+	delivery.HendleRespond(w, r, http.StatusOK, res)
+}
+
+func audienceErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrGroupNotFound), errors.Is(err, domain.ErrSegmentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrInvalidAudience):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+func contentErrorStatus(err error) int {
+	if errors.Is(err, domain.ErrTemplateNotFound) {
+		return http.StatusNotFound
 	}
+	return http.StatusUnprocessableEntity
+}
+
+func (nh *NotificationHandler) Broadcast() http.HandlerFunc {
 	type request struct {
-		Data notification `json:"data"`
+		Data        *models.NotificationData `json:"data"`
+		TemplateKey string                   `json:"template_key"`
+		Variables   map[string]interface{}   `json:"variables"`
+		Locale      string                   `json:"locale"`
+		DeliverAt   *time.Time               `json:"deliver_at"`
 	}
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -151,7 +268,19 @@ func (nh *NotificationHandler) Broadcast() http.HandlerFunc {
 			return
 		}
 
-		users, err := nh.userService.UsersGet()
+		// Рассылка ограничена организацией издателя
+		orgID, _ := ctx.Value(meta.OrgIDKey).(int)
+		notifications := nh.notificationService.ForOrg(orgID)
+		content, err := nh.templateService.ForOrg(orgID).Resolve(req.TemplateKey, req.Locale, req.Variables, req.Data)
+		if err != nil {
+			delivery.HendleError(w, r, contentErrorStatus(err), err)
+			return
+		}
+		if err := services.ValidateDeliverAt(req.DeliverAt); err != nil {
+			delivery.HendleError(w, r, http.StatusUnprocessableEntity, err)
+			return
+		}
+		users, err := nh.userService.ForOrg(orgID).UsersGet()
 		if err != nil {
 			nh.logger.Errorf(nh.ctx, "Failed to get users: %v", err)
 			delivery.HendleError(w, r, http.StatusInternalServerError, domain.ErrUserNotFound)
@@ -162,30 +291,45 @@ func (nh *NotificationHandler) Broadcast() http.HandlerFunc {
 				nh.logger.Infof(nh.ctx, "Skipping user %d with role %s", user.ID, user.Role)
 				continue
 			}
-			notificationMap := map[string]interface{}{
-				"title":   req.Data.Title,
-				"message": req.Data.Message,
-			}
 			n := &models.UserNotification{
 				UserID:       user.ID,
-				Notification: notificationMap,
+				Notification: content(user),
+				Broadcast:    true,
+			}
+			if apiKeyID, ok := ctx.Value(meta.APIKeyIDKey).(int); ok {
+				n.APIKeyID = &apiKeyID
 			}
 
-			channel := "notifications:user#" + strconv.Itoa(user.ID)
+			channel := notifications.UserChannel(user.OrgID, user.ID)
 
-			if err := nh.notificationService.NotificationCreate(n); err != nil {
+			if req.DeliverAt != nil {
+				if err := notifications.Schedule(n, *req.DeliverAt); err != nil {
+					nh.logger.Errorf(nh.ctx, "Failed to schedule notification: %v", err)
+					delivery.HendleError(w, r, http.StatusInternalServerError, domain.ErrCentrifugeNotificationCreateFailed)
+					return
+				}
+				continue
+			}
+			if err := notifications.NotificationCreate(n); err != nil {
 				nh.logger.Errorf(nh.ctx, "Failed to create notification: %v", err)
 				delivery.HendleError(w, r, http.StatusInternalServerError, domain.ErrCentrifugeNotificationCreateFailed)
 				return
 			}
 			nh.logger.Infof(nh.ctx, "Notification created for user %d: %v", user.ID, n.UID)
-			_, err := nh.notificationService.Publish(n, channel)
+			if !nh.verifyService.CanDeliver(user) {
+				nh.logger.Infof(nh.ctx, "User %d has unverified email, notification %d is not pushed", user.ID, n.UID)
+				continue
+			}
+			_, err := notifications.Publish(n, channel)
+			if errors.Is(err, domain.ErrPushSuppressed) || errors.Is(err, domain.ErrPushDeferred) {
+				continue
+			}
 			if err != nil {
 				nh.logger.Errorf(nh.ctx, "Failed to publish notification: %v", err)
 				delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrCentrifugePublishFailed)
 				return
 			}
-			presence, err := nh.notificationService.Presence(channel)
+			presence, err := notifications.Presence(channel)
 			if err != nil {
 				nh.logger.Errorf(nh.ctx, "Failed to get presence: %v", err)
 				delivery.HendleError(w, r, http.StatusInternalServerError, domain.ErrCentrifugePresenceFailed)
@@ -203,7 +347,7 @@ func (nh *NotificationHandler) Broadcast() http.HandlerFunc {
 					delivery.HendleError(w, r, http.StatusInternalServerError, domain.ErrCentrifugeNotification)
 					return
 				}
-				if err := nh.notificationService.MarkAsSend(n, userid); err != nil {
+				if err := notifications.MarkAsSend(n, userid); err != nil {
 					nh.logger.Errorf(nh.ctx, "Failed to mark notification as sent: %v", err)
 					delivery.HendleError(w, r, http.StatusInternalServerError, domain.ErrCentrifugeNotification)
 					return
@@ -214,10 +358,91 @@ func (nh *NotificationHandler) Broadcast() http.HandlerFunc {
 		w.Header().Set("Content-Type", "application/json")
 
 		r = r.WithContext(ctx)
-		nh.logger.Infof(r.Context(), "Broadcast notification sent: %v", req.Data)
+		nh.logger.Infof(r.Context(), "Broadcast notification sent to %d users", len(users))
+	}
+}
+
+// ListScheduled возвращает запланированные и ещё не отправленные уведомления
+// организации издателя. Уведомления рассылок, групп и сегментов видны только
+// с правом notification:broadcast.
+func (nh *NotificationHandler) ListScheduled() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		broadcast, err := nh.adminMiddleware.Allowed(r.Context(), domain.PermNotificationBroadcast)
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		orgID, _ := r.Context().Value(meta.OrgIDKey).(int)
+		notifications, err := nh.notificationService.ForOrg(orgID).Scheduled(broadcast)
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, notifications)
+	}
+}
+
+func (nh *NotificationHandler) Reschedule() http.HandlerFunc {
+	type request struct {
+		DeliverAt time.Time `json:"deliver_at"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		var req request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		broadcast, err := nh.adminMiddleware.Allowed(r.Context(), domain.PermNotificationBroadcast)
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		orgID, _ := r.Context().Value(meta.OrgIDKey).(int)
+		n, err := nh.notificationService.ForOrg(orgID).Reschedule(id, req.DeliverAt, broadcast)
+		if err != nil {
+			delivery.HendleError(w, r, scheduleErrorStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, n)
 	}
 }
 
+func (nh *NotificationHandler) CancelScheduled() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		broadcast, err := nh.adminMiddleware.Allowed(r.Context(), domain.PermNotificationBroadcast)
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		orgID, _ := r.Context().Value(meta.OrgIDKey).(int)
+		if err := nh.notificationService.ForOrg(orgID).CancelScheduled(id, broadcast); err != nil {
+			delivery.HendleError(w, r, scheduleErrorStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusNoContent, nil)
+	}
+}
+
+func scheduleErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrScheduledNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrInvalidDeliverAt):
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusInternalServerError
+}
+
 func (nh *NotificationHandler) MarkAsRead() http.HandlerFunc {
 	type request struct {
 		NotificationID int `json:"notification_id"`
@@ -326,3 +551,144 @@ func (nh *NotificationHandler) GetNotificationsByFilter() http.HandlerFunc {
 		}
 	}
 }
+
+func (nh *NotificationHandler) CentrifugoConnectionToken() http.HandlerFunc {
+	type response struct {
+		Token string `json:"token"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userIDstr, ok := ctx.Value(meta.UserIDKey).(string)
+		if !ok || userIDstr == "" {
+			nh.logger.Errorf(nh.ctx, "Invalid user ID in context: %v", userIDstr)
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidUserID)
+			return
+		}
+		userID, err := strconv.Atoi(userIDstr)
+		if err != nil {
+			nh.logger.Errorf(nh.ctx, "Failed to convert user ID to int: %v", err)
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidUserID)
+			return
+		}
+
+		user, err := nh.userService.UsersGetById(userID)
+		if err != nil {
+			nh.logger.Errorf(nh.ctx, "Failed to get user: %v", err)
+			delivery.HendleError(w, r, http.StatusNotFound, domain.ErrUserNotFound)
+			return
+		}
+		if !nh.verifyService.CanDeliver(user) {
+			delivery.HendleError(w, r, http.StatusForbidden, domain.ErrEmailNotVerified)
+			return
+		}
+
+		token, err := nh.authService.GenerateCentrifugoConnectionToken(userID)
+		if err != nil {
+			nh.logger.Errorf(nh.ctx, "Failed to generate connection token: %v", err)
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, response{Token: token})
+	}
+}
+
+func (nh *NotificationHandler) CentrifugoSubscriptionToken() http.HandlerFunc {
+	type request struct {
+		Channel string `json:"channel"`
+	}
+	type response struct {
+		Token string `json:"token"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userIDstr, ok := ctx.Value(meta.UserIDKey).(string)
+		if !ok || userIDstr == "" {
+			nh.logger.Errorf(nh.ctx, "Invalid user ID in context: %v", userIDstr)
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidUserID)
+			return
+		}
+		userID, err := strconv.Atoi(userIDstr)
+		if err != nil {
+			nh.logger.Errorf(nh.ctx, "Failed to convert user ID to int: %v", err)
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidUserID)
+			return
+		}
+		var req request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			nh.logger.Errorf(nh.ctx, "Failed to decode request: %v", err)
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+
+		orgID, _ := ctx.Value(meta.OrgIDKey).(int)
+		if !nh.notificationService.CanSubscribe(orgID, userID, req.Channel) {
+			nh.logger.Warnf(nh.ctx, "User %d is not allowed to subscribe to %s", userID, req.Channel)
+			delivery.HendleError(w, r, http.StatusForbidden, domain.ErrChannelForbidden)
+			return
+		}
+
+		token, err := nh.authService.GenerateCentrifugoSubscriptionToken(userID, req.Channel)
+		if err != nil {
+			nh.logger.Errorf(nh.ctx, "Failed to generate subscription token: %v", err)
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, response{Token: token})
+	}
+}
+
+func (nh *NotificationHandler) GetPreferences() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := nh.currentUserID(w, r)
+		if !ok {
+			return
+		}
+		p, err := nh.notificationService.Preferences(userID)
+		if err != nil {
+			nh.logger.Errorf(nh.ctx, "Failed to get notification preferences: %v", err)
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, p)
+	}
+}
+
+func (nh *NotificationHandler) UpdatePreferences() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := nh.currentUserID(w, r)
+		if !ok {
+			return
+		}
+		// Поля, которых нет в запросе, сохраняют текущие значения
+		p, err := nh.notificationService.Preferences(userID)
+		if err != nil {
+			nh.logger.Errorf(nh.ctx, "Failed to get notification preferences: %v", err)
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(p); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		p.UserID = userID
+		if err := nh.notificationService.UpdatePreferences(p); err != nil {
+			delivery.HendleError(w, r, http.StatusUnprocessableEntity, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, p)
+	}
+}
+
+func (nh *NotificationHandler) currentUserID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	userIDstr, ok := r.Context().Value(meta.UserIDKey).(string)
+	if !ok || userIDstr == "" {
+		delivery.HendleError(w, r, http.StatusUnauthorized, domain.ErrInvalidUserID)
+		return 0, false
+	}
+	userID, err := strconv.Atoi(userIDstr)
+	if err != nil {
+		delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidUserID)
+		return 0, false
+	}
+	return userID, true
+}
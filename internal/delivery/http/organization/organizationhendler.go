@@ -0,0 +1,122 @@
+package organization
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/DANazavr/RATest/internal/common/meta"
+	delivery "github.com/DANazavr/RATest/internal/delivery/http"
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/services"
+	"github.com/gorilla/mux"
+)
+
+type OrganizationHendler struct {
+	ctx                 context.Context
+	logger              *log.Log
+	organizationService *services.OrganizationService
+	inviteService       *services.InviteService
+}
+
+func NewOrganizationHendler(ctx context.Context, logger *log.Log, os *services.OrganizationService, is *services.InviteService) *OrganizationHendler {
+	return &OrganizationHendler{
+		ctx:                 ctx,
+		logger:              logger.WithComponent("organization/organizationHendler"),
+		organizationService: os,
+		inviteService:       is,
+	}
+}
+
+func (h *OrganizationHendler) HandleCreate() http.HandlerFunc {
+	type request struct {
+		Slug string `json:"slug"`
+		Name string `json:"name"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		o := &models.Organization{
+			Slug: req.Slug,
+			Name: req.Name,
+		}
+		if err := h.organizationService.Create(o); err != nil {
+			delivery.HendleError(w, r, organizationErrorStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusCreated, o)
+	}
+}
+
+func (h *OrganizationHendler) HandleList() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		orgs, err := h.organizationService.Get()
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, orgs)
+	}
+}
+
+// HandleCreateInvite создаёт приглашение в организацию из пути, например для
+// её первого администратора.
+func (h *OrganizationHendler) HandleCreateInvite() http.HandlerFunc {
+	type request struct {
+		Role  string `json:"role"`
+		Email string `json:"email"`
+	}
+	type response struct {
+		Invite *models.Invite `json:"invite"`
+		Token  string         `json:"token"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(mux.Vars(r)["id"])
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		if _, err := h.organizationService.Organization(id); err != nil {
+			delivery.HendleError(w, r, organizationErrorStatus(err), err)
+			return
+		}
+		i := &models.Invite{
+			Role:  req.Role,
+			Email: req.Email,
+		}
+		if userIDstr, ok := r.Context().Value(meta.UserIDKey).(string); ok {
+			if userID, err := strconv.Atoi(userIDstr); err == nil {
+				i.CreatedBy = &userID
+			}
+		}
+
+		token, err := h.inviteService.ForOrg(id).Create(i)
+		if err != nil {
+			delivery.HendleError(w, r, organizationErrorStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusCreated, response{Invite: i, Token: token})
+	}
+}
+
+func organizationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrOrganizationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrOrganizationSlugTaken):
+		return http.StatusConflict
+	}
+	return http.StatusUnprocessableEntity
+}
@@ -0,0 +1,16 @@
+package delivery
+
+import (
+	"net"
+	"net/http"
+)
+
+// ClientIP возвращает адрес клиента по TCP соединению. Заголовки вроде
+// X-Forwarded-For не учитываются: их может подделать сам клиент.
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
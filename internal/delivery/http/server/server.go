@@ -6,9 +6,16 @@ import (
 
 	"github.com/DANazavr/RATest/config"
 	"github.com/DANazavr/RATest/internal/delivery/http/admin"
+	"github.com/DANazavr/RATest/internal/delivery/http/apikey"
+	"github.com/DANazavr/RATest/internal/delivery/http/audience"
 	"github.com/DANazavr/RATest/internal/delivery/http/auth"
+	"github.com/DANazavr/RATest/internal/delivery/http/dataexport"
+	"github.com/DANazavr/RATest/internal/delivery/http/invite"
 	"github.com/DANazavr/RATest/internal/delivery/http/notification"
+	"github.com/DANazavr/RATest/internal/delivery/http/organization"
+	"github.com/DANazavr/RATest/internal/delivery/http/template"
 	"github.com/DANazavr/RATest/internal/delivery/http/user"
+	"github.com/DANazavr/RATest/internal/domain"
 	"github.com/DANazavr/RATest/internal/log"
 	"github.com/DANazavr/RATest/internal/services"
 	"github.com/DANazavr/RATest/internal/store"
@@ -25,21 +32,34 @@ type server struct {
 	authHendler         *auth.AuthHendler
 	userHendler         *user.UserHendler
 	notificationHandler *notification.NotificationHandler
+	apiKeyHendler       *apikey.APIKeyHendler
+	inviteHendler       *invite.InviteHendler
+	audienceHendler     *audience.AudienceHendler
+	organizationHendler *organization.OrganizationHendler
+	dataExportHendler   *dataexport.DataExportHendler
+	templateHendler     *template.TemplateHendler
 	authMiddleware      *auth.MiddlewareAuth
 	adminMiddleware     *admin.MiddlewareAdmin
 }
 
-func NewServer(ctx context.Context, store store.Store, config *config.Config, logger *log.Log, us *services.UserService, as *services.AuthService, ns *services.NotificationService) *server {
+func NewServer(ctx context.Context, store store.Store, config *config.Config, logger *log.Log, us *services.UserService, as *services.AuthService, ns *services.NotificationService, ps *services.PermissionService, ks *services.APIKeyService, is *services.InviteService, rs *services.PasswordResetService, vs *services.EmailVerificationService, ls *services.LoginThrottleService, oc *services.OIDCService, au *services.AudienceService, os *services.OrganizationService, ds *services.DataExportService, ts *services.TemplateService) *server {
+	adminMiddleware := admin.NewMiddlewareAdmin(ctx, logger, as, ps, ks)
 	s := &server{
 		ctx:                 ctx,
 		router:              mux.NewRouter(),
 		logger:              logger.WithComponent("http/server"),
 		config:              config,
-		authHendler:         auth.NewAuthHendler(ctx, logger, us, as),
-		userHendler:         user.NewUserHendler(ctx, logger, store, us),
-		notificationHandler: notification.NewNotificationHandler(ctx, logger, us, ns),
+		authHendler:         auth.NewAuthHendler(ctx, logger, us, as, is, rs, vs, ls, oc),
+		userHendler:         user.NewUserHendler(ctx, logger, store, us, vs, rs),
+		notificationHandler: notification.NewNotificationHandler(ctx, logger, us, as, ns, vs, au, ts, adminMiddleware),
+		apiKeyHendler:       apikey.NewAPIKeyHendler(ctx, logger, ks),
+		inviteHendler:       invite.NewInviteHendler(ctx, logger, is),
+		audienceHendler:     audience.NewAudienceHendler(ctx, logger, au),
+		organizationHendler: organization.NewOrganizationHendler(ctx, logger, os, is),
+		dataExportHendler:   dataexport.NewDataExportHendler(ctx, logger, ds),
+		templateHendler:     template.NewTemplateHendler(ctx, logger, ts),
 		authMiddleware:      auth.NewMiddlewareAuth(ctx, logger, as),
-		adminMiddleware:     admin.NewMiddlewareAdmin(ctx, logger, as),
+		adminMiddleware:     adminMiddleware,
 	}
 
 	s.configureRouter()
@@ -50,29 +70,87 @@ func NewServer(ctx context.Context, store store.Store, config *config.Config, lo
 }
 
 func (s *server) configureRouter() {
+	s.router.HandleFunc("/.well-known/jwks.json", s.authHendler.HandleJWKS()).Methods("GET")
 	s.router.HandleFunc("/register", s.authHendler.HandleRegister()).Methods("POST")
 	s.router.HandleFunc("/login", s.authHendler.HandleLogin()).Methods("POST")
+	s.router.HandleFunc("/login/mfa", s.authHendler.HandleLoginMFA()).Methods("POST")
+	s.router.HandleFunc("/login/mfa/enroll", s.authHendler.HandleLoginMFAEnroll()).Methods("POST")
+	s.router.HandleFunc("/oidc/{provider}/login", s.authHendler.HandleOIDCLogin()).Methods("GET")
+	s.router.HandleFunc("/oidc/{provider}/callback", s.authHendler.HandleOIDCCallback()).Methods("GET")
 	s.router.HandleFunc("/token_refresh", s.authHendler.HandleTokensRefresh()).Methods("GET")
+	s.router.HandleFunc("/password_reset/request", s.authHendler.HandlePasswordResetRequest()).Methods("POST")
+	s.router.HandleFunc("/password_reset/confirm", s.authHendler.HandlePasswordResetConfirm()).Methods("POST")
+	s.router.HandleFunc("/email/confirm", s.authHendler.HandleEmailConfirm()).Methods("GET")
+	s.router.HandleFunc("/email/resend", s.authHendler.HandleEmailResendByEmail()).Methods("POST")
+	s.router.Handle("/logout", s.authMiddleware.Auth(s.authHendler.HandleLogout())).Methods("POST")
+	s.router.Handle("/logout_all", s.authMiddleware.Auth(s.authHendler.HandleLogoutAll())).Methods("POST")
 
 	in := s.router.PathPrefix("/user").Subrouter()
 	in.Use(s.authMiddleware.Auth)
 	in.HandleFunc("/getnotifications", s.notificationHandler.GetNotificationsByFilter()).Methods("GET")
 	in.HandleFunc("/markasread", s.notificationHandler.MarkAsRead()).Methods("POST")
+	in.HandleFunc("/notification_preferences", s.notificationHandler.GetPreferences()).Methods("GET")
+	in.HandleFunc("/notification_preferences", s.notificationHandler.UpdatePreferences()).Methods("PUT")
 	in.HandleFunc("/profile", s.userHendler.HandleGetUser()).Methods("GET")
+	in.HandleFunc("/password", s.userHendler.HandleChangePassword()).Methods("POST")
+	in.HandleFunc("/email", s.userHendler.HandleChangeEmail()).Methods("POST")
+	in.HandleFunc("/account", s.userHendler.HandleDeleteAccount()).Methods("DELETE")
+	in.HandleFunc("/exports", s.dataExportHendler.HandleRequest()).Methods("POST")
+	in.HandleFunc("/exports", s.dataExportHendler.HandleList()).Methods("GET")
+	in.HandleFunc("/exports/{id:[0-9]+}/download", s.dataExportHendler.HandleDownload()).Methods("GET")
+	in.HandleFunc("/email/resend", s.authHendler.HandleEmailResend()).Methods("POST")
+	in.HandleFunc("/mfa/enroll", s.authHendler.HandleMFAEnroll()).Methods("POST")
+	in.HandleFunc("/mfa/confirm", s.authHendler.HandleMFAConfirm()).Methods("POST")
+	in.HandleFunc("/mfa/disable", s.authHendler.HandleMFADisable()).Methods("POST")
+	in.HandleFunc("/centrifugo/connection_token", s.notificationHandler.CentrifugoConnectionToken()).Methods("GET")
+	in.HandleFunc("/centrifugo/subscription_token", s.notificationHandler.CentrifugoSubscriptionToken()).Methods("POST")
 
 	admin := s.router.PathPrefix("/admin").Subrouter()
-	admin.Use(s.adminMiddleware.Admin)
-	admin.HandleFunc("/getUsers", s.userHendler.HandleGetUsers()).Methods("GET")
+	admin.Handle("/getUsers", s.adminMiddleware.Require(domain.PermUserRead)(s.userHendler.HandleGetUsers())).Methods("GET")
+	admin.Handle("/users/{id:[0-9]+}/role", s.adminMiddleware.Require(domain.PermUserManage)(s.userHendler.HandleSetRole())).Methods("PUT")
+	admin.Handle("/users/{id:[0-9]+}/disable", s.adminMiddleware.Require(domain.PermUserManage)(s.userHendler.HandleSetDisabled(true))).Methods("POST")
+	admin.Handle("/users/{id:[0-9]+}/enable", s.adminMiddleware.Require(domain.PermUserManage)(s.userHendler.HandleSetDisabled(false))).Methods("POST")
+	admin.Handle("/users/{id:[0-9]+}/password_reset", s.adminMiddleware.Require(domain.PermUserManage)(s.userHendler.HandleForcePasswordReset())).Methods("POST")
+	admin.Handle("/users/{id:[0-9]+}", s.adminMiddleware.Require(domain.PermUserManage)(s.userHendler.HandleDeleteUser())).Methods("DELETE")
+	admin.Handle("/users/{id:[0-9]+}/exports", s.adminMiddleware.Require(domain.PermUserManage)(s.dataExportHendler.HandleAdminRequest())).Methods("POST")
+	admin.Handle("/users/{id:[0-9]+}/exports", s.adminMiddleware.Require(domain.PermUserManage)(s.dataExportHendler.HandleAdminList())).Methods("GET")
+	admin.Handle("/exports/{id:[0-9]+}/download", s.adminMiddleware.Require(domain.PermUserManage)(s.dataExportHendler.HandleAdminDownload())).Methods("GET")
+	admin.Handle("/apikeys", s.adminMiddleware.Require(domain.PermAPIKeyManage)(s.apiKeyHendler.HandleCreate())).Methods("POST")
+	admin.Handle("/apikeys", s.adminMiddleware.Require(domain.PermAPIKeyManage)(s.apiKeyHendler.HandleList())).Methods("GET")
+	admin.Handle("/apikeys/{id:[0-9]+}", s.adminMiddleware.Require(domain.PermAPIKeyManage)(s.apiKeyHendler.HandleRevoke())).Methods("DELETE")
+	admin.Handle("/invites", s.adminMiddleware.Require(domain.PermUserInvite)(s.inviteHendler.HandleCreate())).Methods("POST")
+	admin.Handle("/invites", s.adminMiddleware.Require(domain.PermUserInvite)(s.inviteHendler.HandleList())).Methods("GET")
+	admin.Handle("/groups", s.adminMiddleware.Require(domain.PermAudienceManage)(s.audienceHendler.HandleCreateGroup())).Methods("POST")
+	admin.Handle("/groups", s.adminMiddleware.Require(domain.PermAudienceManage)(s.audienceHendler.HandleListGroups())).Methods("GET")
+	admin.Handle("/groups/{id:[0-9]+}", s.adminMiddleware.Require(domain.PermAudienceManage)(s.audienceHendler.HandleDeleteGroup())).Methods("DELETE")
+	admin.Handle("/groups/{id:[0-9]+}/members", s.adminMiddleware.Require(domain.PermAudienceManage)(s.audienceHendler.HandleListGroupMembers())).Methods("GET")
+	admin.Handle("/groups/{id:[0-9]+}/members", s.adminMiddleware.Require(domain.PermAudienceManage)(s.audienceHendler.HandleAddGroupMembers())).Methods("POST")
+	admin.Handle("/groups/{id:[0-9]+}/members/{user_id:[0-9]+}", s.adminMiddleware.Require(domain.PermAudienceManage)(s.audienceHendler.HandleRemoveGroupMember())).Methods("DELETE")
+	admin.Handle("/segments", s.adminMiddleware.Require(domain.PermAudienceManage)(s.audienceHendler.HandleCreateSegment())).Methods("POST")
+	admin.Handle("/segments", s.adminMiddleware.Require(domain.PermAudienceManage)(s.audienceHendler.HandleListSegments())).Methods("GET")
+	admin.Handle("/segments/{id:[0-9]+}", s.adminMiddleware.Require(domain.PermAudienceManage)(s.audienceHendler.HandleDeleteSegment())).Methods("DELETE")
+	admin.Handle("/segments/{id:[0-9]+}/members", s.adminMiddleware.Require(domain.PermAudienceManage)(s.audienceHendler.HandleListSegmentMembers())).Methods("GET")
+	admin.Handle("/organizations", s.adminMiddleware.Require(domain.PermOrganizationManage)(s.organizationHendler.HandleCreate())).Methods("POST")
+	admin.Handle("/organizations", s.adminMiddleware.Require(domain.PermOrganizationManage)(s.organizationHendler.HandleList())).Methods("GET")
+	admin.Handle("/organizations/{id:[0-9]+}/invites", s.adminMiddleware.Require(domain.PermOrganizationManage)(s.organizationHendler.HandleCreateInvite())).Methods("POST")
+	admin.Handle("/templates", s.adminMiddleware.Require(domain.PermTemplateManage)(s.templateHendler.HandleCreate())).Methods("POST")
+	admin.Handle("/templates", s.adminMiddleware.Require(domain.PermTemplateManage)(s.templateHendler.HandleList())).Methods("GET")
+	admin.Handle("/templates/{key}", s.adminMiddleware.Require(domain.PermTemplateManage)(s.templateHendler.HandleGet())).Methods("GET")
+	admin.Handle("/templates/{key}", s.adminMiddleware.Require(domain.PermTemplateManage)(s.templateHendler.HandleUpdate())).Methods("PUT")
+	admin.Handle("/templates/{key}", s.adminMiddleware.Require(domain.PermTemplateManage)(s.templateHendler.HandleDelete())).Methods("DELETE")
+	admin.Handle("/templates/{key}/preview", s.adminMiddleware.Require(domain.PermTemplateManage)(s.templateHendler.HandlePreview())).Methods("POST")
 
 	notificationRouter := s.router.PathPrefix("/notification").Subrouter()
-	notificationRouter.Use(s.adminMiddleware.Admin)
-	notificationRouter.HandleFunc("/broadcast", s.notificationHandler.Broadcast()).Methods("POST")
-	notificationRouter.HandleFunc("/publish", s.notificationHandler.Publish()).Methods("POST")
+	notificationRouter.Handle("/broadcast", s.adminMiddleware.Require(domain.PermNotificationBroadcast)(s.notificationHandler.Broadcast())).Methods("POST")
+	notificationRouter.Handle("/publish", s.adminMiddleware.Require(domain.PermNotificationPublish)(s.notificationHandler.Publish())).Methods("POST")
+	notificationRouter.Handle("/scheduled", s.adminMiddleware.Require(domain.PermNotificationPublish)(s.notificationHandler.ListScheduled())).Methods("GET")
+	notificationRouter.Handle("/scheduled/{id:[0-9]+}", s.adminMiddleware.Require(domain.PermNotificationPublish)(s.notificationHandler.Reschedule())).Methods("PUT")
+	notificationRouter.Handle("/scheduled/{id:[0-9]+}", s.adminMiddleware.Require(domain.PermNotificationPublish)(s.notificationHandler.CancelScheduled())).Methods("DELETE")
 
 	c := cors.New(cors.Options{
 		AllowedOrigins:   []string{"*"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Authorization", "Content-Type"},
+		AllowedHeaders:   []string{"Authorization", "Content-Type", "X-API-Key", "X-Timestamp", "X-Signature"},
 		AllowCredentials: true,
 	})
 
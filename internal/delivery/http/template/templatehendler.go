@@ -0,0 +1,166 @@
+package template
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/DANazavr/RATest/internal/common/meta"
+	delivery "github.com/DANazavr/RATest/internal/delivery/http"
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/services"
+	"github.com/gorilla/mux"
+)
+
+type TemplateHendler struct {
+	ctx             context.Context
+	logger          *log.Log
+	templateService *services.TemplateService
+}
+
+func NewTemplateHendler(ctx context.Context, logger *log.Log, ts *services.TemplateService) *TemplateHendler {
+	return &TemplateHendler{
+		ctx:             ctx,
+		logger:          logger.WithComponent("template/templateHendler"),
+		templateService: ts,
+	}
+}
+
+type templateRequest struct {
+	Key           string                            `json:"key"`
+	Description   string                            `json:"description"`
+	Category      string                            `json:"category"`
+	Priority      string                            `json:"priority"`
+	Icon          string                            `json:"icon"`
+	Image         string                            `json:"image"`
+	DefaultLocale string                            `json:"default_locale"`
+	Variables     []models.TemplateVariable         `json:"variables"`
+	Locales       map[string]models.TemplateContent `json:"locales"`
+}
+
+func (req *templateRequest) template() *models.NotificationTemplate {
+	return &models.NotificationTemplate{
+		Key:           req.Key,
+		Description:   req.Description,
+		Category:      req.Category,
+		Priority:      req.Priority,
+		Icon:          req.Icon,
+		Image:         req.Image,
+		DefaultLocale: req.DefaultLocale,
+		Variables:     req.Variables,
+		Locales:       req.Locales,
+	}
+}
+
+func (h *TemplateHendler) HandleCreate() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := &templateRequest{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		t := req.template()
+		if userIDstr, ok := r.Context().Value(meta.UserIDKey).(string); ok {
+			if userID, err := strconv.Atoi(userIDstr); err == nil {
+				t.CreatedBy = &userID
+			}
+		}
+		if err := h.orgTemplates(r).Create(t); err != nil {
+			delivery.HendleError(w, r, templateErrorStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusCreated, t)
+	}
+}
+
+func (h *TemplateHendler) HandleList() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		templates, err := h.orgTemplates(r).Templates()
+		if err != nil {
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, templates)
+	}
+}
+
+func (h *TemplateHendler) HandleGet() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		t, err := h.orgTemplates(r).Template(mux.Vars(r)["key"])
+		if err != nil {
+			delivery.HendleError(w, r, templateErrorStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, t)
+	}
+}
+
+// HandleUpdate заменяет шаблон из пути целиком. Ключ шаблона не меняется.
+func (h *TemplateHendler) HandleUpdate() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := &templateRequest{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		req.Key = mux.Vars(r)["key"]
+		t := req.template()
+		if err := h.orgTemplates(r).Update(t); err != nil {
+			delivery.HendleError(w, r, templateErrorStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, t)
+	}
+}
+
+func (h *TemplateHendler) HandleDelete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.orgTemplates(r).Delete(mux.Vars(r)["key"]); err != nil {
+			delivery.HendleError(w, r, templateErrorStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusNoContent, nil)
+	}
+}
+
+// HandlePreview отрисовывает шаблон с переданными или примерными значениями
+// переменных, ничего не отправляя.
+func (h *TemplateHendler) HandlePreview() http.HandlerFunc {
+	type request struct {
+		Locale    string                 `json:"locale"`
+		Variables map[string]interface{} `json:"variables"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		p, err := h.orgTemplates(r).Preview(mux.Vars(r)["key"], req.Locale, req.Variables)
+		if err != nil {
+			delivery.HendleError(w, r, templateErrorStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, p)
+	}
+}
+
+func templateErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrTemplateNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrTemplateKeyTaken):
+		return http.StatusConflict
+	}
+	return http.StatusUnprocessableEntity
+}
+
+// orgTemplates возвращает сервис шаблонов организации администратора.
+func (h *TemplateHendler) orgTemplates(r *http.Request) *services.TemplateService {
+	orgID, _ := r.Context().Value(meta.OrgIDKey).(int)
+	return h.templateService.ForOrg(orgID)
+}
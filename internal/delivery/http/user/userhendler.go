@@ -2,54 +2,298 @@ package user
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/DANazavr/RATest/internal/common/meta"
 	delivery "github.com/DANazavr/RATest/internal/delivery/http"
 	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
 	"github.com/DANazavr/RATest/internal/log"
 	"github.com/DANazavr/RATest/internal/services"
 	"github.com/DANazavr/RATest/internal/store"
+	"github.com/gorilla/mux"
 )
 
 type UserHendler struct {
-	ctx         context.Context
-	logger      *log.Log
-	userService *services.UserService
+	ctx           context.Context
+	logger        *log.Log
+	userService   *services.UserService
+	verifyService *services.EmailVerificationService
+	resetService  *services.PasswordResetService
 }
 
-func NewUserHendler(ctx context.Context, logger *log.Log, store store.Store, us *services.UserService) *UserHendler {
+func NewUserHendler(ctx context.Context, logger *log.Log, store store.Store, us *services.UserService, vs *services.EmailVerificationService, rs *services.PasswordResetService) *UserHendler {
 	return &UserHendler{
-		ctx:         ctx,
-		logger:      logger.WithComponent("user/userHendler"),
-		userService: us,
+		ctx:           ctx,
+		logger:        logger.WithComponent("user/userHendler"),
+		userService:   us,
+		verifyService: vs,
+		resetService:  rs,
 	}
 }
 
 func (h *UserHendler) HandleGetUser() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
-		userID, ok := ctx.Value(meta.UserIDKey).(int64)
+		u, ok := h.currentUser(w, r)
 		if !ok {
-			delivery.HendleError(w, r, http.StatusUnauthorized, domain.ErrInvalidToken)
 			return
 		}
-		u, err := h.userService.UsersGetById(int(userID))
+		delivery.HendleRespond(w, r, http.StatusOK, u)
+	}
+}
+
+// HandleGetUsers отдаёт страницу пользователей. Параметры запроса: limit,
+// cursor, sort, order (asc/desc), role, created_from, created_to (RFC 3339), q.
+func (h *UserHendler) HandleGetUsers() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		f := &models.UserFilter{
+			Role:   q.Get("role"),
+			Search: q.Get("q"),
+			SortBy: q.Get("sort"),
+			Desc:   q.Get("order") == "desc",
+		}
+		if v := q.Get("limit"); v != "" {
+			limit, err := strconv.Atoi(v)
+			if err != nil {
+				delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidFilter)
+				return
+			}
+			f.Limit = limit
+		}
+		var err error
+		if f.CreatedFrom, err = services.ParseFilterTime(q.Get("created_from")); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidFilter)
+			return
+		}
+		if f.CreatedTo, err = services.ParseFilterTime(q.Get("created_to")); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidFilter)
+			return
+		}
+
+		page, err := h.orgUsers(r).UsersList(f, q.Get("cursor"))
+		if err != nil {
+			if errors.Is(err, domain.ErrInvalidFilter) || errors.Is(err, domain.ErrInvalidCursor) {
+				delivery.HendleError(w, r, http.StatusBadRequest, err)
+				return
+			}
+			delivery.HendleError(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusOK, page)
+	}
+}
+
+func (h *UserHendler) HandleChangePassword() http.HandlerFunc {
+	type request struct {
+		CurrentPassword string `json:"current_password"`
+		NewPassword     string `json:"new_password"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		u, ok := h.currentUser(w, r)
+		if !ok {
+			return
+		}
+		if err := h.userService.ChangePassword(r.Context(), u, req.CurrentPassword, req.NewPassword, sessionID(r)); err != nil {
+			delivery.HendleError(w, r, accountErrorStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusNoContent, nil)
+	}
+}
+
+func (h *UserHendler) HandleChangeEmail() http.HandlerFunc {
+	type request struct {
+		Password string `json:"password"`
+		Email    string `json:"email"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		u, ok := h.currentUser(w, r)
+		if !ok {
+			return
+		}
+		if err := h.userService.Reauthenticate(u, req.Password, sessionID(r)); err != nil {
+			delivery.HendleError(w, r, accountErrorStatus(err), err)
+			return
+		}
+		if err := h.verifyService.RequestChange(r.Context(), u, req.Email); err != nil {
+			delivery.HendleError(w, r, accountErrorStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusAccepted, nil)
+	}
+}
+
+func (h *UserHendler) HandleDeleteAccount() http.HandlerFunc {
+	type request struct {
+		Password string `json:"password"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		u, ok := h.currentUser(w, r)
+		if !ok {
+			return
+		}
+		if err := h.userService.DeleteAccount(r.Context(), u, req.Password, sessionID(r)); err != nil {
+			delivery.HendleError(w, r, accountErrorStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusNoContent, nil)
+	}
+}
+
+func (h *UserHendler) HandleSetRole() http.HandlerFunc {
+	type request struct {
+		Role string `json:"role"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := userIDFromPath(w, r)
+		if !ok {
+			return
+		}
+		req := &request{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidRequestBody)
+			return
+		}
+		u, err := h.orgUsers(r).ChangeRole(r.Context(), id, req.Role)
 		if err != nil {
-			delivery.HendleError(w, r, http.StatusNotFound, err)
+			delivery.HendleError(w, r, manageErrorStatus(err), err)
 			return
 		}
 		delivery.HendleRespond(w, r, http.StatusOK, u)
 	}
 }
 
-func (h *UserHendler) HandleGetUsers() http.HandlerFunc {
+func (h *UserHendler) HandleSetDisabled(disabled bool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		u, err := h.userService.UsersGet()
+		id, ok := userIDFromPath(w, r)
+		if !ok {
+			return
+		}
+		u, err := h.orgUsers(r).SetDisabled(r.Context(), id, disabled)
 		if err != nil {
-			delivery.HendleError(w, r, http.StatusNotFound, err)
+			delivery.HendleError(w, r, manageErrorStatus(err), err)
 			return
 		}
 		delivery.HendleRespond(w, r, http.StatusOK, u)
 	}
 }
+
+func (h *UserHendler) HandleForcePasswordReset() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := userIDFromPath(w, r)
+		if !ok {
+			return
+		}
+		if _, err := h.orgUsers(r).UsersGetById(id); err != nil {
+			delivery.HendleError(w, r, manageErrorStatus(err), err)
+			return
+		}
+		if err := h.resetService.ForceReset(r.Context(), id); err != nil {
+			delivery.HendleError(w, r, manageErrorStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusAccepted, nil)
+	}
+}
+
+func (h *UserHendler) HandleDeleteUser() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := userIDFromPath(w, r)
+		if !ok {
+			return
+		}
+		if err := h.orgUsers(r).DeleteUser(r.Context(), id); err != nil {
+			delivery.HendleError(w, r, manageErrorStatus(err), err)
+			return
+		}
+		delivery.HendleRespond(w, r, http.StatusNoContent, nil)
+	}
+}
+
+// orgUsers возвращает сервис пользователей организации администратора.
+func (h *UserHendler) orgUsers(r *http.Request) *services.UserService {
+	orgID, _ := r.Context().Value(meta.OrgIDKey).(int)
+	return h.userService.ForOrg(orgID)
+}
+
+func userIDFromPath(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidUserID)
+		return 0, false
+	}
+	return id, true
+}
+
+// currentUser загружает владельца access токена. При ошибке ответ уже записан.
+func (h *UserHendler) currentUser(w http.ResponseWriter, r *http.Request) (*models.User, bool) {
+	userIDstr, ok := r.Context().Value(meta.UserIDKey).(string)
+	if !ok || userIDstr == "" {
+		delivery.HendleError(w, r, http.StatusUnauthorized, domain.ErrInvalidUserID)
+		return nil, false
+	}
+	userID, err := strconv.Atoi(userIDstr)
+	if err != nil {
+		delivery.HendleError(w, r, http.StatusBadRequest, domain.ErrInvalidUserID)
+		return nil, false
+	}
+	u, err := h.userService.UsersGetById(userID)
+	if err != nil {
+		delivery.HendleError(w, r, http.StatusNotFound, err)
+		return nil, false
+	}
+	return u, true
+}
+
+// sessionID возвращает семейство сессий, которым выдан access токен запроса.
+func sessionID(r *http.Request) string {
+	sid, _ := r.Context().Value(meta.SessionIDKey).(string)
+	return sid
+}
+
+func accountErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrIncorrectPassword), errors.Is(err, domain.ErrReauthenticationRequired):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrEmailTaken),
+		errors.Is(err, domain.ErrEmailAlreadyVerified),
+		errors.Is(err, domain.ErrLastAdmin):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrUserNotFound):
+		return http.StatusNotFound
+	}
+	return http.StatusUnprocessableEntity
+}
+
+func manageErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrRecordNotFound), errors.Is(err, domain.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrUnknownRole):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, domain.ErrSuperAdminRole):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrLastAdmin):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
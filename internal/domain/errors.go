@@ -15,6 +15,8 @@ var (
 	ErrInvalidRequestBody         = errors.New("invalid request body")
 	ErrInvalidUserID              = errors.New("invalid user ID")
 	ErrInternalEnviroment         = errors.New("enviroment key is not set")
+	ErrRefreshTokenReused         = errors.New("refresh token reused")
+	ErrSessionRevoked             = errors.New("session revoked")
 
 	// service errors
 	ErrUserAlreadyExists                  = errors.New("user already exists")
@@ -25,5 +27,59 @@ var (
 	ErrCentrifugePresenceFailed           = errors.New("failed to get presence")
 	ErrCentrifugeNotification             = errors.New("error with notification")
 	ErrCentrifugeNotificationCreateFailed = errors.New("notification create failed")
+	ErrChannelForbidden                   = errors.New("channel access denied")
+	ErrPermissionDenied                   = errors.New("permission denied")
+	ErrUnknownRole                        = errors.New("unknown role")
+	ErrUnknownPermission                  = errors.New("unknown permission")
+	ErrInvalidAPIKey                      = errors.New("invalid API key")
+	ErrInvalidSignature                   = errors.New("invalid request signature")
+	ErrAPIKeyNotFound                     = errors.New("API key not found")
+	ErrInvalidInvite                      = errors.New("invalid or expired invite")
+	ErrInviteEmailMismatch                = errors.New("email does not match the invite")
+	ErrAdminAlreadyExists                 = errors.New("admin already exists")
+	ErrInvalidResetToken                  = errors.New("invalid or expired password reset token")
+	ErrInvalidVerificationToken           = errors.New("invalid or expired email verification token")
+	ErrEmailNotVerified                   = errors.New("email is not verified")
+	ErrEmailAlreadyVerified               = errors.New("email is already verified")
+	ErrTooManyLoginAttempts               = errors.New("too many login attempts, try again later")
+	ErrInvalidMFACode                     = errors.New("invalid two-factor code")
+	ErrInvalidMFAToken                    = errors.New("invalid or expired two-factor login token")
+	ErrMFAAlreadyEnabled                  = errors.New("two-factor authentication is already enabled")
+	ErrMFANotEnabled                      = errors.New("two-factor authentication is not enabled")
+	ErrMFARequired                        = errors.New("two-factor authentication is required for this role")
+	ErrUnknownOIDCProvider                = errors.New("unknown identity provider")
+	ErrInvalidOIDCState                   = errors.New("invalid or expired login state")
+	ErrOIDCLoginFailed                    = errors.New("external login failed")
+	ErrOIDCEmailTaken                     = errors.New("an account with this email already exists")
+	ErrIncorrectPassword                  = errors.New("incorrect password")
+	ErrReauthenticationRequired           = errors.New("sign in again to confirm this action")
+	ErrEmailTaken                         = errors.New("email is already in use")
+	ErrLastAdmin                          = errors.New("the last admin cannot be removed")
+	ErrUserDisabled                       = errors.New("user account is disabled")
+	ErrInvalidCursor                      = errors.New("invalid page cursor")
+	ErrGroupNotFound                      = errors.New("group not found")
+	ErrSegmentNotFound                    = errors.New("segment not found")
+	ErrAudienceNameTaken                  = errors.New("audience name is already in use")
+	ErrInvalidAudience                    = errors.New("specify exactly one of channel, group_id or segment_id")
+	ErrPushSuppressed                     = errors.New("real-time delivery is disabled by the user preferences")
+	ErrPushDeferred                       = errors.New("real-time delivery is deferred until the end of quiet hours")
+	ErrUnknownTimezone                    = errors.New("unknown timezone")
+	ErrInvalidQuietHours                  = errors.New("quiet hours must be in HH:MM format")
+	ErrOrganizationNotFound               = errors.New("organization not found")
+	ErrOrganizationSlugTaken              = errors.New("organization slug is already in use")
+	ErrSuperAdminRole                     = errors.New("the superadmin role is managed only by the bootstrap command")
+	ErrDataExportNotFound                 = errors.New("data export not found")
+	ErrDataExportNotReady                 = errors.New("data export is not ready or has expired")
+	ErrMetadataTooLarge                   = errors.New("notification metadata is too large")
+	ErrInvalidPriority                    = errors.New("unknown notification priority")
+	ErrTemplateNotFound                   = errors.New("notification template not found")
+	ErrTemplateKeyTaken                   = errors.New("notification template key is already in use")
+	ErrTemplateForbidden                  = errors.New("template uses a construct that is not allowed")
+	ErrTemplateVariables                  = errors.New("template variables do not match its declaration")
+	ErrTemplateContentConflict            = errors.New("specify either template_key or data, not both")
+	ErrInvalidLocale                      = errors.New("invalid locale")
+	ErrInvalidDeliverAt                   = errors.New("deliver_at must be in the future")
+	ErrScheduledNotFound                  = errors.New("scheduled notification not found or already delivered")
+	ErrScopeNotGranted                    = errors.New("API key scope exceeds the permissions of its creator")
 	// Err
 )
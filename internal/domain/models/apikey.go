@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+type APIKey struct {
+	ID               int        `json:"id" db:"id"`
+	Name             string     `json:"name" db:"name"`
+	Prefix           string     `json:"prefix" db:"prefix"`
+	KeyHash          string     `json:"-" db:"key_hash"`
+	Scopes           []string   `json:"scopes" db:"scopes"`
+	RequireSignature bool       `json:"require_signature" db:"require_signature"`
+	OrgID            int        `json:"org_id" db:"org_id"`
+	CreatedBy        *int       `json:"created_by" db:"created_by"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	LastUsedAt       *time.Time `json:"last_used_at" db:"last_used_at"`
+	RevokedAt        *time.Time `json:"revoked_at" db:"revoked_at"`
+}
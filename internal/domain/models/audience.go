@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// Group - именованная группа пользователей со списком участников,
+// который ведут администраторы.
+type Group struct {
+	ID          int       `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`
+	Description string    `json:"description" db:"description"`
+	OrgID       int       `json:"-" db:"org_id"`
+	CreatedBy   *int      `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	Members     int       `json:"members" db:"members"`
+}
+
+// Segment - аудитория, участники которой выбираются по атрибутам
+// пользователя в момент публикации.
+type Segment struct {
+	ID          int          `json:"id" db:"id"`
+	Name        string       `json:"name" db:"name"`
+	Description string       `json:"description" db:"description"`
+	Rules       SegmentRules `json:"rules" db:"rules"`
+	OrgID       int          `json:"-" db:"org_id"`
+	CreatedBy   *int         `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt   time.Time    `json:"created_at" db:"created_at"`
+}
+
+// SegmentRules - условия сегмента. Пустое условие не ограничивает выборку,
+// заданные объединяются через AND.
+type SegmentRules struct {
+	Role          string     `json:"role,omitempty"`
+	EmailVerified *bool      `json:"email_verified,omitempty"`
+	CreatedFrom   *time.Time `json:"created_from,omitempty"`
+	CreatedTo     *time.Time `json:"created_to,omitempty"`
+	// Префикс имени пользователя или email без учёта регистра
+	Search string `json:"q,omitempty"`
+}
+
+// FanoutResult - итог публикации уведомления группе или сегменту.
+type FanoutResult struct {
+	// Сколько уведомлений сохранено в истории
+	Recipients int `json:"recipients"`
+	// Сколько из них отправлено в Centrifugo
+	Pushed int `json:"pushed"`
+	// Скольким получателям уведомление не удалось сохранить или отправить
+	Failed int `json:"failed"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// Статусы выгрузки данных пользователя
+const (
+	DataExportPending = "pending"
+	DataExportRunning = "running"
+	DataExportReady   = "ready"
+	DataExportFailed  = "failed"
+)
+
+// DataExport - задание на выгрузку профиля и истории уведомлений
+// пользователя. Архив собирается фоновой задачей и хранится до ExpiresAt.
+type DataExport struct {
+	ID          int        `json:"id" db:"id"`
+	UserID      int        `json:"user_id" db:"user_id"`
+	OrgID       int        `json:"-" db:"org_id"`
+	RequestedBy *int       `json:"requested_by" db:"requested_by"`
+	Status      string     `json:"status" db:"status"`
+	Error       string     `json:"error,omitempty" db:"error"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	CompletedAt *time.Time `json:"completed_at" db:"completed_at"`
+	ExpiresAt   *time.Time `json:"expires_at" db:"expires_at"`
+}
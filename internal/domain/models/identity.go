@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// ExternalIdentity связывает пользователя с учётной записью у внешнего
+// провайдера OpenID Connect (пара provider + subject).
+type ExternalIdentity struct {
+	ID          int        `json:"id" db:"id"`
+	UserID      int        `json:"user_id" db:"user_id"`
+	Provider    string     `json:"provider" db:"provider"`
+	Subject     string     `json:"subject" db:"subject"`
+	Email       string     `json:"email" db:"email"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	LastLoginAt *time.Time `json:"last_login_at" db:"last_login_at"`
+}
+
+// OIDCState - незавершённый вход через провайдера. Хранится только хеш
+// state; code_verifier PKCE и nonce не покидают сервер.
+type OIDCState struct {
+	StateHash    string     `json:"-" db:"state_hash"`
+	Provider     string     `json:"provider" db:"provider"`
+	CodeVerifier string     `json:"-" db:"code_verifier"`
+	Nonce        string     `json:"-" db:"nonce"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	ExpiresAt    time.Time  `json:"expires_at" db:"expires_at"`
+	UsedAt       *time.Time `json:"used_at" db:"used_at"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+type Invite struct {
+	ID        string     `json:"id" db:"id"`
+	Role      string     `json:"role" db:"role"`
+	Email     string     `json:"email,omitempty" db:"email"`
+	OrgID     int        `json:"org_id" db:"org_id"`
+	CreatedBy *int       `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty" db:"used_at"`
+	UsedBy    *int       `json:"used_by,omitempty" db:"used_by"`
+}
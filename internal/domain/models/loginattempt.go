@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// Причины в журнале попыток входа
+const (
+	LoginReasonSuccess            = "success"
+	LoginReasonInvalidCredentials = "invalid_credentials"
+	LoginReasonThrottled          = "throttled"
+	LoginReasonEmailNotVerified   = "email_not_verified"
+	LoginReasonMFARequired        = "mfa_required"
+	LoginReasonInvalidMFACode     = "invalid_mfa_code"
+	LoginReasonDisabled           = "disabled"
+)
+
+type LoginAttempt struct {
+	ID        int       `json:"id" db:"id"`
+	Username  string    `json:"username" db:"username"`
+	UserID    *int      `json:"user_id" db:"user_id"`
+	IP        string    `json:"ip" db:"ip"`
+	UserAgent string    `json:"user_agent" db:"user_agent"`
+	Success   bool      `json:"success" db:"success"`
+	Reason    string    `json:"reason" db:"reason"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
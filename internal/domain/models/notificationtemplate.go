@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// Типы переменных шаблона
+const (
+	TemplateVarString = "string"
+	TemplateVarNumber = "number"
+	TemplateVarBool   = "bool"
+	// Строка в RFC 3339, в шаблоне доступна как time.Time
+	TemplateVarTime = "time"
+)
+
+// NotificationTemplate - шаблон уведомления, который издатель указывает по
+// ключу вместо готового текста. Заголовок, текст и ссылка задаются отдельно
+// для каждого языка в синтаксисе text/template.
+type NotificationTemplate struct {
+	ID          int    `json:"id" db:"id"`
+	Key         string `json:"key" db:"key"`
+	Description string `json:"description" db:"description"`
+	Category    string `json:"category" db:"category"`
+	Priority    string `json:"priority" db:"priority"`
+	Icon        string `json:"icon" db:"icon"`
+	Image       string `json:"image" db:"image"`
+	// Язык, который используется, если нужного варианта нет
+	DefaultLocale string                     `json:"default_locale" db:"default_locale"`
+	Variables     []TemplateVariable         `json:"variables" db:"variables"`
+	Locales       map[string]TemplateContent `json:"locales" db:"locales"`
+	OrgID         int                        `json:"-" db:"org_id"`
+	CreatedBy     *int                       `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt     time.Time                  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time                  `json:"updated_at" db:"updated_at"`
+}
+
+type TemplateVariable struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Required bool   `json:"required"`
+}
+
+// TemplateContent - вариант шаблона на одном языке.
+type TemplateContent struct {
+	Title     string `json:"title"`
+	Message   string `json:"message"`
+	ActionURL string `json:"action_url,omitempty"`
+}
+
+// TemplatePreview - шаблон, отрисованный с примерными данными.
+type TemplatePreview struct {
+	// Язык варианта, который был выбран
+	Locale       string           `json:"locale"`
+	Notification NotificationData `json:"notification"`
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// Organization - тенант: пользователи, уведомления, API ключи, приглашения и
+// аудитории одной организации не видны другим.
+type Organization struct {
+	ID        int       `json:"id" db:"id"`
+	Slug      string    `json:"slug" db:"slug"`
+	Name      string    `json:"name" db:"name"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// TOTP - секрет второго фактора пользователя. До подтверждения первым кодом
+// ConfirmedAt равен nil и секрет при входе не требуется.
+type TOTP struct {
+	UserID       int        `json:"user_id" db:"user_id"`
+	Secret       string     `json:"-" db:"secret"`
+	LastUsedStep int64      `json:"-" db:"last_used_step"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	ConfirmedAt  *time.Time `json:"confirmed_at" db:"confirmed_at"`
+}
@@ -1,11 +1,46 @@
 package models
 
+import "time"
+
 type User struct {
-	ID                int    `json:"id"`
-	Username          string `json:"username"`
-	Password          string `json:"password,omitempty"`
-	EncryptedPassword string `json:"-"`
-	Email             string `json:"email"`
-	Role              string `json:"role"`
-	CreatedAt         string `json:"created_at"`
+	ID                int        `json:"id"`
+	Username          string     `json:"username"`
+	Password          string     `json:"password,omitempty"`
+	EncryptedPassword string     `json:"-"`
+	Email             string     `json:"email"`
+	EmailVerifiedAt   *time.Time `json:"email_verified_at"`
+	Role              string     `json:"role"`
+	OrgID             int        `json:"org_id"`
+	DisabledAt        *time.Time `json:"disabled_at"`
+	CreatedAt         string     `json:"created_at"`
+}
+
+// UserFilter - условия постраничной выборки пользователей.
+type UserFilter struct {
+	Role        string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	// Префикс имени пользователя или email без учёта регистра
+	Search        string
+	EmailVerified *bool
+	// Только незаблокированные пользователи
+	Active bool
+	SortBy string
+	Desc   bool
+	Limit  int
+	// Ключ сортировки последней строки предыдущей страницы
+	After *UserCursor
+}
+
+type UserCursor struct {
+	Value string
+	ID    int
+}
+
+// UserPage - страница списка пользователей. Total - число пользователей,
+// подходящих под фильтр, на всех страницах.
+type UserPage struct {
+	Users      []*User `json:"users"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+	Total      int     `json:"total"`
 }
@@ -1,10 +1,69 @@
 package models
 
+import "time"
+
 type UserNotification struct {
-	UID          int                    `json:"uid" db:"uid"`
-	UserID       int                    `json:"user_id" db:"user_id"`
-	CreatedAt    *string                `json:"created_at" db:"created_at"`
-	SendAt       *string                `json:"send_at" db:"send_at"`
-	ReadAt       *string                `json:"read_at" db:"read_at"`
-	Notification map[string]interface{} `json:"notification" db:"notification"`
+	UID          int              `json:"uid" db:"uid"`
+	UserID       int              `json:"user_id" db:"user_id"`
+	OrgID        int              `json:"-" db:"org_id"`
+	CreatedAt    *string          `json:"created_at" db:"created_at"`
+	SendAt       *string          `json:"send_at" db:"send_at"`
+	ReadAt       *string          `json:"read_at" db:"read_at"`
+	Notification NotificationData `json:"notification" db:"notification"`
+	APIKeyID     *int             `json:"api_key_id,omitempty" db:"api_key_id"`
+	DeliverAt    *time.Time       `json:"deliver_at,omitempty" db:"deliver_at"`
+	// Запланировано издателем и ещё не отправлено
+	Scheduled bool `json:"scheduled,omitempty" db:"scheduled"`
+	// Создано рассылкой, публикацией группе или сегменту: запланированное
+	// уведомление меняется только с правом notification:broadcast
+	Broadcast bool `json:"broadcast,omitempty" db:"broadcast"`
+}
+
+// Приоритеты уведомления
+const (
+	PriorityLow    = "low"
+	PriorityNormal = "normal"
+	PriorityHigh   = "high"
+)
+
+// NotificationData - содержимое уведомления. Хранится в колонке notification
+// и в таком же виде отправляется в Centrifugo.
+type NotificationData struct {
+	Title    string `json:"title"`
+	Message  string `json:"message"`
+	Category string `json:"category"`
+	Priority string `json:"priority"`
+	Icon     string `json:"icon,omitempty"`
+	// Ссылка, которую клиент открывает по нажатию на уведомление
+	ActionURL string `json:"action_url,omitempty"`
+	Image     string `json:"image,omitempty"`
+	// Произвольные данные издателя
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// NotificationPreferences - настройки доставки уведомлений пользователя.
+// Уведомления сохраняются в истории всегда, настройки влияют только на отправку.
+type NotificationPreferences struct {
+	UserID int `json:"-" db:"user_id"`
+	// Категории, уведомления которых не отправляются
+	MutedCategories []string `json:"muted_categories" db:"muted_categories"`
+	// Тихие часы: отправка откладывается до их окончания
+	QuietHours *QuietHours `json:"quiet_hours" db:"quiet_hours"`
+	Timezone   string      `json:"timezone" db:"timezone"`
+	// Язык уведомлений из шаблонов, например ru или pt-BR
+	Locale    string           `json:"locale" db:"locale"`
+	Channels  DeliveryChannels `json:"channels" db:"channels"`
+	UpdatedAt *time.Time       `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// QuietHours - интервал в формате HH:MM по времени пользователя. Start позже
+// End означает интервал через полночь.
+type QuietHours struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+type DeliveryChannels struct {
+	Realtime bool `json:"realtime"`
+	Email    bool `json:"email"`
 }
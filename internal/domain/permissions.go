@@ -0,0 +1,27 @@
+package domain
+
+// Права доступа. Набор прав каждой роли хранится в таблице role_permissions.
+const (
+	PermNotificationPublish   = "notification:publish"
+	PermNotificationBroadcast = "notification:broadcast"
+	PermUserRead              = "user:read"
+	PermAPIKeyManage          = "apikey:manage"
+	PermUserInvite            = "user:invite"
+	PermUserManage            = "user:manage"
+	PermAudienceManage        = "audience:manage"
+	PermOrganizationManage    = "organization:manage"
+	PermTemplateManage        = "template:manage"
+)
+
+// Встроенные роли. Открытая регистрация создаёт только RoleUser, остальные
+// роли выдаются по приглашению или командой bootstrap. RoleSuperAdmin
+// управляет организациями и выдаётся только командой bootstrap.
+const (
+	RoleSuperAdmin = "superadmin"
+	RoleAdmin      = "admin"
+	RoleUser       = "user"
+)
+
+// DefaultOrgID - организация, в которую попадают пользователи открытой
+// регистрации и данные, созданные до появления организаций.
+const DefaultOrgID = 1
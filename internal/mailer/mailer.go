@@ -0,0 +1,32 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DANazavr/RATest/config"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer отправляет письма пользователям.
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// New выбирает реализацию по config.Mail.Driver: "smtp" или "file"
+// (по умолчанию, пишет письма в файл или stdout для локальной разработки).
+func New(c config.MailConfig) (Mailer, error) {
+	switch c.Driver {
+	case "", "file":
+		return NewFileMailer(c.From, c.Path)
+	case "smtp":
+		return NewSMTPMailer(c), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", c.Driver)
+	}
+}
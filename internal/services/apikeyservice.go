@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/store"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const (
+	apiKeyPrefix = "rak"
+	// Допустимое расхождение часов клиента при проверке подписи запроса
+	signatureMaxSkew = time.Minute * 5
+)
+
+type APIKeyService struct {
+	ctx    context.Context
+	logger *log.Log
+	store  store.Store
+}
+
+func NewAPIKeyService(ctx context.Context, logger *log.Log, store store.Store) *APIKeyService {
+	return &APIKeyService{
+		ctx:    ctx,
+		logger: logger.WithComponent("services/apikey"),
+		store:  store,
+	}
+}
+
+// ForOrg возвращает копию сервиса, которая выпускает и видит ключи
+// организации orgID.
+func (ks *APIKeyService) ForOrg(orgID int) *APIKeyService {
+	c := *ks
+	c.store = ks.store.Tenant(orgID)
+	return &c
+}
+
+// Create выпускает ключ вида rak_<prefix>_<secret>. Ключ целиком возвращается
+// только здесь, в базе хранится его SHA-256. Ключ получает только права,
+// которые есть у роли role его создателя.
+func (ks *APIKeyService) Create(k *models.APIKey, role string) (string, error) {
+	if err := ks.Validate(k); err != nil {
+		return "", err
+	}
+	if err := ks.checkGranted(k.Scopes, role); err != nil {
+		return "", err
+	}
+
+	prefix := make([]byte, 4)
+	secret := make([]byte, 32)
+	if _, err := rand.Read(prefix); err != nil {
+		return "", err
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	k.Prefix = hex.EncodeToString(prefix)
+	key := apiKeyPrefix + "_" + k.Prefix + "_" + hex.EncodeToString(secret)
+	k.KeyHash = hashToken(key)
+
+	if err := ks.store.APIKey().Create(k); err != nil {
+		ks.logger.Errorf(ks.ctx, "Failed to create API key %s: %v", k.Name, err)
+		return "", err
+	}
+	ks.logger.Infof(ks.ctx, "API key %d (%s) created with scopes %v", k.ID, k.Name, k.Scopes)
+	return key, nil
+}
+
+func (ks *APIKeyService) Get() ([]*models.APIKey, error) {
+	return ks.store.APIKey().Get()
+}
+
+func (ks *APIKeyService) Revoke(id int) error {
+	revoked, err := ks.store.APIKey().Revoke(id)
+	if err != nil {
+		ks.logger.Errorf(ks.ctx, "Failed to revoke API key %d: %v", id, err)
+		return err
+	}
+	if !revoked {
+		return domain.ErrAPIKeyNotFound
+	}
+	ks.logger.Infof(ks.ctx, "API key %d revoked", id)
+	return nil
+}
+
+// Authenticate находит действующий ключ по его значению и отмечает использование.
+func (ks *APIKeyService) Authenticate(key string) (*models.APIKey, error) {
+	parts := strings.Split(key, "_")
+	if len(parts) != 3 || parts[0] != apiKeyPrefix {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	k, err := ks.store.APIKey().GetByPrefix(parts[1])
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrInvalidAPIKey
+	} else if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(k.KeyHash), []byte(hashToken(key))) != 1 || k.RevokedAt != nil {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	if err := ks.store.APIKey().MarkUsed(k.ID); err != nil {
+		ks.logger.Warnf(ks.ctx, "Failed to update last use of API key %d: %v", k.ID, err)
+	}
+	return k, nil
+}
+
+func (ks *APIKeyService) HasScope(k *models.APIKey, permission string) bool {
+	for _, s := range k.Scopes {
+		if s == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// VerifySignature проверяет HMAC-SHA256 подпись запроса. Подписывается строка
+// "<timestamp>\n<payload>", где timestamp - unix-время в секундах, а ключом
+// HMAC служит сам API ключ.
+func (ks *APIKeyService) VerifySignature(key string, timestamp string, signature string, payload []byte) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return domain.ErrInvalidSignature
+	}
+	if skew := time.Since(time.Unix(ts, 0)); skew > signatureMaxSkew || skew < -signatureMaxSkew {
+		return domain.ErrInvalidSignature
+	}
+
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return domain.ErrInvalidSignature
+	}
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(timestamp + "\n"))
+	mac.Write(payload)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return domain.ErrInvalidSignature
+	}
+	return nil
+}
+
+func (ks *APIKeyService) Validate(k *models.APIKey) error {
+	return validation.ValidateStruct(k,
+		validation.Field(&k.Name, validation.Required, validation.Length(1, 100)),
+		validation.Field(&k.Scopes, validation.Required, validation.Each(validation.By(ks.validateScope))),
+	)
+}
+
+// checkGranted проверяет, что роль role обладает всеми правами scopes.
+// organization:manage открывает доступ ко всем организациям, поэтому его
+// получает только ключ суперадминистратора.
+func (ks *APIKeyService) checkGranted(scopes []string, role string) error {
+	granted, err := ks.store.Role().GetPermissions(role)
+	if err != nil {
+		return err
+	}
+	for _, s := range scopes {
+		if !slices.Contains(granted, s) || (s == domain.PermOrganizationManage && role != domain.RoleSuperAdmin) {
+			ks.logger.Warnf(ks.ctx, "Role %q cannot grant scope %s to an API key", role, s)
+			return domain.ErrScopeNotGranted
+		}
+	}
+	return nil
+}
+
+func (ks *APIKeyService) validateScope(value interface{}) error {
+	permission, _ := value.(string)
+	exists, err := ks.store.Role().PermissionExists(permission)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return domain.ErrUnknownPermission
+	}
+	return nil
+}
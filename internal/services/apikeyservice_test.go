@@ -0,0 +1,43 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/services"
+	"github.com/DANazavr/RATest/internal/store/sqlstore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyService_CreateScopes(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("api_keys")
+
+	logger := log.NewLog(t.Context(), &log.LogConfig{Component: "services", LogLevel: "debug"})
+	ks := services.NewAPIKeyService(t.Context(), logger, sqlstore.New(t.Context(), db, logger)).ForOrg(domain.DefaultOrgID)
+
+	testCases := []struct {
+		name   string
+		role   string
+		scopes []string
+		err    error
+	}{
+		{name: "granted", role: domain.RoleAdmin, scopes: []string{domain.PermNotificationPublish}},
+		{name: "not granted", role: "publisher", scopes: []string{domain.PermNotificationPublish, domain.PermUserManage}, err: domain.ErrScopeNotGranted},
+		{name: "tenant admin", role: domain.RoleAdmin, scopes: []string{domain.PermOrganizationManage}, err: domain.ErrScopeNotGranted},
+		{name: "superadmin", role: domain.RoleSuperAdmin, scopes: []string{domain.PermOrganizationManage}},
+		{name: "no role", role: "", scopes: []string{domain.PermNotificationPublish}, err: domain.ErrScopeNotGranted},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ks.Create(&models.APIKey{Name: tc.name, Scopes: tc.scopes}, tc.role)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/store"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// segmentPageSize - по сколько пользователей выбирается сегмент за запрос.
+const segmentPageSize = 500
+
+// AudienceService управляет группами и сегментами пользователей и выбирает
+// их участников для публикации уведомлений.
+type AudienceService struct {
+	ctx    context.Context
+	logger *log.Log
+	store  store.Store
+}
+
+func NewAudienceService(ctx context.Context, logger *log.Log, store store.Store) *AudienceService {
+	return &AudienceService{
+		ctx:    ctx,
+		logger: logger.WithComponent("services/audience"),
+		store:  store,
+	}
+}
+
+// ForOrg возвращает копию сервиса, которая видит только группы, сегменты и
+// пользователей организации orgID.
+func (as *AudienceService) ForOrg(orgID int) *AudienceService {
+	c := *as
+	c.store = as.store.Tenant(orgID)
+	return &c
+}
+
+func (as *AudienceService) CreateGroup(g *models.Group) error {
+	if err := validation.ValidateStruct(g,
+		validation.Field(&g.Name, validation.Required, validation.Length(1, 100)),
+	); err != nil {
+		return err
+	}
+	if err := as.store.Group().Create(g); err != nil {
+		return err
+	}
+	as.logger.Infof(as.ctx, "Group %d %q created", g.ID, g.Name)
+	return nil
+}
+
+func (as *AudienceService) Groups() ([]*models.Group, error) {
+	return as.store.Group().Get()
+}
+
+func (as *AudienceService) Group(id int) (*models.Group, error) {
+	g, err := as.store.Group().GetById(id)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrGroupNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (as *AudienceService) DeleteGroup(id int) error {
+	if err := as.store.Group().Delete(id); err == sql.ErrNoRows {
+		return domain.ErrGroupNotFound
+	} else if err != nil {
+		return err
+	}
+	as.logger.Infof(as.ctx, "Group %d deleted", id)
+	return nil
+}
+
+// AddGroupMembers добавляет пользователей в группу и возвращает, сколько из
+// них стали новыми участниками.
+func (as *AudienceService) AddGroupMembers(groupID int, userIDs []int) (int, error) {
+	if len(userIDs) == 0 {
+		return 0, domain.ErrInvalidUserID
+	}
+	n, err := as.store.Group().AddMembers(groupID, userIDs)
+	if err == sql.ErrNoRows {
+		return 0, domain.ErrGroupNotFound
+	} else if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+func (as *AudienceService) RemoveGroupMember(groupID, userID int) error {
+	if err := as.store.Group().RemoveMember(groupID, userID); err == sql.ErrNoRows {
+		return domain.ErrUserNotFound
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (as *AudienceService) GroupMembers(groupID int) ([]*models.User, error) {
+	if _, err := as.Group(groupID); err != nil {
+		return nil, err
+	}
+	return as.store.Group().Members(groupID)
+}
+
+func (as *AudienceService) CreateSegment(s *models.Segment) error {
+	if err := validation.ValidateStruct(s,
+		validation.Field(&s.Name, validation.Required, validation.Length(1, 100)),
+	); err != nil {
+		return err
+	}
+	if s.Rules.Role != "" {
+		exists, err := as.store.Role().Exists(s.Rules.Role)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return domain.ErrUnknownRole
+		}
+	}
+	if s.Rules.CreatedFrom != nil && s.Rules.CreatedTo != nil && !s.Rules.CreatedFrom.Before(*s.Rules.CreatedTo) {
+		return domain.ErrInvalidFilter
+	}
+	if err := as.store.Segment().Create(s); err != nil {
+		return err
+	}
+	as.logger.Infof(as.ctx, "Segment %d %q created", s.ID, s.Name)
+	return nil
+}
+
+func (as *AudienceService) Segments() ([]*models.Segment, error) {
+	return as.store.Segment().Get()
+}
+
+func (as *AudienceService) Segment(id int) (*models.Segment, error) {
+	s, err := as.store.Segment().GetById(id)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrSegmentNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (as *AudienceService) DeleteSegment(id int) error {
+	if err := as.store.Segment().Delete(id); err == sql.ErrNoRows {
+		return domain.ErrSegmentNotFound
+	} else if err != nil {
+		return err
+	}
+	as.logger.Infof(as.ctx, "Segment %d deleted", id)
+	return nil
+}
+
+// SegmentMembers выбирает незаблокированных пользователей, подходящих под
+// условия сегмента на текущий момент.
+func (as *AudienceService) SegmentMembers(id int) ([]*models.User, error) {
+	s, err := as.Segment(id)
+	if err != nil {
+		return nil, err
+	}
+	f := &models.UserFilter{
+		Role:          s.Rules.Role,
+		CreatedFrom:   s.Rules.CreatedFrom,
+		CreatedTo:     s.Rules.CreatedTo,
+		Search:        s.Rules.Search,
+		EmailVerified: s.Rules.EmailVerified,
+		Active:        true,
+		Limit:         segmentPageSize,
+	}
+	var users []*models.User
+	for {
+		page, err := as.store.User().List(f)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, page...)
+		if len(page) < segmentPageSize {
+			return users, nil
+		}
+		f.After = &models.UserCursor{ID: page[len(page)-1].ID}
+	}
+}
+
+// Recipients возвращает получателей публикации: незаблокированных участников
+// группы groupID или сегмента segmentID. Задан должен быть ровно один из них.
+func (as *AudienceService) Recipients(groupID, segmentID int) ([]*models.User, error) {
+	switch {
+	case groupID > 0 && segmentID == 0:
+		members, err := as.GroupMembers(groupID)
+		if err != nil {
+			return nil, err
+		}
+		users := make([]*models.User, 0, len(members))
+		for _, u := range members {
+			if u.DisabledAt == nil {
+				users = append(users, u)
+			}
+		}
+		return users, nil
+	case segmentID > 0 && groupID == 0:
+		return as.SegmentMembers(segmentID)
+	}
+	return nil, domain.ErrInvalidAudience
+}
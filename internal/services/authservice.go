@@ -2,72 +2,354 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
 	"fmt"
-	"os"
+	"strconv"
 	"time"
 
 	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
 	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/store"
 	"github.com/golang-jwt/jwt/v4"
 )
 
+const (
+	accessTokenTTL     = time.Minute * 15
+	refreshTokenTTL    = time.Hour * 24 * 30 // 30 days
+	centrifugoTokenTTL = time.Minute * 10
+	inviteTokenTTL     = time.Hour * 24 * 7
+	mfaTokenTTL        = time.Minute * 5
+)
+
+// CentrifugoTokenAudience - aud токенов Centrifugo. Centrifugo настраивается
+// с token_audience = "centrifugo" и не принимает токены API, подписанные тем
+// же ключом.
+const CentrifugoTokenAudience = "centrifugo"
+
 type AuthService struct {
 	ctx    context.Context
 	logger *log.Log
+	store  store.Store
+	keys   *KeyRing
 }
 
-func NewAuthService(ctx context.Context, logger *log.Log) *AuthService {
+func NewAuthService(ctx context.Context, logger *log.Log, store store.Store, keys *KeyRing) *AuthService {
 	return &AuthService{
 		ctx:    ctx,
 		logger: logger.WithComponent("authservice"),
+		store:  store,
+		keys:   keys,
 	}
 }
 
-func (am *AuthService) GenerateAccessToken(userID int, role string) (string, error) {
+// GenerateAccessToken подписывает access токен пользователя u, привязанный к
+// семейству сессий familyID: после выхода из этой сессии токен перестаёт
+// приниматься. Токен несёт роль и организацию пользователя.
+func (am *AuthService) GenerateAccessToken(u *models.User, familyID string) (string, error) {
 	claims := jwt.MapClaims{
-		"sub":  fmt.Sprintf("%d", userID),
-		"exp":  jwt.NewNumericDate(time.Now().Add(time.Minute * 15)),
+		"sub":  fmt.Sprintf("%d", u.ID),
+		"exp":  jwt.NewNumericDate(time.Now().Add(accessTokenTTL)),
 		"iat":  jwt.NewNumericDate(time.Now()),
-		"role": role,
+		"sid":  familyID,
+		"role": u.Role,
+		"org":  u.OrgID,
 		"type": "access",
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(os.Getenv("SECRET")))
+	return am.keys.Sign(claims)
 }
 
-func (am *AuthService) GenerateRefreshToken(userID int, role string) (string, error) {
+// GenerateRefreshToken подписывает refresh токен для семейства familyID и
+// возвращает сессию, которую нужно сохранить вместе с ним.
+func (am *AuthService) GenerateRefreshToken(u *models.User, familyID string) (string, *models.Session, error) {
+	id, err := newTokenID()
+	if err != nil {
+		return "", nil, err
+	}
+	s := &models.Session{
+		ID:        id,
+		FamilyID:  familyID,
+		UserID:    u.ID,
+		ExpiresAt: time.Now().Add(refreshTokenTTL),
+	}
 	claims := jwt.MapClaims{
-		"sub":  fmt.Sprintf("%d", userID),
-		"exp":  jwt.NewNumericDate(time.Now().Add(time.Hour * 24 * 30)), // 30 days
+		"sub":  fmt.Sprintf("%d", u.ID),
+		"exp":  jwt.NewNumericDate(s.ExpiresAt),
 		"iat":  jwt.NewNumericDate(time.Now()),
-		"role": role,
+		"jti":  s.ID,
+		"sid":  s.FamilyID,
+		"role": u.Role,
+		"org":  u.OrgID,
 		"type": "refresh",
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(os.Getenv("SECRET")))
+	signed, err := am.keys.Sign(claims)
+	if err != nil {
+		return "", nil, err
+	}
+	s.TokenHash = hashToken(signed)
+	return signed, s, nil
 }
 
-func (am *AuthService) GenerateTokens(userID int, role string) (string, string, error) {
-	accessToken, err := am.GenerateAccessToken(userID, role)
+// GenerateTokens выдаёт пару токенов и открывает новое семейство refresh сессий.
+func (am *AuthService) GenerateTokens(u *models.User) (string, string, error) {
+	familyID, err := newTokenID()
 	if err != nil {
 		return "", "", err
 	}
-	refreshToken, err := am.GenerateRefreshToken(userID, role)
+	accessToken, err := am.GenerateAccessToken(u, familyID)
 	if err != nil {
 		return "", "", err
 	}
+	refreshToken, session, err := am.GenerateRefreshToken(u, familyID)
+	if err != nil {
+		return "", "", err
+	}
+	if err := am.store.Session().Create(session); err != nil {
+		am.logger.Errorf(am.ctx, "Failed to create session for user %d: %v", u.ID, err)
+		return "", "", err
+	}
 	return accessToken, refreshToken, nil
 }
 
-func (am *AuthService) ParseToken(tokenString string) (*jwt.Token, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, domain.ErrInvalidSigningMethod
+// ValidateRefreshToken проверяет refresh токен по сохранённой сессии.
+// Повторное предъявление уже ротированного токена отзывает всё семейство.
+func (am *AuthService) ValidateRefreshToken(tokenString string) (*models.Session, error) {
+	token, err := am.ParseToken(tokenString)
+	if err != nil {
+		return nil, domain.ErrInvalidToken
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, domain.ErrInvalidToken
+	}
+	if tokenType, ok := claims["type"].(string); !ok || tokenType != "refresh" {
+		return nil, domain.ErrInvalidToken
+	}
+	jti, ok := claims["jti"].(string)
+	if !ok || jti == "" {
+		return nil, domain.ErrInvalidToken
+	}
+
+	s, err := am.store.Session().GetById(jti)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrInvalidToken
+	} else if err != nil {
+		return nil, err
+	}
+	if s.TokenHash != hashToken(tokenString) {
+		return nil, domain.ErrInvalidToken
+	}
+	if s.RevokedAt != nil {
+		return nil, domain.ErrSessionRevoked
+	}
+	if s.RotatedAt != nil {
+		am.revokeReusedFamily(s)
+		return nil, domain.ErrRefreshTokenReused
+	}
+	return s, nil
+}
+
+// RotateTokens выдаёт пользователю u новую пару токенов взамен сессии s в том
+// же семействе.
+func (am *AuthService) RotateTokens(s *models.Session, u *models.User) (string, string, error) {
+	accessToken, err := am.GenerateAccessToken(u, s.FamilyID)
+	if err != nil {
+		return "", "", err
+	}
+	refreshToken, next, err := am.GenerateRefreshToken(u, s.FamilyID)
+	if err != nil {
+		return "", "", err
+	}
+	if err := am.store.Session().Rotate(s.ID, next); err != nil {
+		if errors.Is(err, domain.ErrRefreshTokenReused) {
+			am.revokeReusedFamily(s)
 		}
-		return []byte(os.Getenv("SECRET")), nil
-	})
+		return "", "", err
+	}
+	return accessToken, refreshToken, nil
+}
+
+// ValidateAccessToken разбирает access токен и проверяет, что его сессия не
+// отозвана, а пользователь не заблокирован.
+func (am *AuthService) ValidateAccessToken(tokenString string) (jwt.MapClaims, error) {
+	token, err := am.ParseToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, domain.ErrInvalidToken
+	}
+	if tokenType, ok := claims["type"].(string); !ok || tokenType != "access" {
+		return nil, domain.ErrInvalidToken
+	}
+	familyID, ok := claims["sid"].(string)
+	if !ok || familyID == "" {
+		return nil, domain.ErrInvalidToken
+	}
+	// Токены, выданные до появления организаций, не принимаются
+	if TokenOrgID(claims) <= 0 {
+		return nil, domain.ErrInvalidToken
+	}
+	active, err := am.store.Session().IsFamilyActive(familyID)
+	if err != nil {
+		am.logger.Errorf(am.ctx, "Failed to check session %s: %v", familyID, err)
+		return nil, err
+	}
+	if !active {
+		return nil, domain.ErrSessionRevoked
+	}
+	return claims, nil
+}
+
+// TokenOrgID возвращает организацию владельца токена из его claims.
+func TokenOrgID(claims jwt.MapClaims) int {
+	org, _ := claims["org"].(float64)
+	return int(org)
+}
+
+// Logout отзывает семейство сессий familyID вместе с его access токенами.
+func (am *AuthService) Logout(familyID string) error {
+	if err := am.store.Session().RevokeFamily(familyID); err != nil {
+		am.logger.Errorf(am.ctx, "Failed to revoke session family %s: %v", familyID, err)
+		return err
+	}
+	return nil
+}
+
+// LogoutAll отзывает все сессии пользователя.
+func (am *AuthService) LogoutAll(userID int) error {
+	if err := am.store.Session().RevokeByUserId(userID); err != nil {
+		am.logger.Errorf(am.ctx, "Failed to revoke sessions of user %d: %v", userID, err)
+		return err
+	}
+	return nil
+}
+
+func (am *AuthService) revokeReusedFamily(s *models.Session) {
+	am.logger.Warnf(am.ctx, "Refresh token reuse detected for user %d, revoking session family %s", s.UserID, s.FamilyID)
+	if err := am.store.Session().RevokeFamily(s.FamilyID); err != nil {
+		am.logger.Errorf(am.ctx, "Failed to revoke session family %s: %v", s.FamilyID, err)
+	}
+}
+
+func (am *AuthService) ParseToken(tokenString string) (*jwt.Token, error) {
+	token, err := jwt.Parse(tokenString, am.keys.Keyfunc, jwt.WithValidMethods(am.keys.Methods()))
 	if err != nil || !token.Valid {
 		return nil, err
 	}
 	return token, nil
 }
+
+// GenerateCentrifugoConnectionToken выдаёт токен подключения к Centrifugo.
+// Centrifugo проверяет его по открытым ключам из JWKS.
+func (am *AuthService) GenerateCentrifugoConnectionToken(userID int) (string, error) {
+	claims := jwt.MapClaims{
+		"sub": fmt.Sprintf("%d", userID),
+		"aud": CentrifugoTokenAudience,
+		"exp": jwt.NewNumericDate(time.Now().Add(centrifugoTokenTTL)),
+		"iat": jwt.NewNumericDate(time.Now()),
+	}
+	return am.keys.Sign(claims)
+}
+
+// GenerateCentrifugoSubscriptionToken выдаёт токен подписки на канал channel.
+// Право на чтение канала проверяет вызывающий код.
+func (am *AuthService) GenerateCentrifugoSubscriptionToken(userID int, channel string) (string, error) {
+	claims := jwt.MapClaims{
+		"sub":     fmt.Sprintf("%d", userID),
+		"channel": channel,
+		"aud":     CentrifugoTokenAudience,
+		"exp":     jwt.NewNumericDate(time.Now().Add(centrifugoTokenTTL)),
+		"iat":     jwt.NewNumericDate(time.Now()),
+	}
+	return am.keys.Sign(claims)
+}
+
+// GenerateInviteToken подписывает токен приглашения i. Сам токен не хранится:
+// по jti находится запись приглашения, которая и определяет роль.
+func (am *AuthService) GenerateInviteToken(i *models.Invite) (string, error) {
+	claims := jwt.MapClaims{
+		"jti":  i.ID,
+		"role": i.Role,
+		"exp":  jwt.NewNumericDate(i.ExpiresAt),
+		"iat":  jwt.NewNumericDate(time.Now()),
+		"type": "invite",
+	}
+	return am.keys.Sign(claims)
+}
+
+// ValidateInviteToken проверяет подпись токена приглашения и возвращает его jti.
+func (am *AuthService) ValidateInviteToken(tokenString string) (string, error) {
+	token, err := am.ParseToken(tokenString)
+	if err != nil {
+		return "", domain.ErrInvalidInvite
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return "", domain.ErrInvalidInvite
+	}
+	if tokenType, ok := claims["type"].(string); !ok || tokenType != "invite" {
+		return "", domain.ErrInvalidInvite
+	}
+	jti, ok := claims["jti"].(string)
+	if !ok || jti == "" {
+		return "", domain.ErrInvalidInvite
+	}
+	return jti, nil
+}
+
+// GenerateMFAToken подписывает короткоживущий токен для второго шага входа:
+// пароль уже проверен, осталось предъявить код второго фактора.
+func (am *AuthService) GenerateMFAToken(userID int) (string, error) {
+	claims := jwt.MapClaims{
+		"sub":  fmt.Sprintf("%d", userID),
+		"exp":  jwt.NewNumericDate(time.Now().Add(mfaTokenTTL)),
+		"iat":  jwt.NewNumericDate(time.Now()),
+		"type": "mfa",
+	}
+	return am.keys.Sign(claims)
+}
+
+// ValidateMFAToken проверяет токен второго шага входа и возвращает id пользователя.
+func (am *AuthService) ValidateMFAToken(tokenString string) (int, error) {
+	token, err := am.ParseToken(tokenString)
+	if err != nil {
+		return 0, domain.ErrInvalidMFAToken
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return 0, domain.ErrInvalidMFAToken
+	}
+	if tokenType, ok := claims["type"].(string); !ok || tokenType != "mfa" {
+		return 0, domain.ErrInvalidMFAToken
+	}
+	sub, _ := claims["sub"].(string)
+	userID, err := strconv.Atoi(sub)
+	if err != nil {
+		return 0, domain.ErrInvalidMFAToken
+	}
+	return userID, nil
+}
+
+// JWKS возвращает открытые ключи для проверки выданных токенов.
+func (am *AuthService) JWKS() JWKSet {
+	return am.keys.JWKS()
+}
+
+func newTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,41 @@
+package services_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/services"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthService_CentrifugoTokenAudience(t *testing.T) {
+	dir := t.TempDir()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	writeKey(t, dir, "key", key)
+	kr, err := services.LoadKeyRing(dir, "key")
+	require.NoError(t, err)
+	as := services.NewAuthService(t.Context(), log.NewLog(t.Context(), &log.LogConfig{Component: "services", LogLevel: "debug"}), nil, kr)
+
+	connection, err := as.GenerateCentrifugoConnectionToken(1)
+	require.NoError(t, err)
+	subscription, err := as.GenerateCentrifugoSubscriptionToken(1, "notifications:org1.user#1")
+	require.NoError(t, err)
+	for _, token := range []string{connection, subscription} {
+		parsed, err := as.ParseToken(token)
+		require.NoError(t, err)
+		assert.True(t, parsed.Claims.(jwt.MapClaims).VerifyAudience(services.CentrifugoTokenAudience, true))
+	}
+
+	// Токены API audience Centrifugo не содержат
+	access, err := as.GenerateAccessToken(&models.User{ID: 1, Role: "user", OrgID: 1}, "family")
+	require.NoError(t, err)
+	parsed, err := as.ParseToken(access)
+	require.NoError(t, err)
+	assert.False(t, parsed.Claims.(jwt.MapClaims).VerifyAudience(services.CentrifugoTokenAudience, true))
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/store"
+)
+
+const (
+	// dataExportTTL - сколько хранится готовый архив или ошибка выгрузки.
+	dataExportTTL = 7 * 24 * time.Hour
+	// dataExportBatchSize - сколько выгрузок забирается за один запрос.
+	dataExportBatchSize = 10
+	// dataExportLease - через сколько незавершённая выгрузка считается
+	// брошенной упавшим обработчиком и собирается заново.
+	dataExportLease = 10 * time.Minute
+)
+
+// DataExportService собирает выгрузки профиля и истории уведомлений
+// пользователей. Задание ставится в очередь запросом, архив собирается
+// фоновой задачей Run.
+type DataExportService struct {
+	ctx    context.Context
+	logger *log.Log
+	store  store.Store
+}
+
+func NewDataExportService(ctx context.Context, logger *log.Log, store store.Store) *DataExportService {
+	return &DataExportService{
+		ctx:    ctx,
+		logger: logger.WithComponent("services/dataexport"),
+		store:  store,
+	}
+}
+
+// ForOrg возвращает копию сервиса, которая видит только выгрузки
+// пользователей организации orgID.
+func (ds *DataExportService) ForOrg(orgID int) *DataExportService {
+	c := *ds
+	c.store = ds.store.Tenant(orgID)
+	return &c
+}
+
+// Request ставит в очередь выгрузку данных пользователя userID. Если выгрузка
+// этого пользователя уже ждёт обработки, новая не создаётся и возвращается она.
+// Брошенная выгрузка в статусе running не мешает: Run соберёт её заново.
+func (ds *DataExportService) Request(ctx context.Context, userID int, requestedBy *int) (*models.DataExport, error) {
+	u, err := ds.store.User().GetById(userID)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrUserNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	exports, err := ds.store.DataExport().GetByUserId(u.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range exports {
+		if e.Status == models.DataExportPending || e.Status == models.DataExportRunning {
+			return e, nil
+		}
+	}
+
+	e := &models.DataExport{
+		UserID:      u.ID,
+		OrgID:       u.OrgID,
+		RequestedBy: requestedBy,
+	}
+	if err := ds.store.DataExport().Create(e); err != nil {
+		return nil, err
+	}
+	ds.logger.Infof(ctx, "Data export %d of user %d requested", e.ID, u.ID)
+	return e, nil
+}
+
+func (ds *DataExportService) Exports(userID int) ([]*models.DataExport, error) {
+	return ds.store.DataExport().GetByUserId(userID)
+}
+
+func (ds *DataExportService) Export(id int) (*models.DataExport, error) {
+	e, err := ds.store.DataExport().GetById(id)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrDataExportNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Archive возвращает ZIP архив готовой выгрузки id.
+func (ds *DataExportService) Archive(id int) ([]byte, error) {
+	archive, err := ds.store.DataExport().Archive(id)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrDataExportNotReady
+	} else if err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+// Run раз в interval собирает ожидающие выгрузки и удаляет истёкшие, пока
+// ctx не будет отменён.
+func (ds *DataExportService) Run(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			ds.processPending()
+			if n, err := ds.store.DataExport().DeleteExpired(); err != nil {
+				ds.logger.Errorf(ds.ctx, "Failed to delete expired data exports: %v", err)
+			} else if n > 0 {
+				ds.logger.Infof(ds.ctx, "%d expired data exports deleted", n)
+			}
+		}
+	}
+}
+
+func (ds *DataExportService) processPending() {
+	for {
+		pending, err := ds.store.DataExport().ClaimPending(dataExportBatchSize, dataExportLease)
+		if err != nil {
+			ds.logger.Errorf(ds.ctx, "Failed to load pending data exports: %v", err)
+			return
+		}
+		for _, e := range pending {
+			if err := ds.build(e); err != nil {
+				ds.logger.Errorf(ds.ctx, "Failed to build data export %d: %v", e.ID, err)
+				if err := ds.store.DataExport().Fail(e.ID, err.Error(), time.Now().Add(dataExportTTL)); err != nil {
+					ds.logger.Errorf(ds.ctx, "Failed to mark data export %d as failed: %v", e.ID, err)
+				}
+			}
+		}
+		if len(pending) < dataExportBatchSize {
+			return
+		}
+	}
+}
+
+func (ds *DataExportService) build(e *models.DataExport) error {
+	u, err := ds.store.User().GetById(e.UserID)
+	if err != nil {
+		return err
+	}
+	notifications, err := ds.store.Notification().GetByUserId(e.UserID)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := WriteDataExport(&buf, u, notifications); err != nil {
+		return err
+	}
+	if err := ds.store.DataExport().Complete(e.ID, buf.Bytes(), time.Now().Add(dataExportTTL)); err != nil {
+		return err
+	}
+	ds.logger.Infof(ds.ctx, "Data export %d of user %d is ready, %d notifications", e.ID, e.UserID, len(notifications))
+	return nil
+}
+
+// WriteDataExport пишет в w ZIP архив с профилем u в profile.json и его
+// уведомлениями в notifications.jsonl, по одному JSON объекту на строку.
+func WriteDataExport(w io.Writer, u *models.User, notifications []*models.UserNotification) error {
+	zw := zip.NewWriter(w)
+
+	f, err := zw.Create("profile.json")
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(u); err != nil {
+		return err
+	}
+
+	f, err = zw.Create("notifications.jsonl")
+	if err != nil {
+		return err
+	}
+	enc = json.NewEncoder(f)
+	for _, n := range notifications {
+		if err := enc.Encode(n); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
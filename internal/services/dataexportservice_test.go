@@ -0,0 +1,52 @@
+package services_test
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteDataExport(t *testing.T) {
+	u := &models.User{ID: 7, Username: "user", Email: "user@example.org", EncryptedPassword: "hash", Role: "user"}
+	notifications := []*models.UserNotification{
+		{UID: 1, UserID: 7, Notification: models.NotificationData{Title: "first"}},
+		{UID: 2, UserID: 7, Notification: models.NotificationData{Title: "second"}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, services.WriteDataExport(&buf, u, notifications))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	files := map[string][]byte{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		files[f.Name], err = io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+	}
+
+	profile := &models.User{}
+	require.NoError(t, json.Unmarshal(files["profile.json"], profile))
+	assert.Equal(t, u.ID, profile.ID)
+	assert.Equal(t, u.Email, profile.Email)
+	assert.NotContains(t, string(files["profile.json"]), "hash")
+
+	var uids []int
+	sc := bufio.NewScanner(bytes.NewReader(files["notifications.jsonl"]))
+	for sc.Scan() {
+		n := &models.UserNotification{}
+		require.NoError(t, json.Unmarshal(sc.Bytes(), n))
+		uids = append(uids, n.UID)
+	}
+	assert.Equal(t, []int{1, 2}, uids)
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/mailer"
+	"github.com/DANazavr/RATest/internal/store"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+)
+
+const (
+	emailVerificationTTL = time.Hour * 48
+	// Не чаще одного письма за это время на запрос по email
+	emailVerificationResendInterval = time.Minute
+
+	// Режимы config.EmailVerification
+	EmailVerificationLogin         = "login"
+	EmailVerificationNotifications = "notifications"
+)
+
+type EmailVerificationService struct {
+	ctx       context.Context
+	logger    *log.Log
+	store     store.Store
+	mailer    mailer.Mailer
+	publicURL string
+	mode      string
+}
+
+func NewEmailVerificationService(ctx context.Context, logger *log.Log, store store.Store, mailer mailer.Mailer, publicURL string, mode string) *EmailVerificationService {
+	return &EmailVerificationService{
+		ctx:       ctx,
+		logger:    logger.WithComponent("services/emailverification"),
+		store:     store,
+		mailer:    mailer,
+		publicURL: publicURL,
+		mode:      mode,
+	}
+}
+
+// Send отправляет пользователю ссылку для подтверждения текущего email.
+func (vs *EmailVerificationService) Send(ctx context.Context, u *models.User) error {
+	if u.EmailVerifiedAt != nil {
+		return domain.ErrEmailAlreadyVerified
+	}
+	return vs.send(ctx, u, u.Email)
+}
+
+// ResendByEmail повторно отправляет ссылку подтверждения по адресу email без
+// входа в систему. Для неизвестного или уже подтверждённого адреса, а также
+// если письмо уже отправлялось недавно, ничего не делает и не возвращает
+// ошибку, чтобы не раскрывать, зарегистрирован ли адрес.
+func (vs *EmailVerificationService) ResendByEmail(ctx context.Context, email string) error {
+	u, err := vs.store.User().GetByEmail(email)
+	if err == sql.ErrNoRows {
+		vs.logger.Infof(ctx, "Verification email requested for unknown email")
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if u.EmailVerifiedAt != nil {
+		return nil
+	}
+	sent, err := vs.store.EmailVerification().SentSince(u.ID, emailVerificationResendInterval)
+	if err != nil {
+		return err
+	}
+	if sent {
+		vs.logger.Infof(ctx, "Verification email for user %d already sent recently", u.ID)
+		return nil
+	}
+	// send сам логирует ошибку
+	_ = vs.send(ctx, u, u.Email)
+	return nil
+}
+
+// RequestChange отправляет ссылку подтверждения на новый адрес email. Адрес
+// пользователя меняется только после перехода по ссылке.
+func (vs *EmailVerificationService) RequestChange(ctx context.Context, u *models.User, email string) error {
+	if err := validation.Validate(email, validation.Required, is.Email); err != nil {
+		return err
+	}
+	if strings.EqualFold(email, u.Email) {
+		return vs.Send(ctx, u)
+	}
+	if _, err := vs.store.User().GetByEmail(email); err == nil {
+		return domain.ErrEmailTaken
+	} else if err != sql.ErrNoRows {
+		return err
+	}
+	return vs.send(ctx, u, email)
+}
+
+func (vs *EmailVerificationService) send(ctx context.Context, u *models.User, email string) error {
+	token, err := newTokenID()
+	if err != nil {
+		return err
+	}
+	v := &models.EmailVerification{
+		UserID:    u.ID,
+		Email:     email,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(emailVerificationTTL),
+	}
+	if err := vs.store.EmailVerification().Create(v); err != nil {
+		vs.logger.Errorf(ctx, "Failed to create email verification for user %d: %v", u.ID, err)
+		return err
+	}
+
+	link := vs.publicURL + "/email/confirm?token=" + url.QueryEscape(token)
+	if err := vs.mailer.Send(ctx, &mailer.Message{
+		To:      email,
+		Subject: "Confirm your email",
+		Body: fmt.Sprintf("Hello, %s!\n\nTo confirm your email address, follow the link:\n%s\n\nThe link is valid for %s.",
+			u.Username, link, emailVerificationTTL),
+	}); err != nil {
+		vs.logger.Errorf(ctx, "Failed to send verification email to user %d: %v", u.ID, err)
+		return err
+	}
+	vs.logger.Infof(ctx, "Verification email sent to user %d", u.ID)
+	return nil
+}
+
+func (vs *EmailVerificationService) Confirm(ctx context.Context, token string) error {
+	userID, err := vs.store.EmailVerification().Confirm(hashToken(token))
+	if err != nil {
+		return err
+	}
+	vs.logger.Infof(ctx, "Email of user %d verified", userID)
+	return nil
+}
+
+// CheckLogin возвращает domain.ErrEmailNotVerified, если вход с
+// неподтверждённым email запрещён настройками.
+func (vs *EmailVerificationService) CheckLogin(u *models.User) error {
+	if vs.mode == EmailVerificationLogin && u.EmailVerifiedAt == nil {
+		return domain.ErrEmailNotVerified
+	}
+	return nil
+}
+
+// CanDeliver сообщает, можно ли доставлять пользователю уведомления через Centrifugo.
+func (vs *EmailVerificationService) CanDeliver(u *models.User) bool {
+	return vs.mode != EmailVerificationNotifications || u.EmailVerifiedAt != nil
+}
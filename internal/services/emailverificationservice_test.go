@@ -0,0 +1,52 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/mailer"
+	"github.com/DANazavr/RATest/internal/services"
+	"github.com/DANazavr/RATest/internal/store/sqlstore"
+	"github.com/stretchr/testify/assert"
+)
+
+type countingMailer struct {
+	sent int
+}
+
+func (m *countingMailer) Send(context.Context, *mailer.Message) error {
+	m.sent++
+	return nil
+}
+
+func TestEmailVerificationService_ResendByEmail(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("email_verifications", "users")
+
+	logger := log.NewLog(t.Context(), &log.LogConfig{Component: "services", LogLevel: "debug"})
+	s := sqlstore.New(t.Context(), db, logger)
+	m := &countingMailer{}
+	vs := services.NewEmailVerificationService(t.Context(), logger, s, m, "http://localhost", services.EmailVerificationLogin)
+
+	u := &models.User{
+		Username:          "testuser",
+		EncryptedPassword: "encrypted_password",
+		Email:             "email@example.com",
+		Role:              "user",
+		OrgID:             domain.DefaultOrgID,
+	}
+	assert.NoError(t, s.User().Create(u))
+
+	assert.NoError(t, vs.ResendByEmail(t.Context(), "unknown@example.com"))
+	assert.Equal(t, 0, m.sent)
+
+	assert.NoError(t, vs.ResendByEmail(t.Context(), "email@example.com"))
+	assert.Equal(t, 1, m.sent)
+
+	// Повторный запрос сразу после первого не отправляет письмо
+	assert.NoError(t, vs.ResendByEmail(t.Context(), "email@example.com"))
+	assert.Equal(t, 1, m.sent)
+}
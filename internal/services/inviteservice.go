@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/store"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+)
+
+type InviteService struct {
+	ctx         context.Context
+	logger      *log.Log
+	store       store.Store
+	authService *AuthService
+}
+
+func NewInviteService(ctx context.Context, logger *log.Log, store store.Store, as *AuthService) *InviteService {
+	return &InviteService{
+		ctx:         ctx,
+		logger:      logger.WithComponent("services/invite"),
+		store:       store,
+		authService: as,
+	}
+}
+
+// ForOrg возвращает копию сервиса, которая создаёт и видит приглашения
+// организации orgID.
+func (vs *InviteService) ForOrg(orgID int) *InviteService {
+	c := *vs
+	c.store = vs.store.Tenant(orgID)
+	return &c
+}
+
+// Create сохраняет приглашение и возвращает подписанный токен для него.
+func (vs *InviteService) Create(i *models.Invite) (string, error) {
+	if err := vs.Validate(i); err != nil {
+		return "", err
+	}
+	id, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
+	i.ID = id
+	i.ExpiresAt = time.Now().Add(inviteTokenTTL)
+
+	if err := vs.store.Invite().Create(i); err != nil {
+		vs.logger.Errorf(vs.ctx, "Failed to create invite for role %s: %v", i.Role, err)
+		return "", err
+	}
+	token, err := vs.authService.GenerateInviteToken(i)
+	if err != nil {
+		return "", err
+	}
+	vs.logger.Infof(vs.ctx, "Invite %s for role %s created", i.ID, i.Role)
+	return token, nil
+}
+
+func (vs *InviteService) Get() ([]*models.Invite, error) {
+	return vs.store.Invite().Get()
+}
+
+// Resolve находит неиспользованное приглашение по его токену.
+func (vs *InviteService) Resolve(token string) (*models.Invite, error) {
+	id, err := vs.authService.ValidateInviteToken(token)
+	if err != nil {
+		return nil, err
+	}
+	i, err := vs.store.Invite().GetById(id)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrInvalidInvite
+	} else if err != nil {
+		return nil, err
+	}
+	if i.UsedAt != nil || time.Now().After(i.ExpiresAt) {
+		return nil, domain.ErrInvalidInvite
+	}
+	return i, nil
+}
+
+func (vs *InviteService) Validate(i *models.Invite) error {
+	return validation.ValidateStruct(i,
+		validation.Field(&i.Role, validation.Required, validation.By(vs.validateRole)),
+		validation.Field(&i.Email, is.Email),
+	)
+}
+
+func (vs *InviteService) validateRole(value interface{}) error {
+	role, _ := value.(string)
+	if role == domain.RoleSuperAdmin {
+		return domain.ErrSuperAdminRole
+	}
+	exists, err := vs.store.Role().Exists(role)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return domain.ErrUnknownRole
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package services_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DANazavr/RATest/internal/services"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeKey(t *testing.T, dir, kid string, key interface{}) {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, kid+".pem"), data, 0o600))
+}
+
+func TestKeyRing_Rotation(t *testing.T) {
+	dir := t.TempDir()
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	writeKey(t, dir, "old", edKey)
+
+	kr, err := services.LoadKeyRing(dir, "old")
+	require.NoError(t, err)
+	oldToken, err := kr.Sign(jwt.MapClaims{"sub": "1"})
+	require.NoError(t, err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	writeKey(t, dir, "new", rsaKey)
+
+	kr, err = services.LoadKeyRing(dir, "new")
+	require.NoError(t, err)
+	newToken, err := kr.Sign(jwt.MapClaims{"sub": "1"})
+	require.NoError(t, err)
+
+	// Токены, подписанные прежним ключом, продолжают проверяться
+	for _, token := range []string{oldToken, newToken} {
+		parsed, err := jwt.Parse(token, kr.Keyfunc, jwt.WithValidMethods(kr.Methods()))
+		assert.NoError(t, err)
+		assert.True(t, parsed.Valid)
+	}
+
+	jwks := kr.JWKS()
+	assert.Len(t, jwks.Keys, 2)
+	assert.Equal(t, "new", jwks.Keys[0].Kid)
+	assert.Equal(t, "RSA", jwks.Keys[0].Kty)
+	assert.Equal(t, "OKP", jwks.Keys[1].Kty)
+}
+
+func TestKeyRing_MissingActiveKey(t *testing.T) {
+	_, err := services.LoadKeyRing(t.TempDir(), "main")
+	assert.Error(t, err)
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/store"
+)
+
+// Ограничения перебора паролей. После free неудачных попыток каждая следующая
+// удваивает паузу, начиная с loginBackoffBase; после lockout попыток вход
+// блокируется на loginLockout с момента последней неудачи.
+const (
+	loginAttemptWindow      = time.Minute * 15
+	loginBackoffBase        = time.Second
+	loginLockout            = time.Minute * 15
+	usernameFreeAttempts    = 3
+	usernameLockoutAttempts = 10
+	ipFreeAttempts          = 20
+	ipLockoutAttempts       = 100
+)
+
+type LoginThrottleService struct {
+	ctx    context.Context
+	logger *log.Log
+	store  store.Store
+}
+
+func NewLoginThrottleService(ctx context.Context, logger *log.Log, store store.Store) *LoginThrottleService {
+	return &LoginThrottleService{
+		ctx:    ctx,
+		logger: logger.WithComponent("services/loginthrottle"),
+		store:  store,
+	}
+}
+
+// Check возвращает domain.ErrTooManyLoginAttempts и время до следующей
+// разрешённой попытки, если для username или ip действует пауза.
+func (ls *LoginThrottleService) Check(username string, ip string) (time.Duration, error) {
+	n, elapsed, err := ls.store.LoginAttempt().FailuresByUsername(username, loginAttemptWindow)
+	if err != nil {
+		ls.logger.Errorf(ls.ctx, "Failed to count login failures for %s: %v", username, err)
+		return 0, err
+	}
+	retry := retryAfter(n, elapsed, usernameFreeAttempts, usernameLockoutAttempts)
+
+	n, elapsed, err = ls.store.LoginAttempt().FailuresByIP(ip, loginAttemptWindow)
+	if err != nil {
+		ls.logger.Errorf(ls.ctx, "Failed to count login failures for %s: %v", ip, err)
+		return 0, err
+	}
+	if r := retryAfter(n, elapsed, ipFreeAttempts, ipLockoutAttempts); r > retry {
+		retry = r
+	}
+
+	if retry > 0 {
+		return retry, domain.ErrTooManyLoginAttempts
+	}
+	return 0, nil
+}
+
+// Record сохраняет попытку входа в журнал. Ошибка журнала вход не прерывает.
+func (ls *LoginThrottleService) Record(a *models.LoginAttempt) {
+	a.Success = a.Reason == models.LoginReasonSuccess
+	if len(a.UserAgent) > 255 {
+		a.UserAgent = a.UserAgent[:255]
+	}
+	if err := ls.store.LoginAttempt().Create(a); err != nil {
+		ls.logger.Errorf(ls.ctx, "Failed to record login attempt for %s from %s: %v", a.Username, a.IP, err)
+		return
+	}
+	if a.Reason == models.LoginReasonInvalidCredentials {
+		ls.logger.Warnf(ls.ctx, "Failed login for %s from %s", a.Username, a.IP)
+	}
+}
+
+// retryAfter возвращает паузу до следующей попытки, если с последней неудачи
+// прошло elapsed.
+func retryAfter(failures int, elapsed *time.Duration, free int, lockout int) time.Duration {
+	if failures < free || elapsed == nil {
+		return 0
+	}
+	delay := loginLockout
+	if failures < lockout {
+		delay = loginBackoffBase << (failures - free)
+		if delay > loginLockout {
+			delay = loginLockout
+		}
+	}
+	return delay - *elapsed
+}
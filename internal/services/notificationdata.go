@@ -0,0 +1,101 @@
+package services
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/protos/gen/go/notification"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// maxMetadataSize - предельный размер metadata уведомления в JSON.
+const maxMetadataSize = 4096
+
+// ValidateNotificationData подставляет категорию и приоритет по умолчанию и
+// проверяет поля уведомления.
+func ValidateNotificationData(d *models.NotificationData) error {
+	d.Category = strings.ToLower(strings.TrimSpace(d.Category))
+	if d.Category == "" {
+		d.Category = DefaultNotificationCategory
+	}
+	d.Priority = strings.ToLower(strings.TrimSpace(d.Priority))
+	if d.Priority == "" {
+		d.Priority = models.PriorityNormal
+	}
+
+	if err := validation.ValidateStruct(d,
+		validation.Field(&d.Title, validation.Required, validation.Length(1, 200)),
+		validation.Field(&d.Message, validation.Length(0, 2000)),
+		validation.Field(&d.Category, validation.Length(1, 50)),
+		validation.Field(&d.Priority, validation.In(models.PriorityLow, models.PriorityNormal, models.PriorityHigh)),
+		validation.Field(&d.Icon, validation.Length(0, 200)),
+		validation.Field(&d.ActionURL, validation.Length(0, 2000), is.URL),
+		validation.Field(&d.Image, validation.Length(0, 2000), is.URL),
+	); err != nil {
+		return err
+	}
+	if len(d.Metadata) > 0 {
+		data, err := json.Marshal(d.Metadata)
+		if err != nil {
+			return err
+		}
+		if len(data) > maxMetadataSize {
+			return domain.ErrMetadataTooLarge
+		}
+	}
+	return nil
+}
+
+// ConvertFromProtoData переводит содержимое уведомления из gRPC запроса в модель.
+func ConvertFromProtoData(d *notification.Data) models.NotificationData {
+	if d == nil {
+		return models.NotificationData{}
+	}
+	var priority string
+	if d.Priority != notification.Priority_PRIORITY_UNSPECIFIED {
+		priority = strings.ToLower(strings.TrimPrefix(d.Priority.String(), "PRIORITY_"))
+	}
+	data := models.NotificationData{
+		Title:     d.Title,
+		Message:   d.Message,
+		Category:  d.Category,
+		Priority:  priority,
+		Icon:      d.Icon,
+		ActionURL: d.ActionUrl,
+		Image:     d.Image,
+	}
+	if d.Metadata != nil {
+		data.Metadata = d.Metadata.AsMap()
+	}
+	return data
+}
+
+// ConvertToProtoData переводит содержимое уведомления в gRPC сообщение.
+// Неизвестный приоритет - ErrInvalidPriority.
+func ConvertToProtoData(d models.NotificationData) (*notification.Data, error) {
+	priority, ok := notification.Priority_value["PRIORITY_"+strings.ToUpper(d.Priority)]
+	if d.Priority != "" && !ok {
+		return nil, domain.ErrInvalidPriority
+	}
+	var metadata *structpb.Struct
+	if len(d.Metadata) > 0 {
+		var err error
+		if metadata, err = structpb.NewStruct(d.Metadata); err != nil {
+			return nil, err
+		}
+	}
+	return &notification.Data{
+		Title:     d.Title,
+		Message:   d.Message,
+		Category:  d.Category,
+		Priority:  notification.Priority(priority),
+		Icon:      d.Icon,
+		ActionUrl: d.ActionURL,
+		Image:     d.Image,
+		Metadata:  metadata,
+	}, nil
+}
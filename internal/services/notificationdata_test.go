@@ -0,0 +1,95 @@
+package services_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateNotificationData(t *testing.T) {
+	testCases := []struct {
+		name    string
+		d       models.NotificationData
+		isValid bool
+	}{
+		{
+			name:    "valid",
+			d:       models.NotificationData{Title: "Hello", Priority: "HIGH", ActionURL: "https://example.org/orders/1"},
+			isValid: true,
+		},
+		{
+			name:    "empty title",
+			d:       models.NotificationData{Message: "hello"},
+			isValid: false,
+		},
+		{
+			name:    "unknown priority",
+			d:       models.NotificationData{Title: "Hello", Priority: "urgent"},
+			isValid: false,
+		},
+		{
+			name:    "invalid action url",
+			d:       models.NotificationData{Title: "Hello", ActionURL: "not a url"},
+			isValid: false,
+		},
+		{
+			name:    "invalid image",
+			d:       models.NotificationData{Title: "Hello", Image: "://"},
+			isValid: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.isValid {
+				assert.NoError(t, services.ValidateNotificationData(&tc.d))
+			} else {
+				assert.Error(t, services.ValidateNotificationData(&tc.d))
+			}
+		})
+	}
+}
+
+func TestValidateNotificationData_Defaults(t *testing.T) {
+	d := &models.NotificationData{Title: "Hello", Category: " Billing "}
+	require.NoError(t, services.ValidateNotificationData(d))
+	assert.Equal(t, "billing", d.Category)
+	assert.Equal(t, models.PriorityNormal, d.Priority)
+
+	d = &models.NotificationData{Title: "Hello"}
+	require.NoError(t, services.ValidateNotificationData(d))
+	assert.Equal(t, services.DefaultNotificationCategory, d.Category)
+}
+
+func TestValidateNotificationData_MetadataTooLarge(t *testing.T) {
+	d := &models.NotificationData{Title: "Hello", Metadata: map[string]interface{}{"blob": strings.Repeat("x", 5000)}}
+	assert.ErrorIs(t, services.ValidateNotificationData(d), domain.ErrMetadataTooLarge)
+}
+
+func TestConvertProtoData(t *testing.T) {
+	d := models.NotificationData{
+		Title:     "Order shipped",
+		Message:   "Your order is on the way",
+		Category:  "orders",
+		Priority:  models.PriorityHigh,
+		Icon:      "truck",
+		ActionURL: "https://example.org/orders/1",
+		Image:     "https://example.org/orders/1.png",
+		Metadata:  map[string]interface{}{"order_id": float64(1), "tags": []interface{}{"express"}},
+	}
+	p, err := services.ConvertToProtoData(d)
+	require.NoError(t, err)
+	assert.Equal(t, d, services.ConvertFromProtoData(p))
+
+	assert.Equal(t, models.NotificationData{}, services.ConvertFromProtoData(nil))
+}
+
+func TestConvertToProtoData_UnknownPriority(t *testing.T) {
+	_, err := services.ConvertToProtoData(models.NotificationData{Title: "Hello", Priority: "urgent"})
+	assert.ErrorIs(t, err, domain.ErrInvalidPriority)
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/mailer"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+// DefaultNotificationCategory - категория уведомления, если издатель её не указал.
+const DefaultNotificationCategory = "general"
+
+const quietHoursLayout = "15:04"
+
+// Preferences возвращает настройки доставки пользователя. Если пользователь
+// их не менял, возвращаются настройки по умолчанию: все категории включены,
+// тихих часов нет, уведомления отправляются только в Centrifugo.
+func (cs *NotificationService) Preferences(userID int) (*models.NotificationPreferences, error) {
+	p, err := cs.store.NotificationPreferences().Get(userID)
+	if err == sql.ErrNoRows {
+		return &models.NotificationPreferences{
+			UserID:          userID,
+			MutedCategories: []string{},
+			Timezone:        "UTC",
+			Channels:        models.DeliveryChannels{Realtime: true},
+		}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (cs *NotificationService) UpdatePreferences(p *models.NotificationPreferences) error {
+	if p.Timezone == "" {
+		p.Timezone = "UTC"
+	}
+	muted := make([]string, 0, len(p.MutedCategories))
+	for _, c := range p.MutedCategories {
+		c = strings.ToLower(strings.TrimSpace(c))
+		if c != "" && !slices.Contains(muted, c) {
+			muted = append(muted, c)
+		}
+	}
+	p.MutedCategories = muted
+	p.Locale = normalizeLocale(p.Locale)
+
+	if err := validation.ValidateStruct(p,
+		validation.Field(&p.Timezone, validation.By(validateTimezone)),
+		validation.Field(&p.Locale, validation.By(validateLocale)),
+		validation.Field(&p.MutedCategories, validation.Each(validation.Length(1, 50))),
+	); err != nil {
+		return err
+	}
+	if p.QuietHours != nil {
+		if _, err := time.Parse(quietHoursLayout, p.QuietHours.Start); err != nil {
+			return domain.ErrInvalidQuietHours
+		}
+		if _, err := time.Parse(quietHoursLayout, p.QuietHours.End); err != nil {
+			return domain.ErrInvalidQuietHours
+		}
+	}
+	if err := cs.store.NotificationPreferences().Save(p); err != nil {
+		cs.logger.Errorf(cs.ctx, "Failed to save notification preferences of user %d: %v", p.UserID, err)
+		return err
+	}
+	return nil
+}
+
+func validateTimezone(value interface{}) error {
+	tz, _ := value.(string)
+	if _, err := time.LoadLocation(tz); err != nil {
+		return domain.ErrUnknownTimezone
+	}
+	return nil
+}
+
+// QuietUntil сообщает, попадает ли момент now в тихие часы p, и если да,
+// возвращает момент их окончания.
+func QuietUntil(p *models.NotificationPreferences, now time.Time) (time.Time, bool) {
+	if p.QuietHours == nil {
+		return time.Time{}, false
+	}
+	start, err := time.Parse(quietHoursLayout, p.QuietHours.Start)
+	if err != nil {
+		return time.Time{}, false
+	}
+	end, err := time.Parse(quietHoursLayout, p.QuietHours.End)
+	if err != nil {
+		return time.Time{}, false
+	}
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	local := now.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+	endToday := time.Date(local.Year(), local.Month(), local.Day(), end.Hour(), end.Minute(), 0, 0, loc)
+
+	switch {
+	case from == to:
+		return time.Time{}, false
+	case from < to:
+		if minute >= from && minute < to {
+			return endToday, true
+		}
+	case minute < to:
+		// Интервал через полночь, сейчас его утренняя часть
+		return endToday, true
+	case minute >= from:
+		return endToday.AddDate(0, 0, 1), true
+	}
+	return time.Time{}, false
+}
+
+// applyPreferences проверяет настройки получателя перед отправкой в Centrifugo.
+// Уведомление приглушённой категории не отправляется, в тихие часы отправка
+// откладывается до их окончания. Копия на email уходит вместе с отправкой.
+func (cs *NotificationService) applyPreferences(n *models.UserNotification) error {
+	p, err := cs.Preferences(n.UserID)
+	if err != nil {
+		return err
+	}
+	category := n.Notification.Category
+	if category == "" {
+		category = DefaultNotificationCategory
+	}
+	if slices.Contains(p.MutedCategories, category) {
+		cs.logger.Infof(cs.ctx, "Category %s is muted by user %d, notification %d is not pushed", category, n.UserID, n.UID)
+		return domain.ErrPushSuppressed
+	}
+	if until, ok := QuietUntil(p, time.Now()); ok {
+		if err := cs.store.Notification().Defer(n.UID, until); err != nil {
+			return err
+		}
+		n.DeliverAt = &until
+		cs.logger.Infof(cs.ctx, "Quiet hours of user %d, notification %d is deferred until %s", n.UserID, n.UID, until)
+		return domain.ErrPushDeferred
+	}
+	if p.Channels.Email {
+		cs.sendEmail(n)
+	}
+	if !p.Channels.Realtime {
+		return domain.ErrPushSuppressed
+	}
+	return nil
+}
+
+// sendEmail отправляет копию уведомления на email получателя. Ошибка
+// отправки не мешает доставке в Centrifugo и только пишется в лог.
+func (cs *NotificationService) sendEmail(n *models.UserNotification) {
+	u, err := cs.store.User().GetById(n.UserID)
+	if err != nil {
+		cs.logger.Errorf(cs.ctx, "Failed to load user %d for notification email: %v", n.UserID, err)
+		return
+	}
+	body := fmt.Sprintf("Hello, %s!\n\n%s", u.Username, n.Notification.Message)
+	if n.Notification.ActionURL != "" {
+		body += "\n\n" + n.Notification.ActionURL
+	}
+	if err := cs.mailer.Send(cs.ctx, &mailer.Message{
+		To:      u.Email,
+		Subject: n.Notification.Title,
+		Body:    body,
+	}); err != nil {
+		cs.logger.Errorf(cs.ctx, "Failed to email notification %d to user %d: %v", n.UID, n.UserID, err)
+	}
+}
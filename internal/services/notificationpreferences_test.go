@@ -0,0 +1,75 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuietUntil(t *testing.T) {
+	day := &models.NotificationPreferences{Timezone: "UTC", QuietHours: &models.QuietHours{Start: "13:00", End: "15:00"}}
+	night := &models.NotificationPreferences{Timezone: "Europe/Moscow", QuietHours: &models.QuietHours{Start: "23:00", End: "07:00"}}
+	msk, err := time.LoadLocation("Europe/Moscow")
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name  string
+		p     *models.NotificationPreferences
+		now   time.Time
+		until time.Time
+		quiet bool
+	}{
+		{
+			name:  "no quiet hours",
+			p:     &models.NotificationPreferences{Timezone: "UTC"},
+			now:   time.Date(2025, 7, 18, 14, 0, 0, 0, time.UTC),
+			quiet: false,
+		},
+		{
+			name:  "inside",
+			p:     day,
+			now:   time.Date(2025, 7, 18, 14, 0, 0, 0, time.UTC),
+			until: time.Date(2025, 7, 18, 15, 0, 0, 0, time.UTC),
+			quiet: true,
+		},
+		{
+			name:  "end is exclusive",
+			p:     day,
+			now:   time.Date(2025, 7, 18, 15, 0, 0, 0, time.UTC),
+			quiet: false,
+		},
+		{
+			name:  "overnight before midnight",
+			p:     night,
+			now:   time.Date(2025, 7, 18, 21, 30, 0, 0, time.UTC), // 00:30 по Москве
+			until: time.Date(2025, 7, 19, 7, 0, 0, 0, msk),
+			quiet: true,
+		},
+		{
+			name:  "overnight evening",
+			p:     night,
+			now:   time.Date(2025, 7, 18, 20, 30, 0, 0, time.UTC), // 23:30 по Москве
+			until: time.Date(2025, 7, 19, 7, 0, 0, 0, msk),
+			quiet: true,
+		},
+		{
+			name:  "overnight daytime",
+			p:     night,
+			now:   time.Date(2025, 7, 18, 9, 0, 0, 0, time.UTC),
+			quiet: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			until, quiet := services.QuietUntil(tc.p, tc.now)
+			assert.Equal(t, tc.quiet, quiet)
+			if tc.quiet {
+				assert.True(t, tc.until.Equal(until), "until %s, want %s", until, tc.until)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+)
+
+// ValidateDeliverAt проверяет время отправки по расписанию. nil означает
+// отправку сразу.
+func ValidateDeliverAt(at *time.Time) error {
+	if at != nil && !at.After(time.Now()) {
+		return domain.ErrInvalidDeliverAt
+	}
+	return nil
+}
+
+// Schedule сохраняет уведомление, которое фоновая задача отправит получателю
+// в at. До этого уведомление видно только издателю.
+func (cs *NotificationService) Schedule(n *models.UserNotification, at time.Time) error {
+	n.DeliverAt = &at
+	n.Scheduled = true
+	if err := cs.NotificationCreate(n); err != nil {
+		return err
+	}
+	cs.logger.Infof(cs.ctx, "Notification %d for user %d is scheduled for %s", n.UID, n.UserID, at)
+	return nil
+}
+
+// Scheduled возвращает запланированные уведомления. broadcast сообщает, есть
+// ли у издателя право notification:broadcast: без него уведомления рассылок,
+// групп и сегментов не видны, а Reschedule и CancelScheduled их не находят.
+func (cs *NotificationService) Scheduled(broadcast bool) ([]*models.UserNotification, error) {
+	n, err := cs.store.Notification().GetScheduled(broadcast)
+	if err != nil {
+		cs.logger.Errorf(cs.ctx, "Failed to get scheduled notifications: %v", err)
+		return nil, err
+	}
+	return n, nil
+}
+
+func (cs *NotificationService) Reschedule(id int, at time.Time, broadcast bool) (*models.UserNotification, error) {
+	if err := ValidateDeliverAt(&at); err != nil {
+		return nil, err
+	}
+	n, err := cs.store.Notification().Reschedule(id, at, broadcast)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrScheduledNotFound
+	} else if err != nil {
+		cs.logger.Errorf(cs.ctx, "Failed to reschedule notification %d: %v", id, err)
+		return nil, err
+	}
+	return n, nil
+}
+
+// CancelScheduled удаляет запланированное уведомление. Отправленное или уже
+// забранное на отправку уведомление отменить нельзя.
+func (cs *NotificationService) CancelScheduled(id int, broadcast bool) error {
+	err := cs.store.Notification().DeleteScheduled(id, broadcast)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrScheduledNotFound
+	} else if err != nil {
+		cs.logger.Errorf(cs.ctx, "Failed to cancel notification %d: %v", id, err)
+		return err
+	}
+	return nil
+}
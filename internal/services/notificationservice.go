@@ -3,9 +3,14 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
 
+	"github.com/DANazavr/RATest/internal/domain"
 	"github.com/DANazavr/RATest/internal/domain/models"
 	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/mailer"
 	"github.com/DANazavr/RATest/internal/store"
 	"github.com/DANazavr/RATest/protos/gen/go/notification"
 	"github.com/centrifugal/gocent/v3"
@@ -15,10 +20,11 @@ type NotificationService struct {
 	ctx    context.Context
 	logger *log.Log
 	store  store.Store
+	mailer mailer.Mailer
 	Client *gocent.Client
 }
 
-func NewNotificationService(ctx context.Context, logger *log.Log, store store.Store) *NotificationService {
+func NewNotificationService(ctx context.Context, logger *log.Log, store store.Store, mailer mailer.Mailer) *NotificationService {
 	c := gocent.New(gocent.Config{
 		Addr: "http://localhost:8000/api", // правильный адрес для gocent клиента
 		Key:  "my_api_key",
@@ -28,10 +34,30 @@ func NewNotificationService(ctx context.Context, logger *log.Log, store store.St
 		ctx:    ctx,
 		logger: logger.WithComponent("services/centrifuge"),
 		store:  store,
+		mailer: mailer,
 		Client: c,
 	}
 }
 
+// ForOrg возвращает копию сервиса, которая сохраняет и читает уведомления
+// организации orgID.
+func (cs *NotificationService) ForOrg(orgID int) *NotificationService {
+	c := *cs
+	c.store = cs.store.Tenant(orgID)
+	return &c
+}
+
+// UserChannel возвращает персональный канал пользователя в Centrifugo. Каналы
+// разных организаций не пересекаются.
+func (cs *NotificationService) UserChannel(orgID, userID int) string {
+	return "notifications:org" + strconv.Itoa(orgID) + ".user#" + strconv.Itoa(userID)
+}
+
+// CanSubscribe сообщает, может ли пользователь организации orgID читать канал channel.
+func (cs *NotificationService) CanSubscribe(orgID, userID int, channel string) bool {
+	return channel == cs.UserChannel(orgID, userID)
+}
+
 func (cs *NotificationService) Presence(channel string) (gocent.PresenceResult, error) {
 	presence, err := cs.Client.Presence(cs.ctx, channel)
 	if err != nil {
@@ -41,7 +67,14 @@ func (cs *NotificationService) Presence(channel string) (gocent.PresenceResult,
 	return presence, nil
 }
 
+// Publish отправляет уведомление в channel с учётом настроек получателя: если
+// отправка запрещена или отложена, возвращает domain.ErrPushSuppressed или
+// domain.ErrPushDeferred и в Centrifugo ничего не отправляет.
 func (cs *NotificationService) Publish(n *models.UserNotification, channel string) (gocent.PublishResult, error) {
+	if err := cs.applyPreferences(n); err != nil {
+		return gocent.PublishResult{}, err
+	}
+
 	data, err := json.Marshal(n)
 	if err != nil {
 		cs.logger.Errorf(cs.ctx, "Failed to marshal notification: %v", err)
@@ -58,6 +91,9 @@ func (cs *NotificationService) Publish(n *models.UserNotification, channel strin
 }
 
 func (cs *NotificationService) NotificationCreate(n *models.UserNotification) error {
+	if err := ValidateNotificationData(&n.Notification); err != nil {
+		return err
+	}
 	data, err := json.Marshal(n.Notification)
 	if err != nil {
 		cs.logger.Errorf(cs.ctx, "Failed to marshal notification: %v", err)
@@ -70,6 +106,148 @@ func (cs *NotificationService) NotificationCreate(n *models.UserNotification) er
 	return nil
 }
 
+// Fanout сохраняет уведомление с содержимым content для каждого из users и
+// отправляет его в персональный канал, если canDeliver разрешает доставку
+// пользователю. Копия отмечается отправленной, если получатель сейчас в сети.
+// Если задан deliverAt, копии планируются на это время и сейчас не отправляются.
+// Ошибка для одного получателя не прерывает рассылку остальным, а учитывается
+// в FanoutResult.Failed: сохранённые копии остаются, и издатель видит, скольким
+// получателям уведомление не дошло. Ошибка возвращается, только если не
+// сохранилась ни одна копия.
+// broadcast отмечает копии рассылки группе или сегменту.
+func (cs *NotificationService) Fanout(users []*models.User, content NotificationContent, apiKeyID *int, broadcast bool, deliverAt *time.Time, canDeliver func(*models.User) bool) (*models.FanoutResult, error) {
+	res := &models.FanoutResult{}
+	var lastErr error
+	for _, u := range users {
+		n := &models.UserNotification{
+			UserID:       u.ID,
+			OrgID:        u.OrgID,
+			Notification: content(u),
+			APIKeyID:     apiKeyID,
+			Broadcast:    broadcast,
+		}
+		if deliverAt != nil {
+			if err := cs.Schedule(n, *deliverAt); err != nil {
+				lastErr = err
+				res.Failed++
+				continue
+			}
+			res.Recipients++
+			continue
+		}
+		if err := cs.NotificationCreate(n); err != nil {
+			lastErr = err
+			res.Failed++
+			continue
+		}
+		res.Recipients++
+		if !canDeliver(u) {
+			continue
+		}
+
+		pushed, err := cs.push(n)
+		if err != nil {
+			cs.logger.Errorf(cs.ctx, "Failed to push notification %d to user %d: %v", n.UID, u.ID, err)
+			res.Failed++
+			continue
+		}
+		if pushed {
+			res.Pushed++
+		}
+	}
+	if res.Recipients == 0 && lastErr != nil {
+		return res, lastErr
+	}
+	cs.logger.Infof(cs.ctx, "Notification fanned out to %d users, %d pushed, %d failed", res.Recipients, res.Pushed, res.Failed)
+	return res, nil
+}
+
+// push отправляет сохранённое уведомление в персональный канал получателя и
+// отмечает его отправленным, если получатель в сети. Возвращает false, если
+// настройки получателя запретили или отложили отправку.
+func (cs *NotificationService) push(n *models.UserNotification) (bool, error) {
+	channel := cs.UserChannel(n.OrgID, n.UserID)
+	if _, err := cs.Publish(n, channel); errors.Is(err, domain.ErrPushSuppressed) || errors.Is(err, domain.ErrPushDeferred) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	// Персональный канал читает только его владелец. Уведомление уже ушло в
+	// Centrifugo, поэтому ошибки presence и отметки не делают отправку
+	// неудачной, иначе повтор отправил бы его второй раз: оно только остаётся
+	// неотмеченным
+	presence, err := cs.Presence(channel)
+	if err != nil {
+		cs.logger.Warnf(cs.ctx, "Notification %d is pushed but not marked as sent: presence of user %d is unknown: %v", n.UID, n.UserID, err)
+		return true, nil
+	}
+	if len(presence.Presence) > 0 {
+		if err := cs.MarkAsSend(n, n.UserID); err != nil {
+			cs.logger.Warnf(cs.ctx, "Notification %d is pushed but not marked as sent: %v", n.UID, err)
+		}
+	}
+	return true, nil
+}
+
+const (
+	// deferredBatchSize - сколько отложенных уведомлений забирается за один запрос.
+	deferredBatchSize = 100
+	// deferredLease - через сколько забранное, но не отправленное уведомление
+	// забирается снова.
+	deferredLease = time.Minute
+)
+
+// RunDeferred раз в interval отправляет уведомления, время отправки которых
+// наступило: отложенные из-за тихих часов и запланированные издателем. Работает,
+// пока ctx не будет отменён. Получателям, которым canDeliver запрещает доставку,
+// уведомление остаётся только в истории. Несколько процессов могут выполнять
+// RunDeferred одновременно: каждое уведомление отправит только один из них.
+// Уведомление, которое не удалось отправить, повторяется через deferredLease.
+func (cs *NotificationService) RunDeferred(ctx context.Context, interval time.Duration, canDeliver func(*models.User) bool) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			cs.deliverDue(canDeliver)
+		}
+	}
+}
+
+func (cs *NotificationService) deliverDue(canDeliver func(*models.User) bool) {
+	for {
+		due, err := cs.store.Notification().ClaimDue(deferredBatchSize, deferredLease)
+		if err != nil {
+			cs.logger.Errorf(cs.ctx, "Failed to load deferred notifications: %v", err)
+			return
+		}
+		for _, n := range due {
+			// push может снова отложить уведомление и заменить DeliverAt
+			lease := *n.DeliverAt
+			u, err := cs.store.User().GetById(n.UserID)
+			if err != nil {
+				cs.logger.Errorf(cs.ctx, "Failed to load recipient of deferred notification %d: %v", n.UID, err)
+				continue
+			}
+			if canDeliver(u) {
+				if _, err := cs.push(n); err != nil {
+					cs.logger.Errorf(cs.ctx, "Failed to push deferred notification %d, retrying in %s: %v", n.UID, deferredLease, err)
+					continue
+				}
+			}
+			if err := cs.store.Notification().FinishDue(n.UID, lease); err != nil {
+				cs.logger.Errorf(cs.ctx, "Failed to finish deferred notification %d: %v", n.UID, err)
+			}
+		}
+		if len(due) < deferredBatchSize {
+			return
+		}
+	}
+}
+
 func (cs *NotificationService) GetByUserId(userID int) ([]*models.UserNotification, error) {
 	n, err := cs.store.Notification().GetByUserId(userID)
 	if err != nil {
@@ -124,11 +302,10 @@ func (cs *NotificationService) ValidateFilter(filter string) bool {
 }
 
 func (cs *NotificationService) ConvertToProtoNotification(n *models.UserNotification) (*notification.Notification, error) {
-	// Преобразуем map в google.protobuf.Struct
-	// dataStruct, err := structpb.NewStruct(n.Notification)
-	// if err != nil {
-	// 	return nil, err
-	// }
+	d, err := ConvertToProtoData(n.Notification)
+	if err != nil {
+		return nil, err
+	}
 
 	// Обрабатываем nil-указатели для строк
 	getStringValue := func(s *string) string {
@@ -138,17 +315,16 @@ func (cs *NotificationService) ConvertToProtoNotification(n *models.UserNotifica
 		return *s
 	}
 
-	d := &notification.Data{
-		Title:   n.Notification["title"].(string),
-		Message: n.Notification["message"].(string),
-	}
-
-	return &notification.Notification{
+	pn := &notification.Notification{
 		Uid:       int64(n.UID),
 		Userid:    int64(n.UserID),
 		CreatedAt: getStringValue(n.CreatedAt),
 		SendAt:    getStringValue(n.SendAt),
 		ReadAt:    getStringValue(n.ReadAt),
 		Data:      d,
-	}, nil
+	}
+	if n.DeliverAt != nil {
+		pn.DeliverAt = n.DeliverAt.Format(time.RFC3339)
+	}
+	return pn, nil
 }
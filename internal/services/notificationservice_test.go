@@ -0,0 +1,68 @@
+package services_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/services"
+	"github.com/DANazavr/RATest/internal/store/sqlstore"
+	"github.com/centrifugal/gocent/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationService_RunDeferredRetry(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("user_notifications", "users")
+
+	logger := log.NewLog(t.Context(), &log.LogConfig{Component: "services", LogLevel: "debug"})
+	s := sqlstore.New(t.Context(), db, logger)
+	u := &models.User{
+		Username:          "testuser",
+		EncryptedPassword: "encrypted_password",
+		Email:             "email@example.com",
+		Role:              "user",
+		OrgID:             domain.DefaultOrgID,
+	}
+	assert.NoError(t, s.User().Create(u))
+	un := &models.UserNotification{UserID: u.ID, OrgID: u.OrgID}
+	assert.NoError(t, s.Notification().Create(un, []byte(`{"title":"hello","message":"hello"}`)))
+	assert.NoError(t, s.Notification().Defer(un.UID, time.Now().Add(-time.Minute)))
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	ns := services.NewNotificationService(t.Context(), logger, s, nil)
+	ns.Client = gocent.New(gocent.Config{Addr: failing.URL})
+	run := func() {
+		ctx, cancel := context.WithTimeout(t.Context(), 200*time.Millisecond)
+		defer cancel()
+		ns.RunDeferred(ctx, 20*time.Millisecond, func(*models.User) bool { return true })
+	}
+	run()
+
+	// Centrifugo недоступна: уведомление остаётся в очереди на повтор
+	got, err := s.Notification().GetById(un.UID)
+	assert.NoError(t, err)
+	assert.NotNil(t, got.DeliverAt)
+	assert.Nil(t, got.SendAt)
+
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"result":{}}`))
+	}))
+	defer ok.Close()
+	ns.Client = gocent.New(gocent.Config{Addr: ok.URL})
+	_, err = db.Exec("UPDATE user_notifications SET deliver_at = NOW() - INTERVAL '1 second' WHERE uid = $1", un.UID)
+	assert.NoError(t, err)
+	run()
+
+	got, err = s.Notification().GetById(un.UID)
+	assert.NoError(t, err)
+	assert.Nil(t, got.DeliverAt)
+}
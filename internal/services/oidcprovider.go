@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/DANazavr/RATest/config"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+// OIDCClaims - данные пользователя из проверенного ID токена.
+type OIDCClaims struct {
+	Subject           string `json:"sub"`
+	Email             string `json:"email"`
+	EmailVerified     bool   `json:"email_verified"`
+	PreferredUsername string `json:"preferred_username"`
+	Name              string `json:"name"`
+}
+
+// OIDCProvider выполняет вход по authorization code с PKCE у одного внешнего
+// провайдера. Discovery выполняется при первом обращении, чтобы недоступный
+// провайдер не мешал запуску сервера.
+type OIDCProvider struct {
+	ctx context.Context
+	cfg config.OIDCProviderConfig
+
+	mu       sync.Mutex
+	oauth    *oauth2.Config
+	verifier *oidc.IDTokenVerifier
+}
+
+func NewOIDCProvider(ctx context.Context, cfg config.OIDCProviderConfig) *OIDCProvider {
+	return &OIDCProvider{
+		ctx: ctx,
+		cfg: cfg,
+	}
+}
+
+func (p *OIDCProvider) discover() (*oauth2.Config, *oidc.IDTokenVerifier, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.oauth != nil {
+		return p.oauth, p.verifier, nil
+	}
+
+	// Ключи провайдера обновляются в фоне с контекстом сервера, а не запроса
+	provider, err := oidc.NewProvider(p.ctx, p.cfg.Issuer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("discover %s: %w", p.cfg.Issuer, err)
+	}
+	scopes := []string{oidc.ScopeOpenID}
+	for _, s := range p.cfg.Scopes {
+		if s != oidc.ScopeOpenID {
+			scopes = append(scopes, s)
+		}
+	}
+	p.oauth = &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		RedirectURL:  p.cfg.RedirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       scopes,
+	}
+	p.verifier = provider.Verifier(&oidc.Config{ClientID: p.cfg.ClientID})
+	return p.oauth, p.verifier, nil
+}
+
+// AuthCodeURL возвращает адрес страницы входа провайдера. В запрос уходит
+// только S256-хеш verifier, сам verifier предъявляется при обмене кода.
+func (p *OIDCProvider) AuthCodeURL(state string, nonce string, verifier string) (string, error) {
+	oauth, _, err := p.discover()
+	if err != nil {
+		return "", err
+	}
+	return oauth.AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier)), nil
+}
+
+// Exchange обменивает code на токены и проверяет ID токен: подпись, issuer,
+// audience, срок действия и nonce.
+func (p *OIDCProvider) Exchange(ctx context.Context, code string, verifier string, nonce string) (*OIDCClaims, error) {
+	oauth, idVerifier, err := p.discover()
+	if err != nil {
+		return nil, err
+	}
+	token, err := oauth.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("exchange code: %w", err)
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, fmt.Errorf("token response has no id_token")
+	}
+	idToken, err := idVerifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("verify id_token: %w", err)
+	}
+	if idToken.Nonce != nonce {
+		return nil, fmt.Errorf("id_token nonce mismatch")
+	}
+
+	claims := &OIDCClaims{}
+	if err := idToken.Claims(claims); err != nil {
+		return nil, err
+	}
+	claims.Subject = idToken.Subject
+	return claims, nil
+}
@@ -0,0 +1,163 @@
+package services_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DANazavr/RATest/config"
+	"github.com/DANazavr/RATest/internal/services"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+// mockOIDCProvider - минимальный провайдер OpenID Connect: discovery, JWKS и
+// token endpoint с проверкой PKCE. Код авторизации выдаёт сам тест через authorize.
+type mockOIDCProvider struct {
+	*httptest.Server
+	key *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]mockAuthRequest
+}
+
+type mockAuthRequest struct {
+	challenge string
+	nonce     string
+	subject   string
+	email     string
+}
+
+func newMockOIDCProvider(t *testing.T) *mockOIDCProvider {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	m := &mockOIDCProvider{key: key, codes: map[string]mockAuthRequest{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                                m.URL,
+			"authorization_endpoint":                m.URL + "/authorize",
+			"token_endpoint":                        m.URL + "/token",
+			"jwks_uri":                              m.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "test",
+				"alg": "RS256",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		m.mu.Lock()
+		req, ok := m.codes[r.Form.Get("code")]
+		delete(m.codes, r.Form.Get("code"))
+		m.mu.Unlock()
+
+		sum := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+		if !ok || base64.RawURLEncoding.EncodeToString(sum[:]) != req.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"iss":            m.URL,
+			"aud":            "client",
+			"sub":            req.subject,
+			"email":          req.email,
+			"email_verified": true,
+			"nonce":          req.nonce,
+			"iat":            time.Now().Unix(),
+			"exp":            time.Now().Add(time.Minute).Unix(),
+		})
+		token.Header["kid"] = "test"
+		idToken, err := token.SignedString(key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"expires_in":   60,
+			"id_token":     idToken,
+		})
+	})
+	m.Server = httptest.NewServer(mux)
+	t.Cleanup(m.Close)
+	return m
+}
+
+// authorize имитирует успешный вход пользователя на странице провайдера.
+func (m *mockOIDCProvider) authorize(t *testing.T, authURL string, subject string, email string) string {
+	t.Helper()
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	q := u.Query()
+	require.Equal(t, "S256", q.Get("code_challenge_method"))
+	require.Empty(t, q.Get("code_verifier"))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	code := "code-" + subject
+	m.codes[code] = mockAuthRequest{
+		challenge: q.Get("code_challenge"),
+		nonce:     q.Get("nonce"),
+		subject:   subject,
+		email:     email,
+	}
+	return code
+}
+
+func TestOIDCProvider_Exchange(t *testing.T) {
+	mock := newMockOIDCProvider(t)
+	p := services.NewOIDCProvider(t.Context(), config.OIDCProviderConfig{
+		Name:        "mock",
+		Issuer:      mock.URL,
+		ClientID:    "client",
+		RedirectURL: "http://localhost/oidc/mock/callback",
+		Scopes:      []string{"email"},
+	})
+
+	verifier := oauth2.GenerateVerifier()
+	authURL, err := p.AuthCodeURL("state", "nonce", verifier)
+	require.NoError(t, err)
+
+	code := mock.authorize(t, authURL, "subject-1", "sso@example.com")
+	claims, err := p.Exchange(t.Context(), code, verifier, "nonce")
+	require.NoError(t, err)
+	assert.Equal(t, "subject-1", claims.Subject)
+	assert.Equal(t, "sso@example.com", claims.Email)
+	assert.True(t, claims.EmailVerified)
+
+	// Без исходного verifier код не обменивается
+	code = mock.authorize(t, authURL, "subject-2", "other@example.com")
+	_, err = p.Exchange(t.Context(), code, oauth2.GenerateVerifier(), "nonce")
+	assert.Error(t, err)
+
+	// ID токен, выданный для другого входа, отклоняется по nonce
+	code = mock.authorize(t, authURL, "subject-3", "third@example.com")
+	_, err = p.Exchange(t.Context(), code, verifier, "other-nonce")
+	assert.Error(t, err)
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/DANazavr/RATest/config"
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/store"
+	"golang.org/x/oauth2"
+)
+
+const oidcStateTTL = time.Minute * 10
+
+var usernameDisallowed = regexp.MustCompile(`[^a-z0-9_.-]+`)
+
+// OIDCService входит через внешних провайдеров OpenID Connect и связывает
+// их учётные записи с локальными пользователями.
+type OIDCService struct {
+	ctx         context.Context
+	logger      *log.Log
+	store       store.Store
+	userService *UserService
+	configs     map[string]config.OIDCProviderConfig
+	providers   map[string]*OIDCProvider
+}
+
+func NewOIDCService(ctx context.Context, logger *log.Log, store store.Store, us *UserService, cfgs []config.OIDCProviderConfig) *OIDCService {
+	s := &OIDCService{
+		ctx:         ctx,
+		logger:      logger.WithComponent("services/oidc"),
+		store:       store,
+		userService: us,
+		configs:     make(map[string]config.OIDCProviderConfig, len(cfgs)),
+		providers:   make(map[string]*OIDCProvider, len(cfgs)),
+	}
+	for _, cfg := range cfgs {
+		if cfg.DefaultRole == "" {
+			cfg.DefaultRole = domain.RoleUser
+		}
+		if cfg.OrgID == 0 {
+			cfg.OrgID = domain.DefaultOrgID
+		}
+		s.configs[cfg.Name] = cfg
+		s.providers[cfg.Name] = NewOIDCProvider(ctx, cfg)
+	}
+	return s
+}
+
+// Start начинает вход через провайдера name и возвращает адрес, на который
+// нужно отправить пользователя, и state, который нужно сохранить в браузере,
+// чтобы Callback принял только этот браузер.
+func (s *OIDCService) Start(ctx context.Context, name string) (string, string, error) {
+	p, ok := s.providers[name]
+	if !ok {
+		return "", "", domain.ErrUnknownOIDCProvider
+	}
+	state, err := newTokenID()
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := newTokenID()
+	if err != nil {
+		return "", "", err
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	url, err := p.AuthCodeURL(state, nonce, verifier)
+	if err != nil {
+		s.logger.Errorf(ctx, "Failed to start login with %s: %v", name, err)
+		return "", "", domain.ErrOIDCLoginFailed
+	}
+	if err := s.store.OIDCState().Create(&models.OIDCState{
+		StateHash:    hashToken(state),
+		Provider:     name,
+		CodeVerifier: verifier,
+		Nonce:        nonce,
+		ExpiresAt:    time.Now().Add(oidcStateTTL),
+	}); err != nil {
+		return "", "", err
+	}
+	return url, state, nil
+}
+
+// Callback завершает вход: обменивает code, находит или создаёт локального
+// пользователя и возвращает его. browserState - state, сохранённый в браузере
+// при Start; если он не совпадает с state от провайдера, вход начат в другом
+// браузере и отклоняется с domain.ErrInvalidOIDCState.
+func (s *OIDCService) Callback(ctx context.Context, name string, code string, state string, browserState string) (*models.User, error) {
+	p, ok := s.providers[name]
+	if !ok {
+		return nil, domain.ErrUnknownOIDCProvider
+	}
+	if browserState == "" || subtle.ConstantTimeCompare([]byte(state), []byte(browserState)) != 1 {
+		s.logger.Warnf(ctx, "Login with %s returned to a browser that did not start it", name)
+		return nil, domain.ErrInvalidOIDCState
+	}
+	st, err := s.store.OIDCState().Consume(hashToken(state), name)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := p.Exchange(ctx, code, st.CodeVerifier, st.Nonce)
+	if err != nil {
+		s.logger.Warnf(ctx, "Login with %s failed: %v", name, err)
+		return nil, domain.ErrOIDCLoginFailed
+	}
+	return s.resolveUser(ctx, s.configs[name], claims)
+}
+
+func (s *OIDCService) resolveUser(ctx context.Context, cfg config.OIDCProviderConfig, claims *OIDCClaims) (*models.User, error) {
+	identity, err := s.store.ExternalIdentity().GetByProviderSubject(cfg.Name, claims.Subject)
+	if err == nil {
+		if err := s.store.ExternalIdentity().TouchLogin(identity.ID, claims.Email); err != nil {
+			s.logger.Errorf(ctx, "Failed to update identity %d: %v", identity.ID, err)
+		}
+		return s.userService.UsersGetById(identity.UserID)
+	} else if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	identity = &models.ExternalIdentity{
+		Provider: cfg.Name,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	}
+
+	existing, err := s.store.User().GetByEmail(claims.Email)
+	if err == nil {
+		if !cfg.LinkByEmail || !claims.EmailVerified {
+			return nil, domain.ErrOIDCEmailTaken
+		}
+		identity.UserID = existing.ID
+		if err := s.store.ExternalIdentity().Create(identity); err != nil {
+			return nil, err
+		}
+		s.logger.Infof(ctx, "Linked %s subject %s to user %d", cfg.Name, claims.Subject, existing.ID)
+		return existing, nil
+	} else if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	u := &models.User{
+		Email: claims.Email,
+		Role:  cfg.DefaultRole,
+		OrgID: cfg.OrgID,
+	}
+	if claims.EmailVerified {
+		now := time.Now()
+		u.EmailVerifiedAt = &now
+	}
+	for _, candidate := range usernameCandidates(claims) {
+		u.Username = candidate
+		err = s.userService.UsersCreateExternal(ctx, u, identity)
+		if !errors.Is(err, domain.ErrUserAlreadyExists) {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Infof(ctx, "Created user %d from %s subject %s", u.ID, cfg.Name, claims.Subject)
+	return u, nil
+}
+
+// usernameCandidates предлагает имена для нового пользователя: из
+// preferred_username или email, затем те же с случайным суффиксом.
+func usernameCandidates(claims *OIDCClaims) []string {
+	base := claims.PreferredUsername
+	if base == "" {
+		base, _, _ = strings.Cut(claims.Email, "@")
+	}
+	base = usernameDisallowed.ReplaceAllString(strings.ToLower(base), "")
+	if len(base) > 15 {
+		base = base[:15]
+	}
+	for len(base) < 3 {
+		base += "_"
+	}
+
+	candidates := []string{base}
+	for i := 0; i < 3; i++ {
+		suffix, err := newTokenID()
+		if err != nil {
+			break
+		}
+		candidates = append(candidates, base+"-"+suffix[:4])
+	}
+	return candidates
+}
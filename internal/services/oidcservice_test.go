@@ -0,0 +1,47 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/DANazavr/RATest/config"
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/services"
+	"github.com/DANazavr/RATest/internal/store/sqlstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOIDCService_CallbackBrowserBinding(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("oidc_states", "user_identities", "users")
+
+	mock := newMockOIDCProvider(t)
+	logger := log.NewLog(t.Context(), &log.LogConfig{Component: "services", LogLevel: "debug"})
+	s := sqlstore.New(t.Context(), db, logger)
+	hasher, err := services.NewPasswordHasher(config.PasswordHashConfig{})
+	require.NoError(t, err)
+	oc := services.NewOIDCService(t.Context(), logger, s, services.NewUserService(t.Context(), s, logger, hasher), []config.OIDCProviderConfig{{
+		Name:        "mock",
+		Issuer:      mock.URL,
+		ClientID:    "client",
+		RedirectURL: "http://localhost/oidc/mock/callback",
+		Scopes:      []string{"email"},
+	}})
+
+	// Ссылку начатого входа открыли в другом браузере
+	authURL, state, err := oc.Start(t.Context(), "mock")
+	require.NoError(t, err)
+	code := mock.authorize(t, authURL, "subject-1", "sso@example.com")
+	_, err = oc.Callback(t.Context(), "mock", code, state, "")
+	assert.ErrorIs(t, err, domain.ErrInvalidOIDCState)
+
+	_, other, err := oc.Start(t.Context(), "mock")
+	require.NoError(t, err)
+	_, err = oc.Callback(t.Context(), "mock", code, state, other)
+	assert.ErrorIs(t, err, domain.ErrInvalidOIDCState)
+
+	u, err := oc.Callback(t.Context(), "mock", code, state, state)
+	require.NoError(t, err)
+	assert.Equal(t, "sso@example.com", u.Email)
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/store"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+var organizationSlug = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// OrganizationService управляет организациями. Данные внутри организации
+// управляются её администраторами через остальные сервисы в режиме ForOrg.
+type OrganizationService struct {
+	ctx    context.Context
+	logger *log.Log
+	store  store.Store
+}
+
+func NewOrganizationService(ctx context.Context, logger *log.Log, store store.Store) *OrganizationService {
+	return &OrganizationService{
+		ctx:    ctx,
+		logger: logger.WithComponent("services/organization"),
+		store:  store,
+	}
+}
+
+// Create создаёт организацию вместе с шаблонами, которые нужны сервису, например
+// RegisteredTemplateKey. Организация к этому моменту уже создана, поэтому
+// ошибка создания шаблона только логируется.
+func (ors *OrganizationService) Create(o *models.Organization) error {
+	if err := validation.ValidateStruct(o,
+		validation.Field(&o.Slug, validation.Required, validation.Length(2, 50), validation.Match(organizationSlug)),
+		validation.Field(&o.Name, validation.Required, validation.Length(1, 100)),
+	); err != nil {
+		return err
+	}
+	if err := ors.store.Organization().Create(o); err != nil {
+		return err
+	}
+	if err := ors.store.Tenant(o.ID).Template().Create(registeredTemplate()); err != nil {
+		ors.logger.Errorf(ors.ctx, "Failed to create template %q in organization %d: %v", RegisteredTemplateKey, o.ID, err)
+	}
+	ors.logger.Infof(ors.ctx, "Organization %d %q created", o.ID, o.Slug)
+	return nil
+}
+
+func (ors *OrganizationService) Get() ([]*models.Organization, error) {
+	return ors.store.Organization().Get()
+}
+
+func (ors *OrganizationService) Organization(id int) (*models.Organization, error) {
+	o, err := ors.store.Organization().GetById(id)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrOrganizationNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return o, nil
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/DANazavr/RATest/config"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	HashBcrypt   = "bcrypt"
+	HashArgon2id = "argon2id"
+
+	argon2SaltLen = 16
+	argon2KeyLen  = 32
+)
+
+// PasswordHasher хеширует пароли выбранным алгоритмом. Алгоритм и параметры
+// записываются в сам хеш: bcrypt в формате $2a$<cost>$..., argon2id в
+// формате PHC $argon2id$v=19$m=<KiB>,t=<time>,p=<threads>$<salt>$<hash>.
+type PasswordHasher struct {
+	algorithm  string
+	bcryptCost int
+	memory     uint32
+	time       uint32
+	threads    uint8
+}
+
+func NewPasswordHasher(cfg config.PasswordHashConfig) (*PasswordHasher, error) {
+	h := &PasswordHasher{
+		algorithm:  cfg.Algorithm,
+		bcryptCost: cfg.BcryptCost,
+		memory:     cfg.Argon2Memory,
+		time:       cfg.Argon2Time,
+		threads:    cfg.Argon2Threads,
+	}
+	if h.algorithm == "" {
+		h.algorithm = HashBcrypt
+	}
+	if h.bcryptCost == 0 {
+		h.bcryptCost = bcrypt.DefaultCost
+	}
+	if h.memory == 0 {
+		h.memory = 64 * 1024
+	}
+	if h.time == 0 {
+		h.time = 3
+	}
+	if h.threads == 0 {
+		h.threads = 4
+	}
+
+	switch h.algorithm {
+	case HashBcrypt:
+		if h.bcryptCost < bcrypt.MinCost || h.bcryptCost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("bcrypt cost %d out of range [%d, %d]", h.bcryptCost, bcrypt.MinCost, bcrypt.MaxCost)
+		}
+	case HashArgon2id:
+	default:
+		return nil, fmt.Errorf("unknown password hash algorithm %q", h.algorithm)
+	}
+	return h, nil
+}
+
+func (h *PasswordHasher) Hash(password string) (string, error) {
+	if h.algorithm == HashArgon2id {
+		salt := make([]byte, argon2SaltLen)
+		if _, err := rand.Read(salt); err != nil {
+			return "", err
+		}
+		key := argon2.IDKey([]byte(password), salt, h.time, h.memory, h.threads, argon2KeyLen)
+		return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+			argon2.Version, h.memory, h.time, h.threads,
+			base64.RawStdEncoding.EncodeToString(salt),
+			base64.RawStdEncoding.EncodeToString(key),
+		), nil
+	}
+
+	b, err := bcrypt.GenerateFromPassword([]byte(password), h.bcryptCost)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// Verify проверяет пароль. rehash равен true, если пароль верный, но хеш
+// построен другим алгоритмом или с другими параметрами.
+func (h *PasswordHasher) Verify(hash string, password string) (ok bool, rehash bool) {
+	if strings.HasPrefix(hash, "$argon2id$") {
+		var (
+			version      int
+			memory, time uint32
+			threads      uint8
+		)
+		parts := strings.Split(hash, "$")
+		if len(parts) != 6 {
+			return false, false
+		}
+		if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+			return false, false
+		}
+		if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil {
+			return false, false
+		}
+		salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+		if err != nil {
+			return false, false
+		}
+		key, err := base64.RawStdEncoding.DecodeString(parts[5])
+		if err != nil {
+			return false, false
+		}
+		other := argon2.IDKey([]byte(password), salt, time, memory, threads, uint32(len(key)))
+		if subtle.ConstantTimeCompare(key, other) != 1 {
+			return false, false
+		}
+		return true, h.algorithm != HashArgon2id || memory != h.memory || time != h.time || threads != h.threads
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+		return false, false
+	}
+	cost, err := bcrypt.Cost([]byte(hash))
+	return true, err != nil || h.algorithm != HashBcrypt || cost != h.bcryptCost
+}
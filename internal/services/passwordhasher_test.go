@@ -0,0 +1,55 @@
+package services_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/DANazavr/RATest/config"
+	"github.com/DANazavr/RATest/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestPasswordHasher(t *testing.T) {
+	argon, err := services.NewPasswordHasher(config.PasswordHashConfig{
+		Algorithm:     services.HashArgon2id,
+		Argon2Memory:  1024,
+		Argon2Time:    1,
+		Argon2Threads: 1,
+	})
+	require.NoError(t, err)
+
+	hash, err := argon.Hash("password")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"))
+
+	ok, rehash := argon.Verify(hash, "password")
+	assert.True(t, ok)
+	assert.False(t, rehash)
+
+	ok, _ = argon.Verify(hash, "wrong")
+	assert.False(t, ok)
+
+	// Старый bcrypt-хеш принимается, но требует пересчёта
+	legacy, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	require.NoError(t, err)
+	ok, rehash = argon.Verify(string(legacy), "password")
+	assert.True(t, ok)
+	assert.True(t, rehash)
+
+	// Смена параметров argon2id тоже требует пересчёта
+	stronger, err := services.NewPasswordHasher(config.PasswordHashConfig{
+		Algorithm:     services.HashArgon2id,
+		Argon2Memory:  2048,
+		Argon2Time:    1,
+		Argon2Threads: 1,
+	})
+	require.NoError(t, err)
+	ok, rehash = stronger.Verify(hash, "password")
+	assert.True(t, ok)
+	assert.True(t, rehash)
+
+	_, err = services.NewPasswordHasher(config.PasswordHashConfig{Algorithm: "md5"})
+	assert.Error(t, err)
+}
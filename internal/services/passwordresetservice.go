@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/mailer"
+	"github.com/DANazavr/RATest/internal/store"
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+const passwordResetTTL = time.Hour
+
+type PasswordResetService struct {
+	ctx         context.Context
+	logger      *log.Log
+	store       store.Store
+	userService *UserService
+	mailer      mailer.Mailer
+	publicURL   string
+}
+
+func NewPasswordResetService(ctx context.Context, logger *log.Log, store store.Store, us *UserService, mailer mailer.Mailer, publicURL string) *PasswordResetService {
+	return &PasswordResetService{
+		ctx:         ctx,
+		logger:      logger.WithComponent("services/passwordreset"),
+		store:       store,
+		userService: us,
+		mailer:      mailer,
+		publicURL:   publicURL,
+	}
+}
+
+// RequestReset отправляет письмо со ссылкой на сброс пароля. Для неизвестного
+// email ничего не делает и не возвращает ошибку, чтобы не раскрывать,
+// зарегистрирован ли адрес. По той же причине ошибка создания ссылки или
+// отправки письма только логируется.
+func (ps *PasswordResetService) RequestReset(ctx context.Context, email string) error {
+	u, err := ps.store.User().GetByEmail(email)
+	if err == sql.ErrNoRows {
+		ps.logger.Infof(ctx, "Password reset requested for unknown email")
+		return nil
+	} else if err != nil {
+		return err
+	}
+	// send сам логирует ошибку
+	_ = ps.send(ctx, u)
+	return nil
+}
+
+// ForceReset сбрасывает пароль пользователя id по решению администратора:
+// старый пароль перестаёт действовать, сессии завершаются, а на email
+// отправляется ссылка для установки нового пароля.
+func (ps *PasswordResetService) ForceReset(ctx context.Context, id int) error {
+	u, err := ps.userService.UsersGetById(id)
+	if err != nil {
+		return err
+	}
+	if err := ps.store.User().UpdatePassword(u.ID, ""); err != nil {
+		return err
+	}
+	if err := ps.store.Session().RevokeByUserId(u.ID); err != nil {
+		ps.logger.Errorf(ctx, "Failed to revoke sessions of user %d after forced password reset: %v", u.ID, err)
+		return err
+	}
+	ps.logger.Infof(ctx, "Password of user %d reset by admin, all sessions revoked", u.ID)
+	return ps.send(ctx, u)
+}
+
+func (ps *PasswordResetService) send(ctx context.Context, u *models.User) error {
+	token, err := newTokenID()
+	if err != nil {
+		return err
+	}
+	p := &models.PasswordReset{
+		UserID:    u.ID,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(passwordResetTTL),
+	}
+	if err := ps.store.PasswordReset().Create(p); err != nil {
+		ps.logger.Errorf(ctx, "Failed to create password reset for user %d: %v", u.ID, err)
+		return err
+	}
+
+	link := ps.publicURL + "/password_reset?token=" + url.QueryEscape(token)
+	if err := ps.mailer.Send(ctx, &mailer.Message{
+		To:      u.Email,
+		Subject: "Password reset",
+		Body: fmt.Sprintf("Hello, %s!\n\nTo set a new password, follow the link:\n%s\n\nThe link is valid for %s. If you did not request a reset, ignore this email.",
+			u.Username, link, passwordResetTTL),
+	}); err != nil {
+		ps.logger.Errorf(ctx, "Failed to send password reset email to user %d: %v", u.ID, err)
+		return err
+	}
+	ps.logger.Infof(ctx, "Password reset email sent to user %d", u.ID)
+	return nil
+}
+
+// ConfirmReset устанавливает новый пароль по токену из письма и завершает
+// все сессии пользователя.
+func (ps *PasswordResetService) ConfirmReset(ctx context.Context, token string, password string) error {
+	if err := validation.Validate(password, validation.Required, validation.Length(6, 20)); err != nil {
+		return err
+	}
+	enc, err := ps.userService.encryptString(password)
+	if err != nil {
+		return err
+	}
+	userID, err := ps.store.PasswordReset().Reset(hashToken(token), enc)
+	if err != nil {
+		return err
+	}
+	if err := ps.store.Session().RevokeByUserId(userID); err != nil {
+		ps.logger.Errorf(ctx, "Failed to revoke sessions of user %d after password reset: %v", userID, err)
+		return err
+	}
+	ps.logger.Infof(ctx, "Password of user %d reset, all sessions revoked", userID)
+	return nil
+}
@@ -0,0 +1,43 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/mailer"
+	"github.com/DANazavr/RATest/internal/services"
+	"github.com/DANazavr/RATest/internal/store/sqlstore"
+	"github.com/stretchr/testify/assert"
+)
+
+type failingMailer struct{}
+
+func (failingMailer) Send(context.Context, *mailer.Message) error {
+	return errors.New("smtp unavailable")
+}
+
+func TestPasswordResetService_RequestResetMailFailure(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("password_resets", "users")
+
+	logger := log.NewLog(t.Context(), &log.LogConfig{Component: "services", LogLevel: "debug"})
+	s := sqlstore.New(t.Context(), db, logger)
+	us := services.NewUserService(t.Context(), s, logger, nil)
+	ps := services.NewPasswordResetService(t.Context(), logger, s, us, failingMailer{}, "http://localhost")
+
+	u := &models.User{
+		Username:          "testuser",
+		EncryptedPassword: "encrypted_password",
+		Email:             "email@example.com",
+		Role:              "user",
+		OrgID:             domain.DefaultOrgID,
+	}
+	assert.NoError(t, s.User().Create(u))
+
+	assert.NoError(t, ps.RequestReset(t.Context(), "email@example.com"))
+	assert.NoError(t, ps.RequestReset(t.Context(), "unknown@example.com"))
+}
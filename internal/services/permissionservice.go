@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/store"
+)
+
+// permissionCacheTTL ограничивает, как долго изменения role_permissions
+// могут не доходить до уже запущенного сервера.
+const permissionCacheTTL = time.Minute
+
+type rolePermissions struct {
+	permissions map[string]struct{}
+	loadedAt    time.Time
+}
+
+type PermissionService struct {
+	ctx    context.Context
+	logger *log.Log
+	store  store.Store
+	mu     sync.RWMutex
+	cache  map[string]rolePermissions
+}
+
+func NewPermissionService(ctx context.Context, logger *log.Log, store store.Store) *PermissionService {
+	return &PermissionService{
+		ctx:    ctx,
+		logger: logger.WithComponent("services/permission"),
+		store:  store,
+		cache:  make(map[string]rolePermissions),
+	}
+}
+
+func (ps *PermissionService) HasPermission(role string, permission string) (bool, error) {
+	permissions, err := ps.permissions(role)
+	if err != nil {
+		return false, err
+	}
+	_, ok := permissions[permission]
+	return ok, nil
+}
+
+func (ps *PermissionService) permissions(role string) (map[string]struct{}, error) {
+	ps.mu.RLock()
+	cached, ok := ps.cache[role]
+	ps.mu.RUnlock()
+	if ok && time.Since(cached.loadedAt) < permissionCacheTTL {
+		return cached.permissions, nil
+	}
+
+	list, err := ps.store.Role().GetPermissions(role)
+	if err != nil {
+		ps.logger.Errorf(ps.ctx, "Failed to load permissions for role %s: %v", role, err)
+		return nil, err
+	}
+	permissions := make(map[string]struct{}, len(list))
+	for _, p := range list {
+		permissions[p] = struct{}{}
+	}
+
+	ps.mu.Lock()
+	ps.cache[role] = rolePermissions{permissions: permissions, loadedAt: time.Now()}
+	ps.mu.Unlock()
+	return permissions, nil
+}
@@ -0,0 +1,19 @@
+package services_test
+
+import (
+	"os"
+	"testing"
+)
+
+var (
+	databaseURL string
+)
+
+func TestMain(m *testing.M) {
+	databaseURL = os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		databaseURL = "host=localhost port=5432 user=postgres password=0123 dbname=restapi_test sslmode=disable"
+	}
+
+	os.Exit(m.Run())
+}
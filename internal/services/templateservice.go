@@ -0,0 +1,528 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/store"
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+)
+
+var (
+	templateKeyRe = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+	variableRe    = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	localeRe      = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+)
+
+// templateFuncs - функции, доступные в шаблонах помимо разрешённых встроенных.
+var templateFuncs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"trim":  strings.TrimSpace,
+	// {{.name | default "друг"}}
+	"default": func(def, v interface{}) interface{} {
+		if v == nil || v == "" {
+			return def
+		}
+		return v
+	},
+	// {{.when | date "02.01.2006 15:04"}}
+	"date": func(layout string, t time.Time) string {
+		return t.Format(layout)
+	},
+}
+
+// allowedTemplateFuncs - встроенные функции text/template, которые можно
+// вызывать из шаблона. call, index, slice и прочие недоступны. printf тоже:
+// ширина вида %1000000000d раздувает результат до гигабайт.
+var allowedTemplateFuncs = map[string]bool{
+	"and": true, "or": true, "not": true,
+	"eq": true, "ne": true, "lt": true, "le": true, "gt": true, "ge": true,
+	"len": true, "print": true, "urlquery": true,
+}
+
+// NotificationContent возвращает содержимое уведомления для получателя u.
+type NotificationContent func(u *models.User) models.NotificationData
+
+// StaticContent возвращает одно и то же содержимое всем получателям.
+func StaticContent(d models.NotificationData) NotificationContent {
+	return func(*models.User) models.NotificationData {
+		return d
+	}
+}
+
+// RegisteredTemplateKey - шаблон уведомления, которое получает новый пользователь.
+const RegisteredTemplateKey = "user.registered"
+
+// registeredTemplate возвращает шаблон RegisteredTemplateKey, с которым
+// создаётся каждая организация. Организациям, существовавшим до шаблонов,
+// такой же шаблон добавила миграция.
+func registeredTemplate() *models.NotificationTemplate {
+	return &models.NotificationTemplate{
+		Key:           RegisteredTemplateKey,
+		Description:   "Sent to a user right after registration",
+		Category:      "account",
+		Priority:      models.PriorityNormal,
+		DefaultLocale: "en",
+		Variables:     []models.TemplateVariable{{Name: "username", Type: models.TemplateVarString, Required: true}},
+		Locales: map[string]models.TemplateContent{
+			"en": {Title: "Welcome, {{.username}}!", Message: "Your account has been created."},
+			"ru": {Title: "Добро пожаловать, {{.username}}!", Message: "Ваша учётная запись создана."},
+		},
+	}
+}
+
+// TemplateService управляет шаблонами уведомлений и собирает по ним
+// содержимое для публикации.
+type TemplateService struct {
+	ctx    context.Context
+	logger *log.Log
+	store  store.Store
+}
+
+func NewTemplateService(ctx context.Context, logger *log.Log, store store.Store) *TemplateService {
+	return &TemplateService{
+		ctx:    ctx,
+		logger: logger.WithComponent("services/template"),
+		store:  store,
+	}
+}
+
+// ForOrg возвращает копию сервиса, которая видит только шаблоны организации orgID.
+func (ts *TemplateService) ForOrg(orgID int) *TemplateService {
+	c := *ts
+	c.store = ts.store.Tenant(orgID)
+	return &c
+}
+
+func (ts *TemplateService) Create(t *models.NotificationTemplate) error {
+	if err := validateTemplate(t); err != nil {
+		return err
+	}
+	if err := ts.store.Template().Create(t); err != nil {
+		return err
+	}
+	ts.logger.Infof(ts.ctx, "Template %q created", t.Key)
+	return nil
+}
+
+func (ts *TemplateService) Templates() ([]*models.NotificationTemplate, error) {
+	return ts.store.Template().Get()
+}
+
+func (ts *TemplateService) Template(key string) (*models.NotificationTemplate, error) {
+	t, err := ts.store.Template().GetByKey(key)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrTemplateNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Update заменяет шаблон с ключом t.Key целиком.
+func (ts *TemplateService) Update(t *models.NotificationTemplate) error {
+	if err := validateTemplate(t); err != nil {
+		return err
+	}
+	if err := ts.store.Template().Update(t); err == sql.ErrNoRows {
+		return domain.ErrTemplateNotFound
+	} else if err != nil {
+		return err
+	}
+	ts.logger.Infof(ts.ctx, "Template %q updated", t.Key)
+	return nil
+}
+
+func (ts *TemplateService) Delete(key string) error {
+	if err := ts.store.Template().Delete(key); err == sql.ErrNoRows {
+		return domain.ErrTemplateNotFound
+	} else if err != nil {
+		return err
+	}
+	ts.logger.Infof(ts.ctx, "Template %q deleted", key)
+	return nil
+}
+
+// Resolve возвращает содержимое публикации: уведомление по шаблону key, если
+// он задан, иначе готовое data.
+func (ts *TemplateService) Resolve(key, locale string, vars map[string]interface{}, data *models.NotificationData) (NotificationContent, error) {
+	if key == "" {
+		if data == nil {
+			data = &models.NotificationData{}
+		}
+		if err := ValidateNotificationData(data); err != nil {
+			return nil, err
+		}
+		return StaticContent(*data), nil
+	}
+	if data != nil {
+		return nil, domain.ErrTemplateContentConflict
+	}
+	return ts.Content(key, locale, vars)
+}
+
+// Content отрисовывает шаблон key со значениями vars на всех его языках и
+// возвращает содержимое для получателя: на языке locale, если он задан, иначе
+// на языке из настроек получателя.
+func (ts *TemplateService) Content(key, locale string, vars map[string]interface{}) (NotificationContent, error) {
+	t, err := ts.Template(key)
+	if err != nil {
+		return nil, err
+	}
+	values, err := templateValues(t.Variables, vars)
+	if err != nil {
+		return nil, err
+	}
+	rendered := make(map[string]models.NotificationData, len(t.Locales))
+	for loc := range t.Locales {
+		d, err := renderTemplate(t, loc, values)
+		if err != nil {
+			return nil, err
+		}
+		if err := ValidateNotificationData(&d); err != nil {
+			return nil, err
+		}
+		rendered[loc] = d
+	}
+
+	locale = normalizeLocale(locale)
+	return func(u *models.User) models.NotificationData {
+		loc := locale
+		if loc == "" {
+			if p, err := ts.store.NotificationPreferences().Get(u.ID); err == nil {
+				loc = p.Locale
+			}
+		}
+		return rendered[resolveLocale(t, loc)]
+	}, nil
+}
+
+// Preview отрисовывает шаблон key на языке locale. Переменные, которых нет в
+// vars, получают примерные значения. Результат не проверяется так строго, как
+// при публикации.
+func (ts *TemplateService) Preview(key, locale string, vars map[string]interface{}) (*models.TemplatePreview, error) {
+	t, err := ts.Template(key)
+	if err != nil {
+		return nil, err
+	}
+	sample := make(map[string]interface{}, len(t.Variables))
+	for _, v := range t.Variables {
+		sample[v.Name] = sampleValue(v)
+	}
+	for name, v := range vars {
+		sample[name] = v
+	}
+	values, err := templateValues(t.Variables, sample)
+	if err != nil {
+		return nil, err
+	}
+	loc := resolveLocale(t, normalizeLocale(locale))
+	d, err := renderTemplate(t, loc, values)
+	if err != nil {
+		return nil, err
+	}
+	return &models.TemplatePreview{Locale: loc, Notification: d}, nil
+}
+
+func validateTemplate(t *models.NotificationTemplate) error {
+	t.Category = strings.ToLower(strings.TrimSpace(t.Category))
+	if t.Category == "" {
+		t.Category = DefaultNotificationCategory
+	}
+	t.Priority = strings.ToLower(strings.TrimSpace(t.Priority))
+	if t.Priority == "" {
+		t.Priority = models.PriorityNormal
+	}
+	t.DefaultLocale = normalizeLocale(t.DefaultLocale)
+	locales := make(map[string]models.TemplateContent, len(t.Locales))
+	for loc, c := range t.Locales {
+		locales[normalizeLocale(loc)] = c
+	}
+	t.Locales = locales
+	if t.Variables == nil {
+		t.Variables = []models.TemplateVariable{}
+	}
+
+	if err := validation.ValidateStruct(t,
+		validation.Field(&t.Key, validation.Required, validation.Length(1, 100), validation.Match(templateKeyRe)),
+		validation.Field(&t.Description, validation.Length(0, 500)),
+		validation.Field(&t.Category, validation.Length(1, 50)),
+		validation.Field(&t.Priority, validation.In(models.PriorityLow, models.PriorityNormal, models.PriorityHigh)),
+		validation.Field(&t.Icon, validation.Length(0, 200)),
+		validation.Field(&t.Image, validation.Length(0, 2000), is.URL),
+		validation.Field(&t.DefaultLocale, validation.Required, validation.Match(localeRe)),
+		validation.Field(&t.Locales, validation.Required),
+	); err != nil {
+		return err
+	}
+	if _, ok := t.Locales[t.DefaultLocale]; !ok {
+		return fmt.Errorf("%w: no variant for default locale %q", domain.ErrInvalidLocale, t.DefaultLocale)
+	}
+
+	declared := make(map[string]bool, len(t.Variables))
+	for i := range t.Variables {
+		v := &t.Variables[i]
+		if err := validation.ValidateStruct(v,
+			validation.Field(&v.Name, validation.Required, validation.Length(1, 50), validation.Match(variableRe)),
+			validation.Field(&v.Type, validation.Required, validation.In(models.TemplateVarString, models.TemplateVarNumber, models.TemplateVarBool, models.TemplateVarTime)),
+		); err != nil {
+			return err
+		}
+		if declared[v.Name] {
+			return fmt.Errorf("%w: variable %q is declared twice", domain.ErrTemplateVariables, v.Name)
+		}
+		declared[v.Name] = true
+	}
+
+	for loc, c := range t.Locales {
+		if !localeRe.MatchString(loc) {
+			return fmt.Errorf("%w: %q", domain.ErrInvalidLocale, loc)
+		}
+		if err := validation.ValidateStruct(&c,
+			validation.Field(&c.Title, validation.Required, validation.Length(1, 500)),
+			validation.Field(&c.Message, validation.Length(0, 4000)),
+			validation.Field(&c.ActionURL, validation.Length(0, 2000)),
+		); err != nil {
+			return fmt.Errorf("locale %s: %w", loc, err)
+		}
+		for _, src := range []string{c.Title, c.Message, c.ActionURL} {
+			if _, err := parseTemplate(src, declared); err != nil {
+				return fmt.Errorf("locale %s: %w", loc, err)
+			}
+		}
+	}
+	return nil
+}
+
+// parseTemplate разбирает src и проверяет, что шаблон использует только
+// объявленные переменные, разрешённые функции и условия: range, with,
+// template и обращения к полям и методам значений запрещены.
+func parseTemplate(src string, declared map[string]bool) (*template.Template, error) {
+	tmpl, err := template.New("").Funcs(templateFuncs).Option("missingkey=error").Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	if len(tmpl.Templates()) > 1 {
+		return nil, domain.ErrTemplateForbidden
+	}
+	if tmpl.Tree == nil {
+		return tmpl, nil
+	}
+	if err := checkTemplateNode(tmpl.Tree.Root, declared); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+func checkTemplateNode(node parse.Node, declared map[string]bool) error {
+	switch n := node.(type) {
+	case nil:
+		return nil
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, c := range n.Nodes {
+			if err := checkTemplateNode(c, declared); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *parse.TextNode, *parse.CommentNode:
+		return nil
+	case *parse.ActionNode:
+		return checkTemplatePipe(n.Pipe, declared)
+	case *parse.IfNode:
+		if err := checkTemplatePipe(n.Pipe, declared); err != nil {
+			return err
+		}
+		if err := checkTemplateNode(n.List, declared); err != nil {
+			return err
+		}
+		return checkTemplateNode(n.ElseList, declared)
+	}
+	return fmt.Errorf("%w: %s", domain.ErrTemplateForbidden, node)
+}
+
+func checkTemplatePipe(pipe *parse.PipeNode, declared map[string]bool) error {
+	if pipe == nil {
+		return nil
+	}
+	for _, cmd := range pipe.Cmds {
+		for _, arg := range cmd.Args {
+			switch a := arg.(type) {
+			case *parse.IdentifierNode:
+				if !allowedTemplateFuncs[a.Ident] && templateFuncs[a.Ident] == nil {
+					return fmt.Errorf("%w: function %s", domain.ErrTemplateForbidden, a.Ident)
+				}
+			case *parse.FieldNode:
+				if len(a.Ident) != 1 || !declared[a.Ident[0]] {
+					return fmt.Errorf("%w: undeclared variable %s", domain.ErrTemplateVariables, a)
+				}
+			case *parse.VariableNode:
+				if a.Ident[0] == "$" && len(a.Ident) > 1 && (len(a.Ident) != 2 || !declared[a.Ident[1]]) {
+					return fmt.Errorf("%w: undeclared variable %s", domain.ErrTemplateVariables, a)
+				} else if a.Ident[0] != "$" && len(a.Ident) > 1 {
+					return fmt.Errorf("%w: %s", domain.ErrTemplateForbidden, a)
+				}
+			case *parse.PipeNode:
+				if err := checkTemplatePipe(a, declared); err != nil {
+					return err
+				}
+			case *parse.StringNode, *parse.NumberNode, *parse.BoolNode, *parse.NilNode, *parse.DotNode:
+			default:
+				return fmt.Errorf("%w: %s", domain.ErrTemplateForbidden, arg)
+			}
+		}
+	}
+	return nil
+}
+
+// templateValues проверяет значения переменных по объявлению шаблона и
+// приводит их к типам, с которыми работает шаблон. Необязательные переменные
+// без значения получают нулевое значение своего типа.
+func templateValues(declared []models.TemplateVariable, vars map[string]interface{}) (map[string]interface{}, error) {
+	values := make(map[string]interface{}, len(declared))
+	known := make(map[string]bool, len(declared))
+	for _, v := range declared {
+		known[v.Name] = true
+		raw, ok := vars[v.Name]
+		if !ok || raw == nil {
+			if v.Required {
+				return nil, fmt.Errorf("%w: %s is required", domain.ErrTemplateVariables, v.Name)
+			}
+			raw = nil
+		}
+		value, err := templateValue(v.Type, raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s must be %s", domain.ErrTemplateVariables, v.Name, v.Type)
+		}
+		values[v.Name] = value
+	}
+	for name := range vars {
+		if !known[name] {
+			return nil, fmt.Errorf("%w: unknown variable %s", domain.ErrTemplateVariables, name)
+		}
+	}
+	return values, nil
+}
+
+func templateValue(typ string, raw interface{}) (interface{}, error) {
+	switch typ {
+	case models.TemplateVarString:
+		if raw == nil {
+			return "", nil
+		}
+		if s, ok := raw.(string); ok {
+			return s, nil
+		}
+	case models.TemplateVarNumber:
+		switch n := raw.(type) {
+		case nil:
+			return float64(0), nil
+		case float64:
+			return n, nil
+		case int:
+			return float64(n), nil
+		case int64:
+			return float64(n), nil
+		}
+	case models.TemplateVarBool:
+		if raw == nil {
+			return false, nil
+		}
+		if b, ok := raw.(bool); ok {
+			return b, nil
+		}
+	case models.TemplateVarTime:
+		switch t := raw.(type) {
+		case nil:
+			return time.Time{}, nil
+		case time.Time:
+			return t, nil
+		case string:
+			return time.Parse(time.RFC3339, t)
+		}
+	}
+	return nil, domain.ErrTemplateVariables
+}
+
+func sampleValue(v models.TemplateVariable) interface{} {
+	switch v.Type {
+	case models.TemplateVarNumber:
+		return float64(42)
+	case models.TemplateVarBool:
+		return true
+	case models.TemplateVarTime:
+		return time.Now().UTC().Format(time.RFC3339)
+	}
+	return "[" + v.Name + "]"
+}
+
+// renderTemplate отрисовывает вариант шаблона на языке locale.
+func renderTemplate(t *models.NotificationTemplate, locale string, values map[string]interface{}) (models.NotificationData, error) {
+	declared := make(map[string]bool, len(t.Variables))
+	for _, v := range t.Variables {
+		declared[v.Name] = true
+	}
+	c := t.Locales[locale]
+	var out [3]string
+	for i, src := range []string{c.Title, c.Message, c.ActionURL} {
+		tmpl, err := parseTemplate(src, declared)
+		if err != nil {
+			return models.NotificationData{}, err
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, values); err != nil {
+			return models.NotificationData{}, err
+		}
+		out[i] = buf.String()
+	}
+	return models.NotificationData{
+		Title:     out[0],
+		Message:   out[1],
+		Category:  t.Category,
+		Priority:  t.Priority,
+		Icon:      t.Icon,
+		ActionURL: out[2],
+		Image:     t.Image,
+	}, nil
+}
+
+// resolveLocale выбирает вариант шаблона для locale: точное совпадение, затем
+// основной язык (pt для pt-br), затем язык шаблона по умолчанию.
+func resolveLocale(t *models.NotificationTemplate, locale string) string {
+	if _, ok := t.Locales[locale]; ok {
+		return locale
+	}
+	if base, _, ok := strings.Cut(locale, "-"); ok {
+		if _, ok := t.Locales[base]; ok {
+			return base
+		}
+	}
+	return t.DefaultLocale
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+func validateLocale(value interface{}) error {
+	locale, _ := value.(string)
+	if locale != "" && !localeRe.MatchString(locale) {
+		return domain.ErrInvalidLocale
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/services"
+	"github.com/DANazavr/RATest/internal/store/sqlstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateService_Sandbox(t *testing.T) {
+	// Шаблон проверяется до обращения к хранилищу
+	ts := services.NewTemplateService(t.Context(), log.NewLog(t.Context(), &log.LogConfig{Component: "services", LogLevel: "debug"}), nil)
+
+	testCases := []struct {
+		name  string
+		title string
+	}{
+		{name: "printf width", title: `{{printf "%1000000000d" 1}}`},
+		{name: "range", title: `{{range .name}}{{end}}`},
+		{name: "call", title: `{{call .name}}`},
+		{name: "template", title: `{{template "x"}}`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ts.Create(&models.NotificationTemplate{
+				Key:           "welcome",
+				DefaultLocale: "en",
+				Variables:     []models.TemplateVariable{{Name: "name", Type: models.TemplateVarString}},
+				Locales:       map[string]models.TemplateContent{"en": {Title: tc.title, Message: "hello"}},
+			})
+			assert.ErrorIs(t, err, domain.ErrTemplateForbidden)
+		})
+	}
+}
+
+// newOrderTemplate создаёт в организации по умолчанию шаблон order.shipped с
+// переменными всех типов и вариантами на en (по умолчанию), pt и ru.
+func newOrderTemplate(t *testing.T) *services.TemplateService {
+	t.Helper()
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM notification_templates WHERE key = 'order.shipped'")
+		teardown()
+	})
+
+	logger := log.NewLog(t.Context(), &log.LogConfig{Component: "services", LogLevel: "debug"})
+	ts := services.NewTemplateService(t.Context(), logger, sqlstore.New(t.Context(), db, logger)).ForOrg(domain.DefaultOrgID)
+	require.NoError(t, ts.Create(&models.NotificationTemplate{
+		Key:           "order.shipped",
+		DefaultLocale: "en",
+		Variables: []models.TemplateVariable{
+			{Name: "order", Type: models.TemplateVarString, Required: true},
+			{Name: "items", Type: models.TemplateVarNumber},
+			{Name: "express", Type: models.TemplateVarBool},
+			{Name: "eta", Type: models.TemplateVarTime},
+		},
+		Locales: map[string]models.TemplateContent{
+			"en": {
+				Title:   "Order {{.order}} shipped",
+				Message: `{{.items}} items{{if .express}}, express{{end}}, arrives {{.eta | date "2006-01-02"}}`,
+			},
+			"pt": {Title: "Pedido {{.order}} enviado", Message: "{{.items}} itens"},
+			"ru": {Title: "Заказ {{.order}} отправлен", Message: "{{.items}} шт."},
+		},
+	}))
+	return ts
+}
+
+func TestTemplateService_ContentLocale(t *testing.T) {
+	ts := newOrderTemplate(t)
+	u := &models.User{ID: 1, OrgID: domain.DefaultOrgID}
+
+	testCases := []struct {
+		name   string
+		locale string
+		title  string
+	}{
+		{name: "exact", locale: "ru", title: "Заказ A-17 отправлен"},
+		{name: "region falls back to base", locale: "pt-br", title: "Pedido A-17 enviado"},
+		{name: "normalized", locale: "PT_BR", title: "Pedido A-17 enviado"},
+		{name: "unknown falls back to default", locale: "de", title: "Order A-17 shipped"},
+		{name: "unknown region and base", locale: "de-at", title: "Order A-17 shipped"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content, err := ts.Content("order.shipped", tc.locale, map[string]interface{}{"order": "A-17"})
+			require.NoError(t, err)
+			assert.Equal(t, tc.title, content(u).Title)
+		})
+	}
+}
+
+func TestTemplateService_ContentVariables(t *testing.T) {
+	ts := newOrderTemplate(t)
+	u := &models.User{ID: 1, OrgID: domain.DefaultOrgID}
+
+	testCases := []struct {
+		name    string
+		vars    map[string]interface{}
+		message string
+		err     error
+	}{
+		{
+			name:    "all types",
+			vars:    map[string]interface{}{"order": "A-17", "items": float64(3), "express": true, "eta": "2025-08-01T09:00:00Z"},
+			message: "3 items, express, arrives 2025-08-01",
+		},
+		{
+			name:    "int number and time value",
+			vars:    map[string]interface{}{"order": "A-17", "items": 2, "eta": time.Date(2025, 8, 2, 0, 0, 0, 0, time.UTC)},
+			message: "2 items, arrives 2025-08-02",
+		},
+		{
+			name:    "optional variables get zero values",
+			vars:    map[string]interface{}{"order": "A-17"},
+			message: "0 items, arrives 0001-01-01",
+		},
+		{name: "missing required", vars: map[string]interface{}{"items": 1}, err: domain.ErrTemplateVariables},
+		{name: "required is nil", vars: map[string]interface{}{"order": nil}, err: domain.ErrTemplateVariables},
+		{name: "number as string", vars: map[string]interface{}{"order": "A-17", "items": "3"}, err: domain.ErrTemplateVariables},
+		{name: "bool as string", vars: map[string]interface{}{"order": "A-17", "express": "yes"}, err: domain.ErrTemplateVariables},
+		{name: "time not RFC 3339", vars: map[string]interface{}{"order": "A-17", "eta": "01.08.2025"}, err: domain.ErrTemplateVariables},
+		{name: "string as number", vars: map[string]interface{}{"order": 17}, err: domain.ErrTemplateVariables},
+		{name: "unknown variable", vars: map[string]interface{}{"order": "A-17", "coupon": "X"}, err: domain.ErrTemplateVariables},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content, err := ts.Content("order.shipped", "en", tc.vars)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			d := content(u)
+			assert.Equal(t, "Order A-17 shipped", d.Title)
+			assert.Equal(t, tc.message, d.Message)
+		})
+	}
+}
+
+func TestTemplateService_Preview(t *testing.T) {
+	ts := newOrderTemplate(t)
+
+	testCases := []struct {
+		name   string
+		locale string
+		vars   map[string]interface{}
+		want   string
+		title  string
+		err    error
+	}{
+		{name: "sample values", locale: "en", want: "en", title: "Order [order] shipped"},
+		{name: "given values", locale: "pt-br", vars: map[string]interface{}{"order": "A-17"}, want: "pt", title: "Pedido A-17 enviado"},
+		{name: "default locale", locale: "", want: "en", title: "Order [order] shipped"},
+		{name: "wrong type", locale: "en", vars: map[string]interface{}{"items": "many"}, err: domain.ErrTemplateVariables},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := ts.Preview("order.shipped", tc.locale, tc.vars)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, p.Locale)
+			assert.Equal(t, tc.title, p.Notification.Title)
+		})
+	}
+
+	_, err := ts.Preview("missing", "en", nil)
+	assert.ErrorIs(t, err, domain.ErrTemplateNotFound)
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+)
+
+// Поля, по которым сортируется список пользователей
+var userSortFields = map[string]func(*models.User) string{
+	"id":         func(u *models.User) string { return "" },
+	"username":   func(u *models.User) string { return u.Username },
+	"email":      func(u *models.User) string { return u.Email },
+	"created_at": func(u *models.User) string { return u.CreatedAt },
+}
+
+// userCursor - содержимое курсора страницы. Сортировка сохраняется в курсоре,
+// чтобы курсор нельзя было применить к другому порядку.
+type userCursor struct {
+	SortBy string `json:"s"`
+	Desc   bool   `json:"d,omitempty"`
+	Value  string `json:"v,omitempty"`
+	ID     int    `json:"id"`
+}
+
+func encodeUserCursor(f *models.UserFilter, last *models.User) string {
+	b, _ := json.Marshal(userCursor{
+		SortBy: f.SortBy,
+		Desc:   f.Desc,
+		Value:  userSortFields[f.SortBy](last),
+		ID:     last.ID,
+	})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeUserCursor(cursor string, f *models.UserFilter) (*models.UserCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+	var c userCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+	if c.SortBy != f.SortBy || c.Desc != f.Desc {
+		return nil, domain.ErrInvalidCursor
+	}
+	return &models.UserCursor{Value: c.Value, ID: c.ID}, nil
+}
+
+// ParseFilterTime разбирает границу фильтра по дате создания в RFC 3339.
+// Пустая строка - граница не задана.
+func ParseFilterTime(v string) (*time.Time, error) {
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -2,7 +2,10 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
 	"database/sql"
+	"strings"
+	"time"
 
 	"github.com/DANazavr/RATest/internal/domain"
 	"github.com/DANazavr/RATest/internal/domain/models"
@@ -10,23 +13,32 @@ import (
 	"github.com/DANazavr/RATest/internal/store"
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/go-ozzo/ozzo-validation/is"
-	"golang.org/x/crypto/bcrypt"
 )
 
 type UserService struct {
 	ctx    context.Context
 	logger *log.Log
 	store  store.Store
+	hasher *PasswordHasher
 }
 
-func NewUserService(ctx context.Context, store store.Store, logger *log.Log) *UserService {
+func NewUserService(ctx context.Context, store store.Store, logger *log.Log, hasher *PasswordHasher) *UserService {
 	return &UserService{
 		ctx:    ctx,
 		store:  store,
 		logger: logger.WithComponent("services/user"),
+		hasher: hasher,
 	}
 }
 
+// ForOrg возвращает копию сервиса, которая видит только пользователей
+// организации orgID.
+func (us *UserService) ForOrg(orgID int) *UserService {
+	c := *us
+	c.store = us.store.Tenant(orgID)
+	return &c
+}
+
 func (us *UserService) UsersCreate(ctx context.Context, user *models.User) error {
 	if err := us.Validate(user); err != nil {
 		return err
@@ -41,6 +53,66 @@ func (us *UserService) UsersCreate(ctx context.Context, user *models.User) error
 	return nil
 }
 
+// UsersCreateByInvite создаёт пользователя с ролью из приглашения inv и
+// погашает приглашение. Если в приглашении указан email, он должен совпасть.
+func (us *UserService) UsersCreateByInvite(ctx context.Context, user *models.User, inv *models.Invite) error {
+	if inv.Email != "" && !strings.EqualFold(inv.Email, user.Email) {
+		return domain.ErrInviteEmailMismatch
+	}
+	user.Role = inv.Role
+	if err := us.Validate(user); err != nil {
+		return err
+	}
+	if err := us.BeforeCreate(user); err != nil {
+		return err
+	}
+	if err := us.store.Invite().Accept(inv.ID, user); err != nil {
+		return err
+	}
+	us.logger.Infof(ctx, "User %d registered with role %s by invite %s", user.ID, user.Role, inv.ID)
+	us.Sanitize(user)
+	return nil
+}
+
+// UsersCreateExternal создаёт пользователя без пароля, вошедшего через
+// внешнего провайдера, и привязывает к нему учётную запись identity.
+// Если имя занято, возвращает domain.ErrUserAlreadyExists.
+func (us *UserService) UsersCreateExternal(ctx context.Context, user *models.User, identity *models.ExternalIdentity) error {
+	if err := validation.ValidateStruct(user,
+		validation.Field(&user.Username, validation.Required, validation.Length(3, 20)),
+		validation.Field(&user.Email, validation.Required, is.Email),
+		validation.Field(&user.Role, validation.Required, validation.By(us.validateRole)),
+	); err != nil {
+		return err
+	}
+	if _, err := us.store.User().GetByUsername(user.Username); err == nil {
+		return domain.ErrUserAlreadyExists
+	} else if err != sql.ErrNoRows {
+		return err
+	}
+	return us.store.ExternalIdentity().CreateWithUser(identity, user)
+}
+
+// BootstrapAdmin создаёт первого суперадминистратора в организации по
+// умолчанию. Если незаблокированный суперадминистратор уже есть, возвращает
+// domain.ErrAdminAlreadyExists.
+func (us *UserService) BootstrapAdmin(ctx context.Context, user *models.User) error {
+	n, err := us.store.User().CountByRole(domain.RoleSuperAdmin)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return domain.ErrAdminAlreadyExists
+	}
+	user.Role = domain.RoleSuperAdmin
+	user.OrgID = domain.DefaultOrgID
+	if err := us.UsersCreate(ctx, user); err != nil {
+		return err
+	}
+	// Адрес администратора, созданного из консоли, считается подтверждённым
+	return us.store.User().SetEmailVerified(user.ID)
+}
+
 func (us *UserService) UsersGetByUsername(username string) (*models.User, error) {
 	user, err := us.store.User().GetByUsername(username)
 	if err == sql.ErrNoRows {
@@ -80,6 +152,232 @@ func (us *UserService) UsersGet() ([]*models.User, error) {
 	return user, nil
 }
 
+const (
+	defaultUserPageSize = 50
+	maxUserPageSize     = 500
+)
+
+// UsersList возвращает страницу пользователей по фильтру f. cursor - значение
+// NextCursor предыдущей страницы, пустой cursor начинает с первой.
+func (us *UserService) UsersList(f *models.UserFilter, cursor string) (*models.UserPage, error) {
+	if f.SortBy == "" {
+		f.SortBy = "id"
+	}
+	if _, ok := userSortFields[f.SortBy]; !ok {
+		return nil, domain.ErrInvalidFilter
+	}
+	if f.Limit <= 0 {
+		f.Limit = defaultUserPageSize
+	}
+	if f.Limit > maxUserPageSize {
+		f.Limit = maxUserPageSize
+	}
+	if cursor != "" {
+		after, err := decodeUserCursor(cursor, f)
+		if err != nil {
+			return nil, err
+		}
+		f.After = after
+	}
+
+	total, err := us.store.User().Count(f)
+	if err != nil {
+		return nil, err
+	}
+	// Лишняя строка показывает, есть ли следующая страница
+	limit := f.Limit
+	f.Limit++
+	users, err := us.store.User().List(f)
+	f.Limit = limit
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.UserPage{Users: users, Total: total}
+	if len(users) > limit {
+		page.Users = users[:limit]
+		page.NextCursor = encodeUserCursor(f, page.Users[limit-1])
+	}
+	return page, nil
+}
+
+// Сколько после входа пользователь без пароля может менять учётную запись
+const reauthenticationWindow = time.Minute * 5
+
+// Reauthenticate проверяет текущий пароль пользователя перед изменением
+// учётной записи. У пользователя без пароля (вход только через провайдера
+// OIDC) вместо пароля требуется свежий вход: семейство сессий sessionID должно
+// быть начато не раньше reauthenticationWindow назад, иначе возвращается
+// domain.ErrReauthenticationRequired.
+func (us *UserService) Reauthenticate(u *models.User, password string, sessionID string) error {
+	if u.EncryptedPassword == "" {
+		age, err := us.store.Session().FamilyAge(sessionID, u.ID)
+		if err == sql.ErrNoRows || err == nil && age > reauthenticationWindow {
+			return domain.ErrReauthenticationRequired
+		} else if err != nil {
+			return err
+		}
+		return nil
+	}
+	if password == "" || !us.ComparePassword(u, password) {
+		return domain.ErrIncorrectPassword
+	}
+	return nil
+}
+
+// ChangePassword меняет пароль после проверки текущего и завершает все сессии
+// пользователя.
+func (us *UserService) ChangePassword(ctx context.Context, u *models.User, current string, password string, sessionID string) error {
+	if err := us.Reauthenticate(u, current, sessionID); err != nil {
+		return err
+	}
+	if err := validation.Validate(password, validation.Required, validation.Length(6, 20)); err != nil {
+		return err
+	}
+	enc, err := us.encryptString(password)
+	if err != nil {
+		return err
+	}
+	if err := us.store.User().UpdatePassword(u.ID, enc); err != nil {
+		return err
+	}
+	if err := us.store.Session().RevokeByUserId(u.ID); err != nil {
+		us.logger.Errorf(ctx, "Failed to revoke sessions of user %d after password change: %v", u.ID, err)
+		return err
+	}
+	us.logger.Infof(ctx, "Password of user %d changed, all sessions revoked", u.ID)
+	return nil
+}
+
+// DeleteAccount удаляет учётную запись после проверки пароля. Сессии и
+// уведомления пользователя удаляются вместе с ней.
+func (us *UserService) DeleteAccount(ctx context.Context, u *models.User, password string, sessionID string) error {
+	if err := us.Reauthenticate(u, password, sessionID); err != nil {
+		return err
+	}
+	if err := us.ensureNotLastAdmin(u); err != nil {
+		return err
+	}
+	if err := us.store.User().Delete(u.ID); err == sql.ErrNoRows {
+		return domain.ErrUserNotFound
+	} else if err != nil {
+		return err
+	}
+	us.logger.Infof(ctx, "User %d deleted their account", u.ID)
+	return nil
+}
+
+// CheckActive возвращает domain.ErrUserDisabled, если пользователь заблокирован.
+func (us *UserService) CheckActive(u *models.User) error {
+	if u.DisabledAt != nil {
+		return domain.ErrUserDisabled
+	}
+	return nil
+}
+
+// ChangeRole назначает пользователю id роль role и завершает его сессии:
+// роль записана в уже выданных токенах.
+func (us *UserService) ChangeRole(ctx context.Context, id int, role string) (*models.User, error) {
+	u, err := us.UsersGetById(id)
+	if err != nil {
+		return nil, err
+	}
+	if u.Role == domain.RoleSuperAdmin || role == domain.RoleSuperAdmin {
+		return nil, domain.ErrSuperAdminRole
+	}
+	if err := us.validateRole(role); err != nil {
+		return nil, err
+	}
+	if u.Role == role {
+		return u, nil
+	}
+	if err := us.ensureNotLastAdmin(u); err != nil {
+		return nil, err
+	}
+	u.Role = role
+	if err := us.store.User().Update(u); err != nil {
+		return nil, err
+	}
+	if err := us.store.Session().RevokeByUserId(u.ID); err != nil {
+		us.logger.Errorf(ctx, "Failed to revoke sessions of user %d after role change: %v", u.ID, err)
+		return nil, err
+	}
+	us.logger.Infof(ctx, "Role of user %d changed to %s", u.ID, role)
+	return u, nil
+}
+
+// SetDisabled блокирует или разблокирует пользователя id. Заблокированный
+// пользователь не может войти, а его сессии завершаются.
+func (us *UserService) SetDisabled(ctx context.Context, id int, disabled bool) (*models.User, error) {
+	u, err := us.UsersGetById(id)
+	if err != nil {
+		return nil, err
+	}
+	if u.Role == domain.RoleSuperAdmin {
+		return nil, domain.ErrSuperAdminRole
+	}
+	if disabled == (u.DisabledAt != nil) {
+		return u, nil
+	}
+	if disabled {
+		if err := us.ensureNotLastAdmin(u); err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		u.DisabledAt = &now
+	} else {
+		u.DisabledAt = nil
+	}
+	if err := us.store.User().Update(u); err != nil {
+		return nil, err
+	}
+	if disabled {
+		if err := us.store.Session().RevokeByUserId(u.ID); err != nil {
+			us.logger.Errorf(ctx, "Failed to revoke sessions of disabled user %d: %v", u.ID, err)
+			return nil, err
+		}
+	}
+	us.logger.Infof(ctx, "User %d disabled: %t", u.ID, disabled)
+	return u, nil
+}
+
+// DeleteUser удаляет пользователя id вместе с его сессиями и уведомлениями.
+func (us *UserService) DeleteUser(ctx context.Context, id int) error {
+	u, err := us.UsersGetById(id)
+	if err != nil {
+		return err
+	}
+	if u.Role == domain.RoleSuperAdmin {
+		return domain.ErrSuperAdminRole
+	}
+	if err := us.ensureNotLastAdmin(u); err != nil {
+		return err
+	}
+	if err := us.store.User().Delete(u.ID); err == sql.ErrNoRows {
+		return domain.ErrUserNotFound
+	} else if err != nil {
+		return err
+	}
+	us.logger.Infof(ctx, "User %d deleted", u.ID)
+	return nil
+}
+
+// ensureNotLastAdmin не даёт удалить, заблокировать или понизить
+// единственного администратора организации или единственного суперадмина.
+func (us *UserService) ensureNotLastAdmin(u *models.User) error {
+	if u.Role != domain.RoleAdmin && u.Role != domain.RoleSuperAdmin || u.DisabledAt != nil {
+		return nil
+	}
+	n, err := us.store.User().CountByRole(u.Role)
+	if err != nil {
+		return err
+	}
+	if n <= 1 {
+		return domain.ErrLastAdmin
+	}
+	return nil
+}
+
 func (us *UserService) Validate(u *models.User) error {
 	return validation.ValidateStruct(u, validation.Field(&u.Username, validation.Required, validation.Length(3, 20)),
 		validation.Field(&u.Password, validation.By(func(cond bool) validation.RuleFunc {
@@ -91,15 +389,27 @@ func (us *UserService) Validate(u *models.User) error {
 			}
 		}((u.EncryptedPassword == ""))), validation.Length(6, 20)),
 		validation.Field(&u.Email, validation.Required, is.Email),
-		validation.Field(&u.Role, validation.Required, validation.In("user", "admin")),
+		validation.Field(&u.Role, validation.Required, validation.By(us.validateRole)),
 	)
 }
 
+func (us *UserService) validateRole(value interface{}) error {
+	role, _ := value.(string)
+	exists, err := us.store.Role().Exists(role)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return domain.ErrUnknownRole
+	}
+	return nil
+}
+
 func (us *UserService) BeforeCreate(u *models.User) error {
 	if len(u.Password) > 0 {
 		enc, err := us.encryptString(u.Password)
 		if err != nil {
-			return nil
+			return err
 		}
 		u.EncryptedPassword = enc
 	}
@@ -110,14 +420,163 @@ func (us *UserService) Sanitize(u *models.User) {
 	u.Password = ""
 }
 
+// ComparePassword проверяет пароль пользователя. Если хеш построен с
+// устаревшими параметрами, он пересчитывается по текущей конфигурации.
 func (us *UserService) ComparePassword(u *models.User, password string) bool {
-	return bcrypt.CompareHashAndPassword([]byte(u.EncryptedPassword), []byte(password)) == nil
+	ok, rehash := us.hasher.Verify(u.EncryptedPassword, password)
+	if !ok {
+		return false
+	}
+	if rehash {
+		enc, err := us.encryptString(password)
+		if err != nil {
+			us.logger.Errorf(us.ctx, "Failed to rehash password for user %d: %v", u.ID, err)
+			return true
+		}
+		replaced, err := us.store.User().ReplacePassword(u.ID, u.EncryptedPassword, enc)
+		if err != nil {
+			us.logger.Errorf(us.ctx, "Failed to store rehashed password for user %d: %v", u.ID, err)
+			return true
+		}
+		if !replaced {
+			us.logger.Infof(us.ctx, "Password of user %d changed concurrently, rehash skipped", u.ID)
+			return true
+		}
+		u.EncryptedPassword = enc
+		us.logger.Infof(us.ctx, "Password hash upgraded for user %d", u.ID)
+	}
+	return true
 }
 
 func (us *UserService) encryptString(s string) (string, error) {
-	b, err := bcrypt.GenerateFromPassword([]byte(s), bcrypt.MinCost)
+	return us.hasher.Hash(s)
+}
+
+const recoveryCodeCount = 10
+
+// MFARequired сообщает, обязан ли пользователь входить со вторым фактором.
+func (us *UserService) MFARequired(u *models.User) bool {
+	return u.Role == domain.RoleAdmin || u.Role == domain.RoleSuperAdmin
+}
+
+// MFAEnabled сообщает, подтвердил ли пользователь второй фактор.
+func (us *UserService) MFAEnabled(u *models.User) (bool, error) {
+	t, err := us.store.TOTP().GetByUserId(u.ID)
+	if err == sql.ErrNoRows {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return t.ConfirmedAt != nil, nil
+}
+
+// EnrollTOTP выдаёт новый секрет TOTP и otpauth ссылку для него. Второй
+// фактор включается только после ConfirmTOTP с кодом из приложения.
+func (us *UserService) EnrollTOTP(u *models.User) (string, string, error) {
+	secret, err := newTOTPSecret()
 	if err != nil {
+		return "", "", err
+	}
+	if err := us.store.TOTP().Create(&models.TOTP{UserID: u.ID, Secret: secret}); err != nil {
+		return "", "", err
+	}
+	return secret, totpURI(u.Username, secret), nil
+}
+
+// ConfirmTOTP включает второй фактор по первому коду из приложения и
+// возвращает новые коды восстановления. Они показываются один раз.
+func (us *UserService) ConfirmTOTP(u *models.User, code string) ([]string, error) {
+	t, err := us.store.TOTP().GetByUserId(u.ID)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrMFANotEnabled
+	} else if err != nil {
+		return nil, err
+	}
+	if t.ConfirmedAt != nil {
+		return nil, domain.ErrMFAAlreadyEnabled
+	}
+	step, ok := matchTOTP(t.Secret, code, time.Now())
+	if !ok {
+		return nil, domain.ErrInvalidMFACode
+	}
+
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		c, err := newRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, c)
+		hashes = append(hashes, hashToken(normalizeRecoveryCode(c)))
+	}
+	if err := us.store.TOTP().Confirm(u.ID, step, hashes); err != nil {
+		return nil, err
+	}
+	us.logger.Infof(us.ctx, "Two-factor authentication enabled for user %d", u.ID)
+	return codes, nil
+}
+
+// VerifyMFA проверяет код из приложения или код восстановления. Каждый код
+// принимается только один раз.
+func (us *UserService) VerifyMFA(u *models.User, code string) error {
+	t, err := us.store.TOTP().GetByUserId(u.ID)
+	if err == sql.ErrNoRows {
+		return domain.ErrMFANotEnabled
+	} else if err != nil {
+		return err
+	}
+	if t.ConfirmedAt == nil {
+		return domain.ErrMFANotEnabled
+	}
+	if step, ok := matchTOTP(t.Secret, code, time.Now()); ok {
+		return us.store.TOTP().UseStep(u.ID, step)
+	}
+	if err := us.store.TOTP().UseRecoveryCode(u.ID, hashToken(normalizeRecoveryCode(code))); err != nil {
+		return err
+	}
+	us.logger.Warnf(us.ctx, "User %d signed in with a recovery code", u.ID)
+	return nil
+}
+
+// DisableTOTP отключает второй фактор после проверки кода. Для ролей, где
+// второй фактор обязателен, возвращает domain.ErrMFARequired.
+func (us *UserService) DisableTOTP(u *models.User, code string) error {
+	if us.MFARequired(u) {
+		return domain.ErrMFARequired
+	}
+	if err := us.VerifyMFA(u, code); err != nil {
+		return err
+	}
+	return us.store.TOTP().Delete(u.ID)
+}
+
+func newRecoveryCode() (string, error) {
+	b := make([]byte, 5)
+	if _, err := rand.Read(b); err != nil {
 		return "", err
 	}
-	return string(b), nil
+	c := strings.ToLower(totpEncoding.EncodeToString(b))
+	return c[:4] + "-" + c[4:], nil
+}
+
+func normalizeRecoveryCode(c string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(c))
+}
+
+// CompleteMFA проверяет код второго шага входа. Если второй фактор обязателен,
+// но ещё не подключён, code подтверждает подключение и возвращаются коды
+// восстановления.
+func (us *UserService) CompleteMFA(u *models.User, code string) ([]string, error) {
+	enabled, err := us.MFAEnabled(u)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return nil, us.VerifyMFA(u, code)
+	}
+	if us.MFARequired(u) {
+		return us.ConfirmTOTP(u, code)
+	}
+	return nil, domain.ErrMFANotEnabled
 }
@@ -0,0 +1,64 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/services"
+	"github.com/DANazavr/RATest/internal/store/sqlstore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserService_DeleteAccountWithoutPassword(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("sessions", "users")
+
+	logger := log.NewLog(t.Context(), &log.LogConfig{Component: "services", LogLevel: "debug"})
+	s := sqlstore.New(t.Context(), db, logger)
+	us := services.NewUserService(t.Context(), s, logger, nil)
+
+	testCases := []struct {
+		name     string
+		role     string
+		loggedIn time.Duration
+		err      error
+	}{
+		{name: "fresh login", role: domain.RoleUser, loggedIn: 0},
+		{name: "stale login", role: domain.RoleUser, loggedIn: time.Hour, err: domain.ErrReauthenticationRequired},
+		{name: "no session", role: domain.RoleUser, loggedIn: -1, err: domain.ErrReauthenticationRequired},
+		{name: "only superadmin", role: domain.RoleSuperAdmin, loggedIn: 0, err: domain.ErrLastAdmin},
+	}
+	for i, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Пользователь, созданный при входе через провайдера, без пароля
+			u := &models.User{
+				Username: "ssouser" + string(rune('a'+i)),
+				Email:    string(rune('a'+i)) + "@example.com",
+				Role:     tc.role,
+				OrgID:    domain.DefaultOrgID,
+			}
+			assert.NoError(t, s.User().Create(u))
+			if tc.loggedIn >= 0 {
+				assert.NoError(t, s.Session().Create(&models.Session{
+					ID:        u.Username,
+					FamilyID:  u.Username,
+					UserID:    u.ID,
+					TokenHash: u.Username,
+					ExpiresAt: time.Now().Add(time.Hour),
+				}))
+				_, err := db.Exec("UPDATE sessions SET created_at = NOW() - $2 * INTERVAL '1 second' WHERE id = $1", u.Username, tc.loggedIn.Seconds())
+				assert.NoError(t, err)
+			}
+
+			err := us.DeleteAccount(t.Context(), u, "", u.Username)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -1,12 +1,31 @@
 package store
 
-import "github.com/DANazavr/RATest/internal/domain/models"
+import (
+	"time"
+
+	"github.com/DANazavr/RATest/internal/domain/models"
+)
+
+type OrganizationRepository interface {
+	Create(*models.Organization) error
+	GetById(int) (*models.Organization, error)
+	Get() ([]*models.Organization, error)
+}
 
 type UserRepository interface {
 	Create(*models.User) error
 	GetByUsername(string) (*models.User, error)
 	GetById(int) (*models.User, error)
 	Get() ([]*models.User, error)
+	List(*models.UserFilter) ([]*models.User, error)
+	Count(*models.UserFilter) (int, error)
+	CountByRole(string) (int, error)
+	GetByEmail(string) (*models.User, error)
+	SetEmailVerified(int) error
+	UpdatePassword(int, string) error
+	ReplacePassword(int, string, string) (bool, error)
+	Update(*models.User) error
+	Delete(int) error
 }
 
 type NotificationRepository interface {
@@ -16,4 +35,120 @@ type NotificationRepository interface {
 	GetByUserIdWithFilter(int, string) ([]*models.UserNotification, error)
 	MarkAsSend(int, int) error
 	MarkAsRead(int, int) error
+	Defer(int, time.Time) error
+	ClaimDue(int, time.Duration) ([]*models.UserNotification, error)
+	FinishDue(int, time.Time) error
+	GetScheduled(bool) ([]*models.UserNotification, error)
+	Reschedule(int, time.Time, bool) (*models.UserNotification, error)
+	DeleteScheduled(int, bool) error
+}
+
+type NotificationPreferencesRepository interface {
+	Get(int) (*models.NotificationPreferences, error)
+	Save(*models.NotificationPreferences) error
+}
+
+type SessionRepository interface {
+	Create(*models.Session) error
+	GetById(string) (*models.Session, error)
+	Rotate(string, *models.Session) error
+	RevokeFamily(string) error
+	RevokeByUserId(int) error
+	IsFamilyActive(string) (bool, error)
+	FamilyAge(string, int) (time.Duration, error)
+}
+
+type RoleRepository interface {
+	Exists(string) (bool, error)
+	GetPermissions(string) ([]string, error)
+	PermissionExists(string) (bool, error)
+}
+
+type APIKeyRepository interface {
+	Create(*models.APIKey) error
+	GetByPrefix(string) (*models.APIKey, error)
+	Get() ([]*models.APIKey, error)
+	Revoke(int) (bool, error)
+	MarkUsed(int) error
+}
+
+type InviteRepository interface {
+	Create(*models.Invite) error
+	GetById(string) (*models.Invite, error)
+	Get() ([]*models.Invite, error)
+	Accept(string, *models.User) error
+}
+
+type PasswordResetRepository interface {
+	Create(*models.PasswordReset) error
+	Reset(string, string) (int, error)
+}
+
+type EmailVerificationRepository interface {
+	Create(*models.EmailVerification) error
+	Confirm(string) (int, error)
+	SentSince(int, time.Duration) (bool, error)
+}
+
+type LoginAttemptRepository interface {
+	Create(*models.LoginAttempt) error
+	FailuresByUsername(string, time.Duration) (int, *time.Duration, error)
+	FailuresByIP(string, time.Duration) (int, *time.Duration, error)
+}
+
+type TOTPRepository interface {
+	Create(*models.TOTP) error
+	GetByUserId(int) (*models.TOTP, error)
+	Confirm(int, int64, []string) error
+	UseStep(int, int64) error
+	UseRecoveryCode(int, string) error
+	Delete(int) error
+}
+
+type ExternalIdentityRepository interface {
+	Create(*models.ExternalIdentity) error
+	CreateWithUser(*models.ExternalIdentity, *models.User) error
+	GetByProviderSubject(string, string) (*models.ExternalIdentity, error)
+	TouchLogin(int, string) error
+}
+
+type OIDCStateRepository interface {
+	Create(*models.OIDCState) error
+	Consume(string, string) (*models.OIDCState, error)
+}
+
+type GroupRepository interface {
+	Create(*models.Group) error
+	GetById(int) (*models.Group, error)
+	Get() ([]*models.Group, error)
+	Delete(int) error
+	AddMembers(int, []int) (int, error)
+	RemoveMember(int, int) error
+	Members(int) ([]*models.User, error)
+}
+
+type SegmentRepository interface {
+	Create(*models.Segment) error
+	GetById(int) (*models.Segment, error)
+	Get() ([]*models.Segment, error)
+	Delete(int) error
+}
+
+type DataExportRepository interface {
+	Create(*models.DataExport) error
+	GetById(int) (*models.DataExport, error)
+	GetByUserId(int) ([]*models.DataExport, error)
+	ClaimPending(int, time.Duration) ([]*models.DataExport, error)
+	Complete(int, []byte, time.Time) error
+	Fail(int, string, time.Time) error
+	Archive(int) ([]byte, error)
+	DeleteExpired() (int, error)
+}
+
+type TemplateRepository interface {
+	Create(*models.NotificationTemplate) error
+	GetByKey(string) (*models.NotificationTemplate, error)
+	Get() ([]*models.NotificationTemplate, error)
+	Update(*models.NotificationTemplate) error
+	Delete(string) error
 }
@@ -0,0 +1,82 @@
+package sqlstore
+
+import (
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/lib/pq"
+)
+
+type APIKeyRepository struct {
+	store *Store
+}
+
+func (r *APIKeyRepository) Create(k *models.APIKey) error {
+	r.store.assignOrg(&k.OrgID)
+	if err := r.store.db.QueryRow(
+		"INSERT INTO api_keys (name, prefix, key_hash, scopes, require_signature, org_id, created_by) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, created_at",
+		k.Name, k.Prefix, k.KeyHash, pq.Array(k.Scopes), k.RequireSignature, k.OrgID, k.CreatedBy,
+	).Scan(&k.ID, &k.CreatedAt); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *APIKeyRepository) GetByPrefix(prefix string) (*models.APIKey, error) {
+	k := &models.APIKey{}
+	if err := r.store.db.QueryRow(
+		"SELECT id, name, prefix, key_hash, scopes, require_signature, org_id, created_by, created_at, last_used_at, revoked_at FROM api_keys WHERE prefix = $1 AND ($2::bigint IS NULL OR org_id = $2)", prefix, r.store.org(),
+	).Scan(
+		&k.ID, &k.Name, &k.Prefix, &k.KeyHash, pq.Array(&k.Scopes), &k.RequireSignature, &k.OrgID, &k.CreatedBy, &k.CreatedAt, &k.LastUsedAt, &k.RevokedAt,
+	); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+func (r *APIKeyRepository) Get() ([]*models.APIKey, error) {
+	keys := make([]*models.APIKey, 0, 10)
+	rows, err := r.store.db.Query(
+		"SELECT id, name, prefix, key_hash, scopes, require_signature, org_id, created_by, created_at, last_used_at, revoked_at FROM api_keys WHERE ($1::bigint IS NULL OR org_id = $1) ORDER BY id", r.store.org(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		k := &models.APIKey{}
+		if err := rows.Scan(
+			&k.ID, &k.Name, &k.Prefix, &k.KeyHash, pq.Array(&k.Scopes), &k.RequireSignature, &k.OrgID, &k.CreatedBy, &k.CreatedAt, &k.LastUsedAt, &k.RevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (r *APIKeyRepository) Revoke(id int) (bool, error) {
+	res, err := r.store.db.Exec(
+		"UPDATE api_keys SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL AND ($2::bigint IS NULL OR org_id = $2)", id, r.store.org(),
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (r *APIKeyRepository) MarkUsed(id int) error {
+	_, err := r.store.db.Exec(
+		"UPDATE api_keys SET last_used_at = NOW() WHERE id = $1", id,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/lib/pq"
+)
+
+type GroupRepository struct {
+	store *Store
+}
+
+func (r *GroupRepository) Create(g *models.Group) error {
+	r.store.assignOrg(&g.OrgID)
+	if err := r.store.db.QueryRow(
+		"INSERT INTO groups (name, description, org_id, created_by) VALUES ($1, $2, $3, $4) RETURNING id, created_at",
+		g.Name, g.Description, g.OrgID, g.CreatedBy,
+	).Scan(&g.ID, &g.CreatedAt); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return domain.ErrAudienceNameTaken
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *GroupRepository) GetById(id int) (*models.Group, error) {
+	g := &models.Group{}
+	if err := r.store.db.QueryRow(
+		"SELECT g.id, g.name, g.description, g.org_id, g.created_by, g.created_at, (SELECT COUNT(*) FROM group_members m WHERE m.group_id = g.id) FROM groups g WHERE g.id = $1 AND ($2::bigint IS NULL OR g.org_id = $2)", id, r.store.org(),
+	).Scan(
+		&g.ID, &g.Name, &g.Description, &g.OrgID, &g.CreatedBy, &g.CreatedAt, &g.Members,
+	); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (r *GroupRepository) Get() ([]*models.Group, error) {
+	groups := make([]*models.Group, 0, 10)
+	rows, err := r.store.db.Query(
+		"SELECT g.id, g.name, g.description, g.org_id, g.created_by, g.created_at, (SELECT COUNT(*) FROM group_members m WHERE m.group_id = g.id) FROM groups g WHERE ($1::bigint IS NULL OR g.org_id = $1) ORDER BY g.name", r.store.org(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		g := &models.Group{}
+		if err := rows.Scan(
+			&g.ID, &g.Name, &g.Description, &g.OrgID, &g.CreatedBy, &g.CreatedAt, &g.Members,
+		); err != nil {
+			return nil, err
+		}
+		groups = append(groups, g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// Delete удаляет группу вместе со списком участников.
+func (r *GroupRepository) Delete(id int) error {
+	return deleteById(r.store.db, "DELETE FROM groups WHERE id = $1 AND ($2::bigint IS NULL OR org_id = $2)", id, r.store.org())
+}
+
+// AddMembers добавляет в группу существующих пользователей из userIDs и
+// возвращает число новых участников. Несуществующие id, пользователи другой
+// организации и уже состоящие в группе пропускаются.
+func (r *GroupRepository) AddMembers(groupID int, userIDs []int) (int, error) {
+	var orgID int
+	if err := r.store.db.QueryRow(
+		"SELECT org_id FROM groups WHERE id = $1 AND ($2::bigint IS NULL OR org_id = $2)", groupID, r.store.org(),
+	).Scan(&orgID); err != nil {
+		return 0, err
+	}
+	res, err := r.store.db.Exec(
+		"INSERT INTO group_members (group_id, user_id) SELECT $1, id FROM users WHERE id = ANY($2) AND org_id = $3 ON CONFLICT DO NOTHING",
+		groupID, pq.Array(userIDs), orgID,
+	)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			return 0, sql.ErrNoRows
+		}
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(n), nil
+}
+
+func (r *GroupRepository) RemoveMember(groupID, userID int) error {
+	return deleteById(r.store.db,
+		"DELETE FROM group_members m USING groups g WHERE m.group_id = g.id AND m.group_id = $1 AND m.user_id = $2 AND ($3::bigint IS NULL OR g.org_id = $3)",
+		groupID, userID, r.store.org(),
+	)
+}
+
+// Members возвращает участников группы, включая заблокированных.
+func (r *GroupRepository) Members(groupID int) ([]*models.User, error) {
+	rows, err := r.store.db.Query(
+		"SELECT u.id, u.username, u.encrypted_password, u.email, u.email_verified_at, u.role, u.org_id, u.disabled_at, u.created_at FROM group_members m JOIN users u ON u.id = m.user_id WHERE m.group_id = $1 AND ($2::bigint IS NULL OR u.org_id = $2) ORDER BY u.id",
+		groupID, r.store.org(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	u := make([]*models.User, 0, 10)
+	for rows.Next() {
+		user := &models.User{}
+		if err := rows.Scan(
+			&user.ID, &user.Username, &user.EncryptedPassword, &user.Email, &user.EmailVerifiedAt, &user.Role, &user.OrgID, &user.DisabledAt, &user.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		u = append(u, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+type SegmentRepository struct {
+	store *Store
+}
+
+func (r *SegmentRepository) Create(s *models.Segment) error {
+	rules, err := json.Marshal(s.Rules)
+	if err != nil {
+		return err
+	}
+	r.store.assignOrg(&s.OrgID)
+	if err := r.store.db.QueryRow(
+		"INSERT INTO segments (name, description, rules, org_id, created_by) VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at",
+		s.Name, s.Description, rules, s.OrgID, s.CreatedBy,
+	).Scan(&s.ID, &s.CreatedAt); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return domain.ErrAudienceNameTaken
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *SegmentRepository) GetById(id int) (*models.Segment, error) {
+	s := &models.Segment{}
+	var rules []byte
+	if err := r.store.db.QueryRow(
+		"SELECT id, name, description, rules, org_id, created_by, created_at FROM segments WHERE id = $1 AND ($2::bigint IS NULL OR org_id = $2)", id, r.store.org(),
+	).Scan(
+		&s.ID, &s.Name, &s.Description, &rules, &s.OrgID, &s.CreatedBy, &s.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(rules, &s.Rules); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (r *SegmentRepository) Get() ([]*models.Segment, error) {
+	segments := make([]*models.Segment, 0, 10)
+	rows, err := r.store.db.Query(
+		"SELECT id, name, description, rules, org_id, created_by, created_at FROM segments WHERE ($1::bigint IS NULL OR org_id = $1) ORDER BY name", r.store.org(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		s := &models.Segment{}
+		var rules []byte
+		if err := rows.Scan(
+			&s.ID, &s.Name, &s.Description, &rules, &s.OrgID, &s.CreatedBy, &s.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(rules, &s.Rules); err != nil {
+			return nil, err
+		}
+		segments = append(segments, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return segments, nil
+}
+
+func (r *SegmentRepository) Delete(id int) error {
+	return deleteById(r.store.db, "DELETE FROM segments WHERE id = $1 AND ($2::bigint IS NULL OR org_id = $2)", id, r.store.org())
+}
+
+// deleteById выполняет DELETE и возвращает sql.ErrNoRows, если строка не найдена.
+func deleteById(db *sql.DB, query string, args ...interface{}) error {
+	res, err := db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package sqlstore_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/store/sqlstore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupRepository_Members(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("groups", "users")
+
+	s := sqlstore.New(t.Context(), db, log.NewLog(t.Context(), &log.LogConfig{Component: "sqlstore", LogLevel: "debug"}))
+	var ids []int
+	for _, name := range []string{"alice", "bob"} {
+		u := &models.User{
+			Username:          name,
+			EncryptedPassword: "encrypted_password",
+			Email:             name + "@example.com",
+			Role:              "user",
+			OrgID:             domain.DefaultOrgID,
+		}
+		assert.NoError(t, s.User().Create(u))
+		ids = append(ids, u.ID)
+	}
+
+	g := &models.Group{Name: "beta", OrgID: domain.DefaultOrgID}
+	assert.NoError(t, s.Group().Create(g))
+	assert.ErrorIs(t, s.Group().Create(&models.Group{Name: "beta", OrgID: domain.DefaultOrgID}), domain.ErrAudienceNameTaken)
+
+	// Несуществующий пользователь и повторное добавление пропускаются
+	n, err := s.Group().AddMembers(g.ID, append(ids, ids[0]+1000))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	n, err = s.Group().AddMembers(g.ID, ids)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	_, err = s.Group().AddMembers(g.ID+1000, ids)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	assert.NoError(t, s.Group().RemoveMember(g.ID, ids[0]))
+	assert.ErrorIs(t, s.Group().RemoveMember(g.ID, ids[0]), sql.ErrNoRows)
+
+	members, err := s.Group().Members(g.ID)
+	assert.NoError(t, err)
+	assert.Len(t, members, 1)
+	assert.Equal(t, "bob", members[0].Username)
+
+	got, err := s.Group().GetById(g.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, got.Members)
+}
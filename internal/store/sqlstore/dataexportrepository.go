@@ -0,0 +1,130 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/domain/models"
+)
+
+type DataExportRepository struct {
+	store *Store
+}
+
+const dataExportColumns = "id, user_id, org_id, requested_by, status, COALESCE(error, ''), created_at, completed_at, expires_at"
+
+func (r *DataExportRepository) Create(e *models.DataExport) error {
+	r.store.assignOrg(&e.OrgID)
+	if err := r.store.db.QueryRow(
+		"INSERT INTO data_exports (user_id, org_id, requested_by) VALUES ($1, $2, $3) RETURNING id, status, created_at",
+		e.UserID, e.OrgID, e.RequestedBy,
+	).Scan(&e.ID, &e.Status, &e.CreatedAt); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *DataExportRepository) GetById(id int) (*models.DataExport, error) {
+	e := &models.DataExport{}
+	if err := r.store.db.QueryRow(
+		"SELECT "+dataExportColumns+" FROM data_exports WHERE id = $1 AND ($2::bigint IS NULL OR org_id = $2)", id, r.store.org(),
+	).Scan(
+		&e.ID, &e.UserID, &e.OrgID, &e.RequestedBy, &e.Status, &e.Error, &e.CreatedAt, &e.CompletedAt, &e.ExpiresAt,
+	); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// GetByUserId возвращает выгрузки пользователя, новые первыми.
+func (r *DataExportRepository) GetByUserId(userID int) ([]*models.DataExport, error) {
+	rows, err := r.store.db.Query(
+		"SELECT "+dataExportColumns+" FROM data_exports WHERE user_id = $1 AND ($2::bigint IS NULL OR org_id = $2) ORDER BY id DESC", userID, r.store.org(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanDataExports(rows)
+}
+
+// ClaimPending переводит до limit ожидающих выгрузок в статус running и
+// возвращает их. Выгрузки, уже забранные другим процессом, пропускаются.
+// Выгрузка, которая остаётся в running дольше lease, забирается снова.
+func (r *DataExportRepository) ClaimPending(limit int, lease time.Duration) ([]*models.DataExport, error) {
+	rows, err := r.store.db.Query(
+		`UPDATE data_exports SET status = 'running', started_at = NOW() WHERE id IN (
+			SELECT id FROM data_exports
+			WHERE (status = 'pending' OR (status = 'running' AND started_at < NOW() - $3 * INTERVAL '1 second'))
+				AND ($2::bigint IS NULL OR org_id = $2)
+			ORDER BY created_at LIMIT $1 FOR UPDATE SKIP LOCKED
+		) RETURNING `+dataExportColumns, limit, r.store.org(), int(lease.Seconds()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanDataExports(rows)
+}
+
+// Complete сохраняет архив выгрузки. expires_at хранится без часового пояса,
+// поэтому expiresAt записывается в UTC.
+func (r *DataExportRepository) Complete(id int, archive []byte, expiresAt time.Time) error {
+	_, err := r.store.db.Exec(
+		"UPDATE data_exports SET status = 'ready', archive = $2, completed_at = NOW(), expires_at = $3 WHERE id = $1",
+		id, archive, expiresAt.UTC(),
+	)
+	return err
+}
+
+// Fail отмечает выгрузку неудачной. Запись об ошибке, как и готовый архив,
+// удаляется DeleteExpired после expiresAt.
+func (r *DataExportRepository) Fail(id int, reason string, expiresAt time.Time) error {
+	_, err := r.store.db.Exec(
+		"UPDATE data_exports SET status = 'failed', error = $2, completed_at = NOW(), expires_at = $3 WHERE id = $1",
+		id, reason, expiresAt.UTC(),
+	)
+	return err
+}
+
+// Archive возвращает архив готовой и ещё не истёкшей выгрузки.
+func (r *DataExportRepository) Archive(id int) ([]byte, error) {
+	var archive []byte
+	if err := r.store.db.QueryRow(
+		"SELECT archive FROM data_exports WHERE id = $1 AND status = 'ready' AND expires_at > NOW() AND ($2::bigint IS NULL OR org_id = $2)", id, r.store.org(),
+	).Scan(&archive); err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+// DeleteExpired удаляет выгрузки, срок хранения которых истёк.
+func (r *DataExportRepository) DeleteExpired() (int, error) {
+	res, err := r.store.db.Exec(
+		"DELETE FROM data_exports WHERE expires_at <= NOW() AND ($1::bigint IS NULL OR org_id = $1)", r.store.org(),
+	)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(n), nil
+}
+
+func scanDataExports(rows *sql.Rows) ([]*models.DataExport, error) {
+	defer rows.Close()
+	exports := make([]*models.DataExport, 0, 10)
+	for rows.Next() {
+		e := &models.DataExport{}
+		if err := rows.Scan(
+			&e.ID, &e.UserID, &e.OrgID, &e.RequestedBy, &e.Status, &e.Error, &e.CreatedAt, &e.CompletedAt, &e.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		exports = append(exports, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return exports, nil
+}
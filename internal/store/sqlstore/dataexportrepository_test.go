@@ -0,0 +1,79 @@
+package sqlstore_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/store/sqlstore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataExportRepository(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("data_exports", "users")
+
+	s := sqlstore.New(t.Context(), db, log.NewLog(t.Context(), &log.LogConfig{Component: "sqlstore", LogLevel: "debug"}))
+	u := &models.User{
+		Username:          "user",
+		EncryptedPassword: "encrypted_password",
+		Email:             "user@example.com",
+		Role:              "user",
+		OrgID:             domain.DefaultOrgID,
+	}
+	assert.NoError(t, s.User().Create(u))
+
+	e := &models.DataExport{UserID: u.ID, OrgID: u.OrgID}
+	assert.NoError(t, s.DataExport().Create(e))
+	assert.Equal(t, models.DataExportPending, e.Status)
+
+	claimed, err := s.DataExport().ClaimPending(10, time.Hour)
+	assert.NoError(t, err)
+	assert.Len(t, claimed, 1)
+	assert.Equal(t, models.DataExportRunning, claimed[0].Status)
+	claimed, err = s.DataExport().ClaimPending(10, time.Hour)
+	assert.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	// Выгрузку упавшего обработчика забирает следующий
+	_, err = db.Exec("UPDATE data_exports SET started_at = NOW() - INTERVAL '2 hours' WHERE id = $1", e.ID)
+	assert.NoError(t, err)
+	claimed, err = s.DataExport().ClaimPending(10, time.Hour)
+	assert.NoError(t, err)
+	assert.Len(t, claimed, 1)
+
+	// Пока архив не готов, скачать его нельзя
+	_, err = s.DataExport().Archive(e.ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Срок хранения не зависит от часового пояса процесса
+	ny, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	assert.NoError(t, s.DataExport().Complete(e.ID, []byte("zip"), time.Now().Add(time.Hour).In(ny)))
+	archive, err := s.DataExport().Archive(e.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("zip"), archive)
+
+	_, err = s.Tenant(domain.DefaultOrgID + 1).DataExport().Archive(e.ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	exports, err := s.DataExport().GetByUserId(u.ID)
+	assert.NoError(t, err)
+	assert.Len(t, exports, 1)
+	assert.Equal(t, models.DataExportReady, exports[0].Status)
+	assert.NotNil(t, exports[0].ExpiresAt)
+
+	// Неудачная выгрузка удаляется по истечении срока так же, как готовая
+	failed := &models.DataExport{UserID: u.ID, OrgID: u.OrgID}
+	assert.NoError(t, s.DataExport().Create(failed))
+	assert.NoError(t, s.DataExport().Fail(failed.ID, "boom", time.Now().Add(-time.Minute)))
+	n, err := s.DataExport().DeleteExpired()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	exports, err = s.DataExport().GetByUserId(u.ID)
+	assert.NoError(t, err)
+	assert.Len(t, exports, 1)
+}
@@ -0,0 +1,91 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/lib/pq"
+)
+
+type EmailVerificationRepository struct {
+	store *Store
+}
+
+// Create сохраняет токен подтверждения. expires_at хранится без часового
+// пояса, поэтому время истечения записывается в UTC.
+func (r *EmailVerificationRepository) Create(v *models.EmailVerification) error {
+	if err := r.store.db.QueryRow(
+		"INSERT INTO email_verifications (user_id, email, token_hash, expires_at) VALUES ($1, $2, $3, $4) RETURNING id, created_at",
+		v.UserID, v.Email, v.TokenHash, v.ExpiresAt.UTC(),
+	).Scan(&v.ID, &v.CreatedAt); err != nil {
+		return err
+	}
+	return nil
+}
+
+// SentSince сообщает, выдавался ли пользователю userID токен подтверждения за
+// последние window. Окно отсчитывается от времени базы, как и created_at.
+func (r *EmailVerificationRepository) SentSince(userID int, window time.Duration) (bool, error) {
+	var sent bool
+	if err := r.store.db.QueryRow(
+		"SELECT EXISTS (SELECT 1 FROM email_verifications WHERE user_id = $1 AND created_at > NOW() - $2 * INTERVAL '1 second')",
+		userID, window.Seconds(),
+	).Scan(&sent); err != nil {
+		return false, err
+	}
+	return sent, nil
+}
+
+// Confirm погашает токен tokenHash и отмечает email пользователя подтверждённым.
+// Если токен выдан на новый адрес, email пользователя меняется на него, а
+// остальные неиспользованные токены пользователя гасятся. Если токен не найден,
+// использован или истёк, возвращает domain.ErrInvalidVerificationToken, если
+// адрес занят другим пользователем - domain.ErrEmailTaken.
+func (r *EmailVerificationRepository) Confirm(tokenHash string) (int, error) {
+	tx, err := r.store.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var userID int
+	var email string
+	if err := tx.QueryRow(
+		"UPDATE email_verifications SET used_at = NOW() WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW() RETURNING user_id, email", tokenHash,
+	).Scan(&userID, &email); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, domain.ErrInvalidVerificationToken
+		}
+		return 0, err
+	}
+
+	var current string
+	if err := tx.QueryRow(
+		"SELECT email FROM users WHERE id = $1 FOR UPDATE", userID,
+	).Scan(&current); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, domain.ErrInvalidVerificationToken
+		}
+		return 0, err
+	}
+
+	if _, err := tx.Exec(
+		"UPDATE users SET email = $2, email_verified_at = NOW() WHERE id = $1", userID, email,
+	); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return 0, domain.ErrEmailTaken
+		}
+		return 0, err
+	}
+	if email != current {
+		// Остальные ссылки выданы на прежние адреса
+		if _, err := tx.Exec(
+			"UPDATE email_verifications SET used_at = NOW() WHERE user_id = $1 AND used_at IS NULL", userID,
+		); err != nil {
+			return 0, err
+		}
+	}
+	return userID, tx.Commit()
+}
@@ -0,0 +1,36 @@
+package sqlstore_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/store/sqlstore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailVerificationRepository_ExpiresAtTimezone(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("email_verifications", "users")
+
+	s := sqlstore.New(t.Context(), db, log.NewLog(t.Context(), &log.LogConfig{Component: "sqlstore", LogLevel: "debug"}))
+	u := &models.User{
+		Username:          "testuser",
+		EncryptedPassword: "encrypted_password",
+		Email:             "email@example.com",
+		Role:              "user",
+		OrgID:             domain.DefaultOrgID,
+	}
+	assert.NoError(t, s.User().Create(u))
+	ny, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	// Ссылка, выданная на сервере не в UTC, действует до ExpiresAt
+	v := &models.EmailVerification{UserID: u.ID, Email: u.Email, TokenHash: "hash", ExpiresAt: time.Now().Add(time.Hour).In(ny)}
+	assert.NoError(t, s.EmailVerification().Create(v))
+	userID, err := s.EmailVerification().Confirm("hash")
+	assert.NoError(t, err)
+	assert.Equal(t, u.ID, userID)
+}
@@ -0,0 +1,66 @@
+package sqlstore
+
+import (
+	"github.com/DANazavr/RATest/internal/domain/models"
+)
+
+type ExternalIdentityRepository struct {
+	store *Store
+}
+
+func (r *ExternalIdentityRepository) Create(i *models.ExternalIdentity) error {
+	if err := r.store.db.QueryRow(
+		"INSERT INTO user_identities (user_id, provider, subject, email, last_login_at) VALUES ($1, $2, $3, $4, NOW()) RETURNING id, created_at, last_login_at",
+		i.UserID, i.Provider, i.Subject, i.Email,
+	).Scan(&i.ID, &i.CreatedAt, &i.LastLoginAt); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CreateWithUser создаёт пользователя user и его внешнюю учётную запись i в
+// одной транзакции. Если user.EmailVerifiedAt задан, адрес считается подтверждённым.
+func (r *ExternalIdentityRepository) CreateWithUser(i *models.ExternalIdentity, user *models.User) error {
+	tx, err := r.store.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := tx.QueryRow(
+		"INSERT INTO users (username, encrypted_password, email, role, email_verified_at, org_id) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at",
+		user.Username, user.EncryptedPassword, user.Email, user.Role, user.EmailVerifiedAt, user.OrgID,
+	).Scan(&user.ID, &user.CreatedAt); err != nil {
+		return err
+	}
+
+	i.UserID = user.ID
+	if err := tx.QueryRow(
+		"INSERT INTO user_identities (user_id, provider, subject, email, last_login_at) VALUES ($1, $2, $3, $4, NOW()) RETURNING id, created_at, last_login_at",
+		i.UserID, i.Provider, i.Subject, i.Email,
+	).Scan(&i.ID, &i.CreatedAt, &i.LastLoginAt); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *ExternalIdentityRepository) GetByProviderSubject(provider string, subject string) (*models.ExternalIdentity, error) {
+	i := &models.ExternalIdentity{}
+	if err := r.store.db.QueryRow(
+		"SELECT id, user_id, provider, subject, email, created_at, last_login_at FROM user_identities WHERE provider = $1 AND subject = $2",
+		provider, subject,
+	).Scan(&i.ID, &i.UserID, &i.Provider, &i.Subject, &i.Email, &i.CreatedAt, &i.LastLoginAt); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+func (r *ExternalIdentityRepository) TouchLogin(id int, email string) error {
+	_, err := r.store.db.Exec(
+		"UPDATE user_identities SET last_login_at = NOW(), email = $2 WHERE id = $1", id, email,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package sqlstore_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/store/sqlstore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExternalIdentityRepository_CreateWithUser(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("user_identities", "users")
+
+	s := sqlstore.New(t.Context(), db, log.NewLog(t.Context(), &log.LogConfig{Component: "sqlstore", LogLevel: "debug"}))
+	now := time.Now()
+	u := &models.User{
+		Username:        "ssouser",
+		Email:           "sso@example.com",
+		Role:            "user",
+		OrgID:           domain.DefaultOrgID,
+		EmailVerifiedAt: &now,
+	}
+	i := &models.ExternalIdentity{Provider: "corp", Subject: "subject-1", Email: u.Email}
+	assert.NoError(t, s.ExternalIdentity().CreateWithUser(i, u))
+	assert.NotZero(t, u.ID)
+	assert.Equal(t, u.ID, i.UserID)
+
+	got, err := s.ExternalIdentity().GetByProviderSubject("corp", "subject-1")
+	assert.NoError(t, err)
+	assert.Equal(t, u.ID, got.UserID)
+
+	user, err := s.User().GetById(u.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, user.EmailVerifiedAt)
+
+	// Тот же subject у другого провайдера - другая учётная запись
+	_, err = s.ExternalIdentity().GetByProviderSubject("other", "subject-1")
+	assert.Error(t, err)
+}
+
+func TestOIDCStateRepository_Consume(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("oidc_states")
+
+	s := sqlstore.New(t.Context(), db, log.NewLog(t.Context(), &log.LogConfig{Component: "sqlstore", LogLevel: "debug"}))
+	assert.NoError(t, s.OIDCState().Create(&models.OIDCState{
+		StateHash:    "hash1",
+		Provider:     "corp",
+		CodeVerifier: "verifier",
+		Nonce:        "nonce",
+		ExpiresAt:    time.Now().Add(time.Minute),
+	}))
+	assert.NoError(t, s.OIDCState().Create(&models.OIDCState{
+		StateHash: "hash2",
+		Provider:  "corp",
+		ExpiresAt: time.Now().Add(-time.Minute),
+	}))
+
+	_, err := s.OIDCState().Consume("hash1", "other")
+	assert.ErrorIs(t, err, domain.ErrInvalidOIDCState)
+	_, err = s.OIDCState().Consume("hash2", "corp")
+	assert.ErrorIs(t, err, domain.ErrInvalidOIDCState)
+
+	st, err := s.OIDCState().Consume("hash1", "corp")
+	assert.NoError(t, err)
+	assert.Equal(t, "verifier", st.CodeVerifier)
+	_, err = s.OIDCState().Consume("hash1", "corp")
+	assert.ErrorIs(t, err, domain.ErrInvalidOIDCState)
+}
+
+func TestOIDCStateRepository_ExpiresAtTimezone(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("oidc_states")
+
+	s := sqlstore.New(t.Context(), db, log.NewLog(t.Context(), &log.LogConfig{Component: "sqlstore", LogLevel: "debug"}))
+	ny, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	// state, выданный на сервере не в UTC, действует до ExpiresAt
+	assert.NoError(t, s.OIDCState().Create(&models.OIDCState{
+		StateHash: "hash",
+		Provider:  "corp",
+		ExpiresAt: time.Now().Add(10 * time.Minute).In(ny),
+	}))
+	_, err = s.OIDCState().Consume("hash", "corp")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,93 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+)
+
+type InviteRepository struct {
+	store *Store
+}
+
+// Create сохраняет приглашение. expires_at хранится без часового пояса,
+// поэтому время истечения записывается в UTC.
+func (r *InviteRepository) Create(i *models.Invite) error {
+	r.store.assignOrg(&i.OrgID)
+	if err := r.store.db.QueryRow(
+		"INSERT INTO invites (id, role, email, org_id, created_by, expires_at) VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6) RETURNING created_at",
+		i.ID, i.Role, i.Email, i.OrgID, i.CreatedBy, i.ExpiresAt.UTC(),
+	).Scan(&i.CreatedAt); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *InviteRepository) GetById(id string) (*models.Invite, error) {
+	i := &models.Invite{}
+	if err := r.store.db.QueryRow(
+		"SELECT id, role, COALESCE(email, ''), org_id, created_by, created_at, expires_at, used_at, used_by FROM invites WHERE id = $1 AND ($2::bigint IS NULL OR org_id = $2)", id, r.store.org(),
+	).Scan(
+		&i.ID, &i.Role, &i.Email, &i.OrgID, &i.CreatedBy, &i.CreatedAt, &i.ExpiresAt, &i.UsedAt, &i.UsedBy,
+	); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+func (r *InviteRepository) Get() ([]*models.Invite, error) {
+	invites := make([]*models.Invite, 0, 10)
+	rows, err := r.store.db.Query(
+		"SELECT id, role, COALESCE(email, ''), org_id, created_by, created_at, expires_at, used_at, used_by FROM invites WHERE ($1::bigint IS NULL OR org_id = $1) ORDER BY created_at DESC", r.store.org(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		i := &models.Invite{}
+		if err := rows.Scan(
+			&i.ID, &i.Role, &i.Email, &i.OrgID, &i.CreatedBy, &i.CreatedAt, &i.ExpiresAt, &i.UsedAt, &i.UsedBy,
+		); err != nil {
+			return nil, err
+		}
+		invites = append(invites, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return invites, nil
+}
+
+// Accept создаёт пользователя по приглашению id в организации приглашения и
+// погашает приглашение в одной транзакции. Если приглашение уже использовано или истекло, возвращает
+// domain.ErrInvalidInvite и пользователя не создаёт.
+func (r *InviteRepository) Accept(id string, user *models.User) error {
+	tx, err := r.store.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := tx.QueryRow(
+		"UPDATE invites SET used_at = NOW() WHERE id = $1 AND used_at IS NULL AND expires_at > NOW() RETURNING org_id", id,
+	).Scan(&user.OrgID); err == sql.ErrNoRows {
+		return domain.ErrInvalidInvite
+	} else if err != nil {
+		return err
+	}
+
+	if err := tx.QueryRow(
+		"INSERT INTO users (username, encrypted_password, email, role, org_id) VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at",
+		user.Username, user.EncryptedPassword, user.Email, user.Role, user.OrgID,
+	).Scan(&user.ID, &user.CreatedAt); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec("UPDATE invites SET used_by = $2 WHERE id = $1", id, user.ID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
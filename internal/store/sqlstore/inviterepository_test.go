@@ -0,0 +1,69 @@
+package sqlstore_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/store/sqlstore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInviteRepository_Accept(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("invites", "users")
+
+	s := sqlstore.New(t.Context(), db, log.NewLog(t.Context(), &log.LogConfig{Component: "sqlstore", LogLevel: "debug"}))
+	i := &models.Invite{
+		ID:        "invite",
+		Role:      "publisher",
+		OrgID:     domain.DefaultOrgID,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	assert.NoError(t, s.Invite().Create(i))
+
+	u := &models.User{
+		Username:          "invited",
+		EncryptedPassword: "encrypted_password",
+		Email:             "invited@example.com",
+		Role:              i.Role,
+	}
+	assert.NoError(t, s.Invite().Accept(i.ID, u))
+	assert.Equal(t, domain.DefaultOrgID, u.OrgID)
+
+	// Приглашение одноразовое
+	other := &models.User{
+		Username:          "other",
+		EncryptedPassword: "encrypted_password",
+		Email:             "other@example.com",
+		Role:              i.Role,
+	}
+	assert.ErrorIs(t, s.Invite().Accept(i.ID, other), domain.ErrInvalidInvite)
+
+	got, err := s.Invite().GetById(i.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, got.UsedAt)
+	assert.Equal(t, u.ID, *got.UsedBy)
+}
+
+func TestInviteRepository_ExpiresAtTimezone(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("invites")
+
+	s := sqlstore.New(t.Context(), db, log.NewLog(t.Context(), &log.LogConfig{Component: "sqlstore", LogLevel: "debug"}))
+	ny, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	i := &models.Invite{
+		ID:        "invite",
+		Role:      "publisher",
+		OrgID:     domain.DefaultOrgID,
+		ExpiresAt: time.Now().Add(time.Hour).In(ny),
+	}
+	assert.NoError(t, s.Invite().Create(i))
+
+	got, err := s.Invite().GetById(i.ID)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, i.ExpiresAt, got.ExpiresAt, time.Second)
+}
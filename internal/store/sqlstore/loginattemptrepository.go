@@ -0,0 +1,57 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/domain/models"
+)
+
+type LoginAttemptRepository struct {
+	store *Store
+}
+
+func (r *LoginAttemptRepository) Create(a *models.LoginAttempt) error {
+	if err := r.store.db.QueryRow(
+		"INSERT INTO login_attempts (username, user_id, ip, user_agent, success, reason) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at",
+		a.Username, a.UserID, a.IP, a.UserAgent, a.Success, a.Reason,
+	).Scan(&a.ID, &a.CreatedAt); err != nil {
+		return err
+	}
+	return nil
+}
+
+// FailuresByUsername считает неверные пароли и коды второго фактора для username за последние
+// window и после последнего успешного входа. Возвращает их число и время, прошедшее с последней.
+// Интервалы считаются в базе, поэтому часовой пояс сервера на них не влияет.
+func (r *LoginAttemptRepository) FailuresByUsername(username string, window time.Duration) (int, *time.Duration, error) {
+	return scanFailures(r.store.db.QueryRow(
+		`SELECT COUNT(*), EXTRACT(EPOCH FROM NOW() - MAX(created_at)) FROM login_attempts
+		WHERE username = $1 AND reason IN ($2, $3) AND created_at > GREATEST(NOW() - $4 * INTERVAL '1 second',
+			COALESCE((SELECT MAX(created_at) FROM login_attempts WHERE username = $1 AND success), NOW() - $4 * INTERVAL '1 second'))`,
+		username, models.LoginReasonInvalidCredentials, models.LoginReasonInvalidMFACode, window.Seconds(),
+	))
+}
+
+// FailuresByIP считает неверные пароли и коды второго фактора с адреса ip за последние window. Успешный вход
+// счётчик не сбрасывает: при переборе чужих учётных записей часть подходит.
+func (r *LoginAttemptRepository) FailuresByIP(ip string, window time.Duration) (int, *time.Duration, error) {
+	return scanFailures(r.store.db.QueryRow(
+		`SELECT COUNT(*), EXTRACT(EPOCH FROM NOW() - MAX(created_at)) FROM login_attempts
+		WHERE ip = $1 AND reason IN ($2, $3) AND created_at > NOW() - $4 * INTERVAL '1 second'`,
+		ip, models.LoginReasonInvalidCredentials, models.LoginReasonInvalidMFACode, window.Seconds(),
+	))
+}
+
+func scanFailures(row *sql.Row) (int, *time.Duration, error) {
+	var n int
+	var elapsed sql.NullFloat64
+	if err := row.Scan(&n, &elapsed); err != nil {
+		return 0, nil, err
+	}
+	if !elapsed.Valid {
+		return n, nil, nil
+	}
+	d := time.Duration(elapsed.Float64 * float64(time.Second))
+	return n, &d, nil
+}
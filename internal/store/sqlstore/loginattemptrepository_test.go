@@ -0,0 +1,49 @@
+package sqlstore_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/store/sqlstore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginAttemptRepository_Failures(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("login_attempts")
+
+	s := sqlstore.New(t.Context(), db, log.NewLog(t.Context(), &log.LogConfig{Component: "sqlstore", LogLevel: "debug"}))
+	record := func(username, ip, reason string) {
+		assert.NoError(t, s.LoginAttempt().Create(&models.LoginAttempt{
+			Username: username,
+			IP:       ip,
+			Success:  reason == models.LoginReasonSuccess,
+			Reason:   reason,
+		}))
+	}
+	window := time.Hour
+
+	record("alice", "10.0.0.1", models.LoginReasonInvalidCredentials)
+	record("alice", "10.0.0.1", models.LoginReasonInvalidCredentials)
+	record("alice", "10.0.0.1", models.LoginReasonThrottled)
+
+	n, elapsed, err := s.LoginAttempt().FailuresByUsername("alice", window)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	if assert.NotNil(t, elapsed) {
+		assert.Less(t, *elapsed, time.Minute)
+	}
+
+	// Успешный вход сбрасывает счётчик по имени, но не по адресу
+	time.Sleep(10 * time.Millisecond)
+	record("alice", "10.0.0.1", models.LoginReasonSuccess)
+	n, _, err = s.LoginAttempt().FailuresByUsername("alice", window)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	n, _, err = s.LoginAttempt().FailuresByIP("10.0.0.1", window)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+}
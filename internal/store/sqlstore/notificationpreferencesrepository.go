@@ -0,0 +1,45 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/lib/pq"
+)
+
+type NotificationPreferencesRepository struct {
+	store *Store
+}
+
+// Get возвращает сохранённые настройки пользователя или sql.ErrNoRows, если
+// пользователь их не менял.
+func (r *NotificationPreferencesRepository) Get(userID int) (*models.NotificationPreferences, error) {
+	p := &models.NotificationPreferences{}
+	var start, end sql.NullString
+	if err := r.store.db.QueryRow(
+		"SELECT user_id, muted_categories, quiet_start, quiet_end, timezone, locale, realtime, email, updated_at FROM notification_preferences WHERE user_id = $1", userID,
+	).Scan(
+		&p.UserID, pq.Array(&p.MutedCategories), &start, &end, &p.Timezone, &p.Locale, &p.Channels.Realtime, &p.Channels.Email, &p.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if start.Valid && end.Valid {
+		p.QuietHours = &models.QuietHours{Start: start.String, End: end.String}
+	}
+	return p, nil
+}
+
+func (r *NotificationPreferencesRepository) Save(p *models.NotificationPreferences) error {
+	var start, end sql.NullString
+	if p.QuietHours != nil {
+		start = sql.NullString{String: p.QuietHours.Start, Valid: true}
+		end = sql.NullString{String: p.QuietHours.End, Valid: true}
+	}
+	return r.store.db.QueryRow(
+		`INSERT INTO notification_preferences (user_id, muted_categories, quiet_start, quiet_end, timezone, realtime, email, locale)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (user_id) DO UPDATE SET muted_categories = $2, quiet_start = $3, quiet_end = $4, timezone = $5, realtime = $6, email = $7, locale = $8, updated_at = NOW()
+		RETURNING updated_at`,
+		p.UserID, pq.Array(p.MutedCategories), start, end, p.Timezone, p.Channels.Realtime, p.Channels.Email, p.Locale,
+	).Scan(&p.UpdatedAt)
+}
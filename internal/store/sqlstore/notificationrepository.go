@@ -1,7 +1,9 @@
 package sqlstore
 
 import (
+	"database/sql"
 	"encoding/json"
+	"time"
 
 	"github.com/DANazavr/RATest/internal/domain/models"
 )
@@ -11,9 +13,15 @@ type NotificationRepository struct {
 }
 
 func (n *NotificationRepository) Create(un *models.UserNotification, data []byte) error {
+	n.store.assignOrg(&un.OrgID)
+	var deliverAt *time.Time
+	if un.DeliverAt != nil {
+		at := un.DeliverAt.UTC()
+		deliverAt = &at
+	}
 	err := n.store.db.QueryRow(
-		"INSERT INTO user_notifications (user_id, notification) VALUES ($1, $2) RETURNING uid, created_at",
-		&un.UserID, &data,
+		"INSERT INTO user_notifications (user_id, org_id, notification, api_key_id, deliver_at, scheduled, broadcast) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING uid, created_at",
+		&un.UserID, un.OrgID, &data, un.APIKeyID, deliverAt, un.Scheduled, un.Broadcast,
 	).Scan(&un.UID, &un.CreatedAt)
 	if err != nil {
 		return err
@@ -25,8 +33,8 @@ func (n *NotificationRepository) GetById(id int) (*models.UserNotification, erro
 	var data []byte
 	un := &models.UserNotification{}
 	err := n.store.db.QueryRow(
-		"SELECT uid, user_id, notification, created_at, send_at, read_at FROM user_notifications WHERE uid = $1", id,
-	).Scan(&un.UID, &un.UserID, &data, &un.CreatedAt, &un.SendAt, &un.ReadAt)
+		"SELECT uid, user_id, org_id, notification, created_at, send_at, read_at, deliver_at FROM user_notifications WHERE uid = $1 AND ($2::bigint IS NULL OR org_id = $2)", id, n.store.org(),
+	).Scan(&un.UID, &un.UserID, &un.OrgID, &data, &un.CreatedAt, &un.SendAt, &un.ReadAt, &un.DeliverAt)
 	if err != nil {
 		return nil, err
 	}
@@ -39,7 +47,7 @@ func (n *NotificationRepository) GetById(id int) (*models.UserNotification, erro
 func (n *NotificationRepository) GetByUserId(userId int) ([]*models.UserNotification, error) {
 	un := make([]*models.UserNotification, 0, 100)
 	rows, err := n.store.db.Query(
-		"SELECT uid, user_id, notification, created_at, send_at, read_at FROM user_notifications WHERE user_id = $1 ORDER BY created_at", userId,
+		"SELECT uid, user_id, org_id, notification, created_at, send_at, read_at, deliver_at FROM user_notifications WHERE user_id = $1 AND NOT scheduled AND ($2::bigint IS NULL OR org_id = $2) ORDER BY created_at", userId, n.store.org(),
 	)
 	if err != nil {
 		return nil, err
@@ -50,8 +58,8 @@ func (n *NotificationRepository) GetByUserId(userId int) ([]*models.UserNotifica
 		var data []byte
 		userNotification := &models.UserNotification{}
 		if err := rows.Scan(
-			&userNotification.UID, &userNotification.UserID, &data,
-			&userNotification.CreatedAt, &userNotification.SendAt, &userNotification.ReadAt,
+			&userNotification.UID, &userNotification.UserID, &userNotification.OrgID, &data,
+			&userNotification.CreatedAt, &userNotification.SendAt, &userNotification.ReadAt, &userNotification.DeliverAt,
 		); err != nil {
 			return nil, err
 		}
@@ -65,7 +73,8 @@ func (n *NotificationRepository) GetByUserId(userId int) ([]*models.UserNotifica
 
 func (n *NotificationRepository) GetByUserIdWithFilter(userId int, filter string) ([]*models.UserNotification, error) {
 	un := make([]*models.UserNotification, 0, 100)
-	query := "SELECT uid, user_id, notification, created_at, send_at, read_at FROM user_notifications WHERE user_id = $1"
+	// Запланированные уведомления получатель видит только после отправки
+	query := "SELECT uid, user_id, org_id, notification, created_at, send_at, read_at, deliver_at FROM user_notifications WHERE user_id = $1 AND NOT scheduled AND ($2::bigint IS NULL OR org_id = $2)"
 	switch filter {
 	case "all":
 		// No additional conditions
@@ -91,7 +100,7 @@ func (n *NotificationRepository) GetByUserIdWithFilter(userId int, filter string
 
 	query += " ORDER BY created_at"
 
-	rows, err := n.store.db.Query(query, userId)
+	rows, err := n.store.db.Query(query, userId, n.store.org())
 	if err != nil {
 		return nil, err
 	}
@@ -101,8 +110,8 @@ func (n *NotificationRepository) GetByUserIdWithFilter(userId int, filter string
 		var data []byte
 		userNotification := &models.UserNotification{}
 		if err := rows.Scan(
-			&userNotification.UID, &userNotification.UserID, &data,
-			&userNotification.CreatedAt, &userNotification.SendAt, &userNotification.ReadAt,
+			&userNotification.UID, &userNotification.UserID, &userNotification.OrgID, &data,
+			&userNotification.CreatedAt, &userNotification.SendAt, &userNotification.ReadAt, &userNotification.DeliverAt,
 		); err != nil {
 			return nil, err
 		}
@@ -116,7 +125,7 @@ func (n *NotificationRepository) GetByUserIdWithFilter(userId int, filter string
 
 func (n *NotificationRepository) MarkAsSend(id int, userid int) error {
 	_, err := n.store.db.Exec(
-		"UPDATE user_notifications SET send_at = NOW() WHERE uid = $1 AND user_id = $2", id, userid,
+		"UPDATE user_notifications SET send_at = NOW() WHERE uid = $1 AND user_id = $2 AND ($3::bigint IS NULL OR org_id = $3)", id, userid, n.store.org(),
 	)
 	if err != nil {
 		return err
@@ -126,10 +135,144 @@ func (n *NotificationRepository) MarkAsSend(id int, userid int) error {
 
 func (n *NotificationRepository) MarkAsRead(id int, userid int) error {
 	_, err := n.store.db.Exec(
-		"UPDATE user_notifications SET read_at = NOW() WHERE uid = $1 AND user_id = $2", id, userid,
+		"UPDATE user_notifications SET read_at = NOW() WHERE uid = $1 AND user_id = $2 AND ($3::bigint IS NULL OR org_id = $3)", id, userid, n.store.org(),
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Defer откладывает отправку неотправленного уведомления до at. deliver_at
+// хранится без часового пояса, поэтому at записывается в UTC.
+func (n *NotificationRepository) Defer(id int, at time.Time) error {
+	_, err := n.store.db.Exec(
+		"UPDATE user_notifications SET deliver_at = $2 WHERE uid = $1 AND send_at IS NULL AND ($3::bigint IS NULL OR org_id = $3)", id, at.UTC(), n.store.org(),
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// ClaimDue забирает до limit неотправленных уведомлений, время отправки которых
+// наступило, и переносит их deliver_at на lease вперёд. Строки, уже забранные
+// другим процессом, пропускаются, поэтому каждое уведомление достаётся одному
+// процессу. Если процесс не вызвал FinishDue, например упал или не смог
+// отправить уведомление, по истечении lease оно забирается снова.
+// Запланированное уведомление появляется у получателя в момент отправки.
+func (n *NotificationRepository) ClaimDue(limit int, lease time.Duration) ([]*models.UserNotification, error) {
+	rows, err := n.store.db.Query(
+		`UPDATE user_notifications SET deliver_at = NOW() + $3 * INTERVAL '1 second', scheduled = FALSE,
+			created_at = CASE WHEN scheduled THEN NOW() ELSE created_at END
+		WHERE uid IN (
+			SELECT uid FROM user_notifications
+			WHERE deliver_at <= NOW() AND send_at IS NULL AND ($2::bigint IS NULL OR org_id = $2)
+			ORDER BY deliver_at LIMIT $1 FOR UPDATE SKIP LOCKED
+		) RETURNING uid, user_id, org_id, notification, created_at, send_at, read_at, deliver_at`, limit, n.store.org(), int(lease.Seconds()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	un := make([]*models.UserNotification, 0, limit)
+	for rows.Next() {
+		var data []byte
+		userNotification := &models.UserNotification{}
+		if err := rows.Scan(
+			&userNotification.UID, &userNotification.UserID, &userNotification.OrgID, &data,
+			&userNotification.CreatedAt, &userNotification.SendAt, &userNotification.ReadAt, &userNotification.DeliverAt,
+		); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &userNotification.Notification); err != nil {
+			return nil, err
+		}
+		un = append(un, userNotification)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return un, nil
+}
+
+// FinishDue снимает deliver_at с уведомления, забранного ClaimDue с временем
+// lease. Если за это время отправку снова отложили до конца тихих часов,
+// deliver_at уже другой и не меняется.
+func (n *NotificationRepository) FinishDue(id int, lease time.Time) error {
+	_, err := n.store.db.Exec(
+		"UPDATE user_notifications SET deliver_at = NULL WHERE uid = $1 AND deliver_at = $2", id, lease.UTC(),
+	)
+	return err
+}
+
+const scheduledColumns = "uid, user_id, org_id, notification, created_at, deliver_at, api_key_id, scheduled, broadcast"
+
+func scanScheduled(row rowScanner) (*models.UserNotification, error) {
+	var data []byte
+	un := &models.UserNotification{}
+	if err := row.Scan(
+		&un.UID, &un.UserID, &un.OrgID, &data, &un.CreatedAt, &un.DeliverAt, &un.APIKeyID, &un.Scheduled, &un.Broadcast,
+	); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &un.Notification); err != nil {
+		return nil, err
+	}
+	return un, nil
+}
+
+// GetScheduled возвращает запланированные и ещё не отправленные уведомления,
+// ближайшие первыми. Уведомления рассылок возвращаются, только если broadcast.
+func (n *NotificationRepository) GetScheduled(broadcast bool) ([]*models.UserNotification, error) {
+	rows, err := n.store.db.Query(
+		"SELECT "+scheduledColumns+" FROM user_notifications WHERE scheduled AND ($2 OR NOT broadcast) AND ($1::bigint IS NULL OR org_id = $1) ORDER BY deliver_at, uid", n.store.org(), broadcast,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	un := make([]*models.UserNotification, 0, 10)
+	for rows.Next() {
+		userNotification, err := scanScheduled(rows)
+		if err != nil {
+			return nil, err
+		}
+		un = append(un, userNotification)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return un, nil
+}
+
+// Reschedule переносит отправку запланированного уведомления на at. Уже
+// забранное на отправку уведомление, как и уведомление рассылки без broadcast,
+// не меняется, тогда возвращается sql.ErrNoRows.
+func (n *NotificationRepository) Reschedule(id int, at time.Time, broadcast bool) (*models.UserNotification, error) {
+	return scanScheduled(n.store.db.QueryRow(
+		"UPDATE user_notifications SET deliver_at = $2 WHERE uid = $1 AND scheduled AND ($4 OR NOT broadcast) AND ($3::bigint IS NULL OR org_id = $3) RETURNING "+scheduledColumns,
+		id, at.UTC(), n.store.org(), broadcast,
+	))
+}
+
+// DeleteScheduled отменяет запланированное уведомление, пока оно не отправлено.
+// Уведомление рассылки отменяется, только если broadcast.
+func (n *NotificationRepository) DeleteScheduled(id int, broadcast bool) error {
+	res, err := n.store.db.Exec(
+		"DELETE FROM user_notifications WHERE uid = $1 AND scheduled AND ($3 OR NOT broadcast) AND ($2::bigint IS NULL OR org_id = $2)", id, n.store.org(), broadcast,
 	)
 	if err != nil {
 		return err
 	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
 	return nil
 }
@@ -0,0 +1,172 @@
+package sqlstore_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/store/sqlstore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationRepository_Scheduled(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("user_notifications", "users")
+
+	s := sqlstore.New(t.Context(), db, log.NewLog(t.Context(), &log.LogConfig{Component: "sqlstore", LogLevel: "debug"}))
+	u := &models.User{
+		Username:          "testuser",
+		EncryptedPassword: "encrypted_password",
+		Email:             "email@example.com",
+		Role:              "user",
+		OrgID:             domain.DefaultOrgID,
+	}
+	assert.NoError(t, s.User().Create(u))
+
+	at := time.Now().Add(time.Hour)
+	un := &models.UserNotification{UserID: u.ID, OrgID: u.OrgID, DeliverAt: &at, Scheduled: true}
+	assert.NoError(t, s.Notification().Create(un, []byte(`{"title":"hello","message":"hello"}`)))
+
+	// Получатель не видит уведомление до отправки
+	inbox, err := s.Notification().GetByUserId(u.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, inbox)
+	scheduled, err := s.Notification().GetScheduled(false)
+	assert.NoError(t, err)
+	assert.Len(t, scheduled, 1)
+
+	due, err := s.Notification().ClaimDue(10, time.Minute)
+	assert.NoError(t, err)
+	assert.Empty(t, due)
+
+	got, err := s.Notification().Reschedule(un.UID, time.Now().Add(-time.Minute), false)
+	assert.NoError(t, err)
+	assert.True(t, got.Scheduled)
+
+	due, err = s.Notification().ClaimDue(10, time.Minute)
+	assert.NoError(t, err)
+	assert.Len(t, due, 1)
+	inbox, err = s.Notification().GetByUserId(u.ID)
+	assert.NoError(t, err)
+	assert.Len(t, inbox, 1)
+
+	// Забранное на отправку уведомление уже не отменить и не перенести
+	assert.ErrorIs(t, s.Notification().DeleteScheduled(un.UID, true), sql.ErrNoRows)
+	_, err = s.Notification().Reschedule(un.UID, at, true)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestNotificationRepository_ScheduledBroadcast(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("user_notifications", "users")
+
+	s := sqlstore.New(t.Context(), db, log.NewLog(t.Context(), &log.LogConfig{Component: "sqlstore", LogLevel: "debug"}))
+	u := &models.User{
+		Username:          "testuser",
+		EncryptedPassword: "encrypted_password",
+		Email:             "email@example.com",
+		Role:              "user",
+		OrgID:             domain.DefaultOrgID,
+	}
+	assert.NoError(t, s.User().Create(u))
+
+	at := time.Now().Add(time.Hour)
+	un := &models.UserNotification{UserID: u.ID, OrgID: u.OrgID, DeliverAt: &at, Scheduled: true, Broadcast: true}
+	assert.NoError(t, s.Notification().Create(un, []byte(`{"title":"hello","message":"hello"}`)))
+
+	// Без права рассылки уведомление рассылки не видно и не меняется
+	scheduled, err := s.Notification().GetScheduled(false)
+	assert.NoError(t, err)
+	assert.Empty(t, scheduled)
+	_, err = s.Notification().Reschedule(un.UID, at.Add(time.Hour), false)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.ErrorIs(t, s.Notification().DeleteScheduled(un.UID, false), sql.ErrNoRows)
+
+	scheduled, err = s.Notification().GetScheduled(true)
+	assert.NoError(t, err)
+	assert.Len(t, scheduled, 1)
+	assert.True(t, scheduled[0].Broadcast)
+	assert.NoError(t, s.Notification().DeleteScheduled(un.UID, true))
+}
+
+func TestNotificationRepository_DeferTimezone(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("user_notifications", "users")
+
+	s := sqlstore.New(t.Context(), db, log.NewLog(t.Context(), &log.LogConfig{Component: "sqlstore", LogLevel: "debug"}))
+	u := &models.User{
+		Username:          "testuser",
+		EncryptedPassword: "encrypted_password",
+		Email:             "email@example.com",
+		Role:              "user",
+		OrgID:             domain.DefaultOrgID,
+	}
+	assert.NoError(t, s.User().Create(u))
+	msk, err := time.LoadLocation("Europe/Moscow")
+	assert.NoError(t, err)
+	ny, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	// Время окончания тихих часов приходит в часовом поясе получателя
+	due := &models.UserNotification{UserID: u.ID, OrgID: u.OrgID}
+	assert.NoError(t, s.Notification().Create(due, []byte(`{"title":"hello","message":"hello"}`)))
+	assert.NoError(t, s.Notification().Defer(due.UID, time.Now().Add(-time.Minute).In(msk)))
+	later := &models.UserNotification{UserID: u.ID, OrgID: u.OrgID}
+	assert.NoError(t, s.Notification().Create(later, []byte(`{"title":"hello","message":"hello"}`)))
+	assert.NoError(t, s.Notification().Defer(later.UID, time.Now().Add(time.Hour).In(ny)))
+
+	claimed, err := s.Notification().ClaimDue(10, time.Minute)
+	assert.NoError(t, err)
+	if assert.Len(t, claimed, 1) {
+		assert.Equal(t, due.UID, claimed[0].UID)
+	}
+}
+
+func TestNotificationRepository_ClaimDueLease(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("user_notifications", "users")
+
+	s := sqlstore.New(t.Context(), db, log.NewLog(t.Context(), &log.LogConfig{Component: "sqlstore", LogLevel: "debug"}))
+	u := &models.User{
+		Username:          "testuser",
+		EncryptedPassword: "encrypted_password",
+		Email:             "email@example.com",
+		Role:              "user",
+		OrgID:             domain.DefaultOrgID,
+	}
+	assert.NoError(t, s.User().Create(u))
+	un := &models.UserNotification{UserID: u.ID, OrgID: u.OrgID}
+	assert.NoError(t, s.Notification().Create(un, []byte(`{"title":"hello","message":"hello"}`)))
+	assert.NoError(t, s.Notification().Defer(un.UID, time.Now().Add(-time.Minute)))
+
+	claimed, err := s.Notification().ClaimDue(10, time.Second)
+	assert.NoError(t, err)
+	if !assert.Len(t, claimed, 1) {
+		return
+	}
+	due, err := s.Notification().ClaimDue(10, time.Second)
+	assert.NoError(t, err)
+	assert.Empty(t, due)
+
+	// Не завершённое процессом уведомление забирается снова после lease
+	time.Sleep(1500 * time.Millisecond)
+	reclaimed, err := s.Notification().ClaimDue(10, time.Second)
+	assert.NoError(t, err)
+	if assert.Len(t, reclaimed, 1) {
+		assert.Equal(t, un.UID, reclaimed[0].UID)
+	}
+
+	// Устаревший lease не снимает новый
+	assert.NoError(t, s.Notification().FinishDue(un.UID, *claimed[0].DeliverAt))
+	got, err := s.Notification().GetById(un.UID)
+	assert.NoError(t, err)
+	assert.NotNil(t, got.DeliverAt)
+
+	assert.NoError(t, s.Notification().FinishDue(un.UID, *reclaimed[0].DeliverAt))
+	got, err = s.Notification().GetById(un.UID)
+	assert.NoError(t, err)
+	assert.Nil(t, got.DeliverAt)
+}
@@ -0,0 +1,42 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+)
+
+type OIDCStateRepository struct {
+	store *Store
+}
+
+// Create сохраняет state входа. expires_at хранится без часового пояса,
+// поэтому время истечения записывается в UTC.
+func (r *OIDCStateRepository) Create(s *models.OIDCState) error {
+	if err := r.store.db.QueryRow(
+		"INSERT INTO oidc_states (state_hash, provider, code_verifier, nonce, expires_at) VALUES ($1, $2, $3, $4, $5) RETURNING created_at",
+		s.StateHash, s.Provider, s.CodeVerifier, s.Nonce, s.ExpiresAt.UTC(),
+	).Scan(&s.CreatedAt); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Consume погашает state провайдера provider. Повторный, чужой или истёкший
+// state отклоняется с domain.ErrInvalidOIDCState.
+func (r *OIDCStateRepository) Consume(stateHash string, provider string) (*models.OIDCState, error) {
+	s := &models.OIDCState{}
+	if err := r.store.db.QueryRow(
+		`UPDATE oidc_states SET used_at = NOW()
+		WHERE state_hash = $1 AND provider = $2 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING state_hash, provider, code_verifier, nonce, created_at, expires_at, used_at`,
+		stateHash, provider,
+	).Scan(&s.StateHash, &s.Provider, &s.CodeVerifier, &s.Nonce, &s.CreatedAt, &s.ExpiresAt, &s.UsedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrInvalidOIDCState
+		}
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,57 @@
+package sqlstore
+
+import (
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/lib/pq"
+)
+
+type OrganizationRepository struct {
+	store *Store
+}
+
+func (r *OrganizationRepository) Create(o *models.Organization) error {
+	if err := r.store.db.QueryRow(
+		"INSERT INTO organizations (slug, name) VALUES ($1, $2) RETURNING id, created_at",
+		o.Slug, o.Name,
+	).Scan(&o.ID, &o.CreatedAt); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return domain.ErrOrganizationSlugTaken
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *OrganizationRepository) GetById(id int) (*models.Organization, error) {
+	o := &models.Organization{}
+	if err := r.store.db.QueryRow(
+		"SELECT id, slug, name, created_at FROM organizations WHERE id = $1", id,
+	).Scan(&o.ID, &o.Slug, &o.Name, &o.CreatedAt); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+func (r *OrganizationRepository) Get() ([]*models.Organization, error) {
+	orgs := make([]*models.Organization, 0, 10)
+	rows, err := r.store.db.Query(
+		"SELECT id, slug, name, created_at FROM organizations ORDER BY id",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		o := &models.Organization{}
+		if err := rows.Scan(&o.ID, &o.Slug, &o.Name, &o.CreatedAt); err != nil {
+			return nil, err
+		}
+		orgs = append(orgs, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return orgs, nil
+}
@@ -0,0 +1,60 @@
+package sqlstore_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/store/sqlstore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore_Tenant(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("users")
+
+	s := sqlstore.New(t.Context(), db, log.NewLog(t.Context(), &log.LogConfig{Component: "sqlstore", LogLevel: "debug"}))
+	o := &models.Organization{Slug: "acme", Name: "Acme"}
+	assert.NoError(t, s.Organization().Create(o))
+	defer func() {
+		db.Exec("DELETE FROM users WHERE org_id = $1", o.ID)
+		db.Exec("DELETE FROM organizations WHERE id = $1", o.ID)
+	}()
+	assert.ErrorIs(t, s.Organization().Create(&models.Organization{Slug: "acme", Name: "Acme"}), domain.ErrOrganizationSlugTaken)
+
+	acme := s.Tenant(o.ID)
+	// Организация строки берётся из хранилища, а не из модели
+	u := &models.User{
+		Username:          "alice",
+		EncryptedPassword: "encrypted_password",
+		Email:             "alice@example.com",
+		Role:              "user",
+		OrgID:             domain.DefaultOrgID,
+	}
+	assert.NoError(t, acme.User().Create(u))
+	assert.Equal(t, o.ID, u.OrgID)
+	other := &models.User{
+		Username:          "bob",
+		EncryptedPassword: "encrypted_password",
+		Email:             "bob@example.com",
+		Role:              "user",
+		OrgID:             domain.DefaultOrgID,
+	}
+	assert.NoError(t, s.User().Create(other))
+
+	users, err := acme.User().Get()
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.Equal(t, "alice", users[0].Username)
+
+	_, err = acme.User().GetById(other.ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.ErrorIs(t, acme.User().Delete(other.ID), sql.ErrNoRows)
+
+	// Хранилище без организации видит всех
+	got, err := s.User().GetById(u.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, o.ID, got.OrgID)
+}
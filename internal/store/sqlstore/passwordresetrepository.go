@@ -0,0 +1,57 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+)
+
+type PasswordResetRepository struct {
+	store *Store
+}
+
+// Create сохраняет токен сброса. expires_at хранится без часового пояса,
+// поэтому время истечения записывается в UTC.
+func (r *PasswordResetRepository) Create(p *models.PasswordReset) error {
+	if err := r.store.db.QueryRow(
+		"INSERT INTO password_resets (user_id, token_hash, expires_at) VALUES ($1, $2, $3) RETURNING id, created_at",
+		p.UserID, p.TokenHash, p.ExpiresAt.UTC(),
+	).Scan(&p.ID, &p.CreatedAt); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Reset погашает токен tokenHash, меняет пароль его владельца и гасит остальные
+// его токены сброса. Возвращает id пользователя или domain.ErrInvalidResetToken,
+// если токен не найден, уже использован или истёк.
+func (r *PasswordResetRepository) Reset(tokenHash string, encryptedPassword string) (int, error) {
+	tx, err := r.store.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var userID int
+	if err := tx.QueryRow(
+		"UPDATE password_resets SET used_at = NOW() WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW() RETURNING user_id", tokenHash,
+	).Scan(&userID); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, domain.ErrInvalidResetToken
+		}
+		return 0, err
+	}
+
+	if _, err := tx.Exec(
+		"UPDATE users SET encrypted_password = $2 WHERE id = $1", userID, encryptedPassword,
+	); err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec(
+		"UPDATE password_resets SET used_at = NOW() WHERE user_id = $1 AND used_at IS NULL", userID,
+	); err != nil {
+		return 0, err
+	}
+	return userID, tx.Commit()
+}
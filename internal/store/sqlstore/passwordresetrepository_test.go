@@ -0,0 +1,73 @@
+package sqlstore_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/store/sqlstore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordResetRepository_Reset(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("password_resets", "users")
+
+	s := sqlstore.New(t.Context(), db, log.NewLog(t.Context(), &log.LogConfig{Component: "sqlstore", LogLevel: "debug"}))
+	u := &models.User{
+		Username:          "testuser",
+		EncryptedPassword: "encrypted_password",
+		Email:             "email@example.com",
+		Role:              "user",
+		OrgID:             domain.DefaultOrgID,
+	}
+	assert.NoError(t, s.User().Create(u))
+
+	first := &models.PasswordReset{UserID: u.ID, TokenHash: "hash1", ExpiresAt: time.Now().Add(time.Hour)}
+	second := &models.PasswordReset{UserID: u.ID, TokenHash: "hash2", ExpiresAt: time.Now().Add(time.Hour)}
+	expired := &models.PasswordReset{UserID: u.ID, TokenHash: "hash3", ExpiresAt: time.Now().Add(-time.Hour)}
+	assert.NoError(t, s.PasswordReset().Create(first))
+	assert.NoError(t, s.PasswordReset().Create(second))
+	assert.NoError(t, s.PasswordReset().Create(expired))
+
+	_, err := s.PasswordReset().Reset("hash3", "new_password")
+	assert.ErrorIs(t, err, domain.ErrInvalidResetToken)
+
+	userID, err := s.PasswordReset().Reset("hash1", "new_password")
+	assert.NoError(t, err)
+	assert.Equal(t, u.ID, userID)
+
+	got, err := s.User().GetById(u.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "new_password", got.EncryptedPassword)
+
+	// После сброса остальные токены пользователя тоже недействительны
+	_, err = s.PasswordReset().Reset("hash2", "other_password")
+	assert.ErrorIs(t, err, domain.ErrInvalidResetToken)
+}
+
+func TestPasswordResetRepository_ExpiresAtTimezone(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("password_resets", "users")
+
+	s := sqlstore.New(t.Context(), db, log.NewLog(t.Context(), &log.LogConfig{Component: "sqlstore", LogLevel: "debug"}))
+	u := &models.User{
+		Username:          "testuser",
+		EncryptedPassword: "encrypted_password",
+		Email:             "email@example.com",
+		Role:              "user",
+		OrgID:             domain.DefaultOrgID,
+	}
+	assert.NoError(t, s.User().Create(u))
+	ny, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	// Ссылка, выданная на сервере не в UTC, действует до ExpiresAt
+	p := &models.PasswordReset{UserID: u.ID, TokenHash: "hash", ExpiresAt: time.Now().Add(time.Hour).In(ny)}
+	assert.NoError(t, s.PasswordReset().Create(p))
+	userID, err := s.PasswordReset().Reset("hash", "new_password")
+	assert.NoError(t, err)
+	assert.Equal(t, u.ID, userID)
+}
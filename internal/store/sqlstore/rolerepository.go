@@ -0,0 +1,48 @@
+package sqlstore
+
+type RoleRepository struct {
+	store *Store
+}
+
+func (r *RoleRepository) Exists(role string) (bool, error) {
+	var exists bool
+	if err := r.store.db.QueryRow(
+		"SELECT EXISTS (SELECT 1 FROM roles WHERE name = $1)", role,
+	).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+func (r *RoleRepository) GetPermissions(role string) ([]string, error) {
+	p := make([]string, 0, 10)
+	rows, err := r.store.db.Query(
+		"SELECT permission FROM role_permissions WHERE role = $1 ORDER BY permission", role,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var permission string
+		if err := rows.Scan(&permission); err != nil {
+			return nil, err
+		}
+		p = append(p, permission)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (r *RoleRepository) PermissionExists(permission string) (bool, error) {
+	var exists bool
+	if err := r.store.db.QueryRow(
+		"SELECT EXISTS (SELECT 1 FROM permissions WHERE name = $1)", permission,
+	).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
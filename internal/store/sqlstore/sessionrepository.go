@@ -0,0 +1,118 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+)
+
+type SessionRepository struct {
+	store *Store
+}
+
+// Create сохраняет сессию. expires_at хранится без часового пояса, поэтому
+// время истечения записывается в UTC.
+func (r *SessionRepository) Create(s *models.Session) error {
+	if err := r.store.db.QueryRow(
+		"INSERT INTO sessions (id, family_id, user_id, token_hash, expires_at) VALUES ($1, $2, $3, $4, $5) RETURNING created_at",
+		s.ID, s.FamilyID, s.UserID, s.TokenHash, s.ExpiresAt.UTC(),
+	).Scan(&s.CreatedAt); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *SessionRepository) GetById(id string) (*models.Session, error) {
+	s := &models.Session{}
+	if err := r.store.db.QueryRow(
+		"SELECT id, family_id, user_id, token_hash, created_at, expires_at, rotated_at, revoked_at FROM sessions WHERE id = $1", id,
+	).Scan(
+		&s.ID, &s.FamilyID, &s.UserID, &s.TokenHash, &s.CreatedAt, &s.ExpiresAt, &s.RotatedAt, &s.RevokedAt,
+	); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Rotate помечает сессию id как использованную и сохраняет следующую сессию
+// того же семейства. Если id уже была ротирована или отозвана, возвращает
+// domain.ErrRefreshTokenReused и ничего не сохраняет.
+func (r *SessionRepository) Rotate(id string, next *models.Session) error {
+	tx, err := r.store.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(
+		"UPDATE sessions SET rotated_at = NOW() WHERE id = $1 AND rotated_at IS NULL AND revoked_at IS NULL", id,
+	)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrRefreshTokenReused
+	}
+
+	if err := tx.QueryRow(
+		"INSERT INTO sessions (id, family_id, user_id, token_hash, expires_at) VALUES ($1, $2, $3, $4, $5) RETURNING created_at",
+		next.ID, next.FamilyID, next.UserID, next.TokenHash, next.ExpiresAt.UTC(),
+	).Scan(&next.CreatedAt); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *SessionRepository) RevokeFamily(familyID string) error {
+	_, err := r.store.db.Exec(
+		"UPDATE sessions SET revoked_at = NOW() WHERE family_id = $1 AND revoked_at IS NULL", familyID,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *SessionRepository) RevokeByUserId(userID int) error {
+	_, err := r.store.db.Exec(
+		"UPDATE sessions SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL", userID,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// FamilyAge возвращает, сколько времени прошло со входа, начавшего семейство
+// сессий familyID пользователя userID. Если семейства нет, возвращает
+// sql.ErrNoRows.
+func (r *SessionRepository) FamilyAge(familyID string, userID int) (time.Duration, error) {
+	var age sql.NullFloat64
+	if err := r.store.db.QueryRow(
+		"SELECT EXTRACT(EPOCH FROM NOW() - MIN(created_at)) FROM sessions WHERE family_id = $1 AND user_id = $2", familyID, userID,
+	).Scan(&age); err != nil {
+		return 0, err
+	}
+	if !age.Valid {
+		return 0, sql.ErrNoRows
+	}
+	return time.Duration(age.Float64 * float64(time.Second)), nil
+}
+
+// IsFamilyActive сообщает, действует ли семейство сессий. Сессии
+// заблокированного пользователя считаются недействующими.
+func (r *SessionRepository) IsFamilyActive(familyID string) (bool, error) {
+	var active bool
+	if err := r.store.db.QueryRow(
+		"SELECT EXISTS (SELECT 1 FROM sessions s JOIN users u ON u.id = s.user_id WHERE s.family_id = $1 AND s.revoked_at IS NULL AND s.expires_at > NOW() AND u.disabled_at IS NULL)", familyID,
+	).Scan(&active); err != nil {
+		return false, err
+	}
+	return active, nil
+}
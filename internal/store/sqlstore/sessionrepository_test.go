@@ -0,0 +1,130 @@
+package sqlstore_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/store/sqlstore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionRepository_Rotate(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("sessions", "users")
+
+	s := sqlstore.New(t.Context(), db, log.NewLog(t.Context(), &log.LogConfig{Component: "sqlstore", LogLevel: "debug"}))
+	u := &models.User{
+		Username:          "testuser",
+		EncryptedPassword: "encrypted_password",
+		Email:             "email@example.com",
+		Role:              "user",
+		OrgID:             domain.DefaultOrgID,
+	}
+	assert.NoError(t, s.User().Create(u))
+
+	first := &models.Session{
+		ID:        "first",
+		FamilyID:  "family",
+		UserID:    u.ID,
+		TokenHash: "hash1",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	assert.NoError(t, s.Session().Create(first))
+
+	second := &models.Session{
+		ID:        "second",
+		FamilyID:  "family",
+		UserID:    u.ID,
+		TokenHash: "hash2",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	assert.NoError(t, s.Session().Rotate(first.ID, second))
+
+	// Повторная ротация того же токена должна считаться переиспользованием
+	third := &models.Session{
+		ID:        "third",
+		FamilyID:  "family",
+		UserID:    u.ID,
+		TokenHash: "hash3",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	assert.ErrorIs(t, s.Session().Rotate(first.ID, third), domain.ErrRefreshTokenReused)
+
+	assert.NoError(t, s.Session().RevokeFamily("family"))
+	got, err := s.Session().GetById(second.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, got.RevokedAt)
+}
+
+func TestSessionRepository_IsFamilyActiveDisabledUser(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("sessions", "users")
+
+	s := sqlstore.New(t.Context(), db, log.NewLog(t.Context(), &log.LogConfig{Component: "sqlstore", LogLevel: "debug"}))
+	u := &models.User{
+		Username:          "testuser",
+		EncryptedPassword: "encrypted_password",
+		Email:             "email@example.com",
+		Role:              "user",
+		OrgID:             domain.DefaultOrgID,
+	}
+	assert.NoError(t, s.User().Create(u))
+	assert.NoError(t, s.Session().Create(&models.Session{
+		ID:        "session",
+		FamilyID:  "family",
+		UserID:    u.ID,
+		TokenHash: "hash",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}))
+
+	active, err := s.Session().IsFamilyActive("family")
+	assert.NoError(t, err)
+	assert.True(t, active)
+
+	// Блокировка сразу делает недействительными уже выданные access токены
+	now := time.Now()
+	u.DisabledAt = &now
+	assert.NoError(t, s.User().Update(u))
+	active, err = s.Session().IsFamilyActive("family")
+	assert.NoError(t, err)
+	assert.False(t, active)
+
+	n, err := s.User().CountByRole("user")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestSessionRepository_ExpiresAtTimezone(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("sessions", "users")
+
+	s := sqlstore.New(t.Context(), db, log.NewLog(t.Context(), &log.LogConfig{Component: "sqlstore", LogLevel: "debug"}))
+	u := &models.User{
+		Username:          "testuser",
+		EncryptedPassword: "encrypted_password",
+		Email:             "email@example.com",
+		Role:              "user",
+		OrgID:             domain.DefaultOrgID,
+	}
+	assert.NoError(t, s.User().Create(u))
+	ny, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	// Сервер может работать не в UTC: сессия всё равно действует ровно до ExpiresAt
+	assert.NoError(t, s.Session().Create(&models.Session{
+		ID:        "session",
+		FamilyID:  "family",
+		UserID:    u.ID,
+		TokenHash: "hash",
+		ExpiresAt: time.Now().Add(time.Hour).In(ny),
+	}))
+	active, err := s.Session().IsFamilyActive("family")
+	assert.NoError(t, err)
+	assert.True(t, active)
+	got, err := s.Session().GetById("session")
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), got.ExpiresAt, time.Minute)
+}
@@ -10,11 +10,30 @@ import (
 )
 
 type Store struct {
-	ctx                    context.Context
-	logger                 *log.Log
-	db                     *sql.DB
-	userRepository         *UserRepository
-	notificationRepository *NotificationRepository
+	ctx                         context.Context
+	logger                      *log.Log
+	db                          *sql.DB
+	organizationRepository      *OrganizationRepository
+	userRepository              *UserRepository
+	notificationRepository      *NotificationRepository
+	preferencesRepository       *NotificationPreferencesRepository
+	sessionRepository           *SessionRepository
+	roleRepository              *RoleRepository
+	apiKeyRepository            *APIKeyRepository
+	inviteRepository            *InviteRepository
+	passwordResetRepository     *PasswordResetRepository
+	emailVerificationRepository *EmailVerificationRepository
+	loginAttemptRepository      *LoginAttemptRepository
+	totpRepository              *TOTPRepository
+	externalIdentityRepository  *ExternalIdentityRepository
+	oidcStateRepository         *OIDCStateRepository
+	groupRepository             *GroupRepository
+	segmentRepository           *SegmentRepository
+	dataExportRepository        *DataExportRepository
+	templateRepository          *TemplateRepository
+	// Организация, которой ограничено хранилище, если scoped
+	orgID  int
+	scoped bool
 }
 
 func New(ctx context.Context, db *sql.DB, logger *log.Log) *Store {
@@ -25,6 +44,45 @@ func New(ctx context.Context, db *sql.DB, logger *log.Log) *Store {
 	}
 }
 
+// Tenant возвращает копию хранилища, ограниченную организацией orgID.
+func (s *Store) Tenant(orgID int) store.Store {
+	return &Store{
+		ctx:    s.ctx,
+		db:     s.db,
+		logger: s.logger,
+		orgID:  orgID,
+		scoped: true,
+	}
+}
+
+// org возвращает организацию хранилища или nil, если оно не ограничено
+// организацией. Запросы фильтруют по нему условием
+// "($n::bigint IS NULL OR org_id = $n)".
+func (s *Store) org() interface{} {
+	if !s.scoped {
+		return nil
+	}
+	return s.orgID
+}
+
+// assignOrg записывает в orgID организацию хранилища, если оно ею ограничено:
+// создать строку в чужой организации нельзя.
+func (s *Store) assignOrg(orgID *int) {
+	if s.scoped {
+		*orgID = s.orgID
+	}
+}
+
+func (s *Store) Organization() store.OrganizationRepository {
+	if s.organizationRepository != nil {
+		return s.organizationRepository
+	}
+	s.organizationRepository = &OrganizationRepository{
+		store: s,
+	}
+	return s.organizationRepository
+}
+
 func (s *Store) User() store.UserRepository {
 	if s.userRepository != nil {
 		return s.userRepository
@@ -44,3 +102,153 @@ func (s *Store) Notification() store.NotificationRepository {
 	}
 	return s.notificationRepository
 }
+
+func (s *Store) NotificationPreferences() store.NotificationPreferencesRepository {
+	if s.preferencesRepository != nil {
+		return s.preferencesRepository
+	}
+	s.preferencesRepository = &NotificationPreferencesRepository{
+		store: s,
+	}
+	return s.preferencesRepository
+}
+
+func (s *Store) Session() store.SessionRepository {
+	if s.sessionRepository != nil {
+		return s.sessionRepository
+	}
+	s.sessionRepository = &SessionRepository{
+		store: s,
+	}
+	return s.sessionRepository
+}
+
+func (s *Store) Role() store.RoleRepository {
+	if s.roleRepository != nil {
+		return s.roleRepository
+	}
+	s.roleRepository = &RoleRepository{
+		store: s,
+	}
+	return s.roleRepository
+}
+
+func (s *Store) APIKey() store.APIKeyRepository {
+	if s.apiKeyRepository != nil {
+		return s.apiKeyRepository
+	}
+	s.apiKeyRepository = &APIKeyRepository{
+		store: s,
+	}
+	return s.apiKeyRepository
+}
+
+func (s *Store) Invite() store.InviteRepository {
+	if s.inviteRepository != nil {
+		return s.inviteRepository
+	}
+	s.inviteRepository = &InviteRepository{
+		store: s,
+	}
+	return s.inviteRepository
+}
+
+func (s *Store) PasswordReset() store.PasswordResetRepository {
+	if s.passwordResetRepository != nil {
+		return s.passwordResetRepository
+	}
+	s.passwordResetRepository = &PasswordResetRepository{
+		store: s,
+	}
+	return s.passwordResetRepository
+}
+
+func (s *Store) EmailVerification() store.EmailVerificationRepository {
+	if s.emailVerificationRepository != nil {
+		return s.emailVerificationRepository
+	}
+	s.emailVerificationRepository = &EmailVerificationRepository{
+		store: s,
+	}
+	return s.emailVerificationRepository
+}
+
+func (s *Store) LoginAttempt() store.LoginAttemptRepository {
+	if s.loginAttemptRepository != nil {
+		return s.loginAttemptRepository
+	}
+	s.loginAttemptRepository = &LoginAttemptRepository{
+		store: s,
+	}
+	return s.loginAttemptRepository
+}
+
+func (s *Store) TOTP() store.TOTPRepository {
+	if s.totpRepository != nil {
+		return s.totpRepository
+	}
+	s.totpRepository = &TOTPRepository{
+		store: s,
+	}
+	return s.totpRepository
+}
+
+func (s *Store) ExternalIdentity() store.ExternalIdentityRepository {
+	if s.externalIdentityRepository != nil {
+		return s.externalIdentityRepository
+	}
+	s.externalIdentityRepository = &ExternalIdentityRepository{
+		store: s,
+	}
+	return s.externalIdentityRepository
+}
+
+func (s *Store) OIDCState() store.OIDCStateRepository {
+	if s.oidcStateRepository != nil {
+		return s.oidcStateRepository
+	}
+	s.oidcStateRepository = &OIDCStateRepository{
+		store: s,
+	}
+	return s.oidcStateRepository
+}
+
+func (s *Store) Group() store.GroupRepository {
+	if s.groupRepository != nil {
+		return s.groupRepository
+	}
+	s.groupRepository = &GroupRepository{
+		store: s,
+	}
+	return s.groupRepository
+}
+
+func (s *Store) Segment() store.SegmentRepository {
+	if s.segmentRepository != nil {
+		return s.segmentRepository
+	}
+	s.segmentRepository = &SegmentRepository{
+		store: s,
+	}
+	return s.segmentRepository
+}
+
+func (s *Store) DataExport() store.DataExportRepository {
+	if s.dataExportRepository != nil {
+		return s.dataExportRepository
+	}
+	s.dataExportRepository = &DataExportRepository{
+		store: s,
+	}
+	return s.dataExportRepository
+}
+
+func (s *Store) Template() store.TemplateRepository {
+	if s.templateRepository != nil {
+		return s.templateRepository
+	}
+	s.templateRepository = &TemplateRepository{
+		store: s,
+	}
+	return s.templateRepository
+}
@@ -0,0 +1,134 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/lib/pq"
+)
+
+type TemplateRepository struct {
+	store *Store
+}
+
+const templateColumns = "id, key, description, category, priority, icon, image, default_locale, variables, locales, org_id, created_by, created_at, updated_at"
+
+func (r *TemplateRepository) Create(t *models.NotificationTemplate) error {
+	variables, locales, err := marshalTemplate(t)
+	if err != nil {
+		return err
+	}
+	r.store.assignOrg(&t.OrgID)
+	if err := r.store.db.QueryRow(
+		`INSERT INTO notification_templates (key, description, category, priority, icon, image, default_locale, variables, locales, org_id, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id, created_at, updated_at`,
+		t.Key, t.Description, t.Category, t.Priority, t.Icon, t.Image, t.DefaultLocale, variables, locales, t.OrgID, t.CreatedBy,
+	).Scan(&t.ID, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return domain.ErrTemplateKeyTaken
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *TemplateRepository) GetByKey(key string) (*models.NotificationTemplate, error) {
+	t, err := scanTemplate(r.store.db.QueryRow(
+		"SELECT "+templateColumns+" FROM notification_templates WHERE key = $1 AND ($2::bigint IS NULL OR org_id = $2)", key, r.store.org(),
+	))
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (r *TemplateRepository) Get() ([]*models.NotificationTemplate, error) {
+	rows, err := r.store.db.Query(
+		"SELECT "+templateColumns+" FROM notification_templates WHERE ($1::bigint IS NULL OR org_id = $1) ORDER BY key", r.store.org(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	templates := make([]*models.NotificationTemplate, 0, 10)
+	for rows.Next() {
+		t, err := scanTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// Update сохраняет всё, кроме ключа, по ключу t.Key.
+func (r *TemplateRepository) Update(t *models.NotificationTemplate) error {
+	variables, locales, err := marshalTemplate(t)
+	if err != nil {
+		return err
+	}
+	if err := r.store.db.QueryRow(
+		`UPDATE notification_templates SET description = $2, category = $3, priority = $4, icon = $5, image = $6, default_locale = $7, variables = $8, locales = $9, updated_at = NOW()
+		WHERE key = $1 AND ($10::bigint IS NULL OR org_id = $10) RETURNING id, org_id, created_by, created_at, updated_at`,
+		t.Key, t.Description, t.Category, t.Priority, t.Icon, t.Image, t.DefaultLocale, variables, locales, r.store.org(),
+	).Scan(&t.ID, &t.OrgID, &t.CreatedBy, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *TemplateRepository) Delete(key string) error {
+	res, err := r.store.db.Exec(
+		"DELETE FROM notification_templates WHERE key = $1 AND ($2::bigint IS NULL OR org_id = $2)", key, r.store.org(),
+	)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func marshalTemplate(t *models.NotificationTemplate) ([]byte, []byte, error) {
+	variables, err := json.Marshal(t.Variables)
+	if err != nil {
+		return nil, nil, err
+	}
+	locales, err := json.Marshal(t.Locales)
+	if err != nil {
+		return nil, nil, err
+	}
+	return variables, locales, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTemplate(row rowScanner) (*models.NotificationTemplate, error) {
+	t := &models.NotificationTemplate{}
+	var variables, locales []byte
+	if err := row.Scan(
+		&t.ID, &t.Key, &t.Description, &t.Category, &t.Priority, &t.Icon, &t.Image, &t.DefaultLocale, &variables, &locales, &t.OrgID, &t.CreatedBy, &t.CreatedAt, &t.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(variables, &t.Variables); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(locales, &t.Locales); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
@@ -0,0 +1,50 @@
+package sqlstore_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/store/sqlstore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateRepository(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("notification_templates")
+
+	s := sqlstore.New(t.Context(), db, log.NewLog(t.Context(), &log.LogConfig{Component: "sqlstore", LogLevel: "debug"}))
+	tpl := &models.NotificationTemplate{
+		Key:           "order.shipped",
+		Priority:      models.PriorityNormal,
+		DefaultLocale: "en",
+		Variables:     []models.TemplateVariable{{Name: "order", Type: models.TemplateVarString, Required: true}},
+		Locales: map[string]models.TemplateContent{
+			"en": {Title: "Order {{.order}} shipped", Message: "On its way"},
+			"ru": {Title: "Заказ {{.order}} отправлен", Message: "Уже в пути"},
+		},
+		OrgID: domain.DefaultOrgID,
+	}
+	assert.NoError(t, s.Template().Create(tpl))
+	assert.ErrorIs(t, s.Template().Create(&models.NotificationTemplate{Key: "order.shipped", OrgID: domain.DefaultOrgID}), domain.ErrTemplateKeyTaken)
+
+	got, err := s.Template().GetByKey("order.shipped")
+	assert.NoError(t, err)
+	assert.Equal(t, tpl.Variables, got.Variables)
+	assert.Equal(t, tpl.Locales, got.Locales)
+
+	// Чужая организация шаблон не видит
+	_, err = s.Tenant(domain.DefaultOrgID + 1000).Template().GetByKey("order.shipped")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	tpl.Locales["en"] = models.TemplateContent{Title: "Order {{.order}} sent", Message: "On its way"}
+	assert.NoError(t, s.Template().Update(tpl))
+	got, err = s.Template().GetByKey("order.shipped")
+	assert.NoError(t, err)
+	assert.Equal(t, "Order {{.order}} sent", got.Locales["en"].Title)
+
+	assert.NoError(t, s.Template().Delete("order.shipped"))
+	assert.ErrorIs(t, s.Template().Delete("order.shipped"), sql.ErrNoRows)
+}
@@ -0,0 +1,121 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+)
+
+type TOTPRepository struct {
+	store *Store
+}
+
+// Create сохраняет новый неподтверждённый секрет, заменяя прежний
+// неподтверждённый. Подтверждённый секрет не перезаписывается.
+func (r *TOTPRepository) Create(t *models.TOTP) error {
+	if err := r.store.db.QueryRow(
+		`INSERT INTO user_totp (user_id, secret) VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret, last_used_step = 0, created_at = NOW()
+		WHERE user_totp.confirmed_at IS NULL
+		RETURNING created_at`,
+		t.UserID, t.Secret,
+	).Scan(&t.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.ErrMFAAlreadyEnabled
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *TOTPRepository) GetByUserId(userID int) (*models.TOTP, error) {
+	t := &models.TOTP{}
+	if err := r.store.db.QueryRow(
+		"SELECT user_id, secret, last_used_step, created_at, confirmed_at FROM user_totp WHERE user_id = $1", userID,
+	).Scan(&t.UserID, &t.Secret, &t.LastUsedStep, &t.CreatedAt, &t.ConfirmedAt); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Confirm включает второй фактор, запоминает использованный шаг step и
+// заменяет коды восстановления пользователя на codeHashes.
+func (r *TOTPRepository) Confirm(userID int, step int64, codeHashes []string) error {
+	tx, err := r.store.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(
+		"UPDATE user_totp SET confirmed_at = NOW(), last_used_step = $2 WHERE user_id = $1 AND confirmed_at IS NULL AND last_used_step < $2",
+		userID, step,
+	)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.ErrInvalidMFACode
+	}
+
+	if _, err := tx.Exec("DELETE FROM recovery_codes WHERE user_id = $1", userID); err != nil {
+		return err
+	}
+	for _, h := range codeHashes {
+		if _, err := tx.Exec(
+			"INSERT INTO recovery_codes (user_id, code_hash) VALUES ($1, $2)", userID, h,
+		); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// UseStep отмечает шаг step использованным. Повторный или более ранний шаг
+// отклоняется с domain.ErrInvalidMFACode, чтобы код нельзя было предъявить дважды.
+func (r *TOTPRepository) UseStep(userID int, step int64) error {
+	res, err := r.store.db.Exec(
+		"UPDATE user_totp SET last_used_step = $2 WHERE user_id = $1 AND confirmed_at IS NOT NULL AND last_used_step < $2",
+		userID, step,
+	)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.ErrInvalidMFACode
+	}
+	return nil
+}
+
+// UseRecoveryCode погашает код восстановления codeHash.
+func (r *TOTPRepository) UseRecoveryCode(userID int, codeHash string) error {
+	res, err := r.store.db.Exec(
+		"UPDATE recovery_codes SET used_at = NOW() WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL",
+		userID, codeHash,
+	)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.ErrInvalidMFACode
+	}
+	return nil
+}
+
+// Delete отключает второй фактор и удаляет коды восстановления.
+func (r *TOTPRepository) Delete(userID int) error {
+	tx, err := r.store.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM recovery_codes WHERE user_id = $1", userID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM user_totp WHERE user_id = $1", userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
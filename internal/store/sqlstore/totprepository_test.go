@@ -0,0 +1,49 @@
+package sqlstore_test
+
+import (
+	"testing"
+
+	"github.com/DANazavr/RATest/internal/domain"
+	"github.com/DANazavr/RATest/internal/domain/models"
+	"github.com/DANazavr/RATest/internal/log"
+	"github.com/DANazavr/RATest/internal/store/sqlstore"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTOTPRepository(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("recovery_codes", "user_totp", "users")
+
+	s := sqlstore.New(t.Context(), db, log.NewLog(t.Context(), &log.LogConfig{Component: "sqlstore", LogLevel: "debug"}))
+	u := &models.User{
+		Username:          "testuser",
+		EncryptedPassword: "encrypted_password",
+		Email:             "email@example.com",
+		Role:              "admin",
+		OrgID:             domain.DefaultOrgID,
+	}
+	assert.NoError(t, s.User().Create(u))
+
+	// Неподтверждённый секрет можно перевыпустить
+	assert.NoError(t, s.TOTP().Create(&models.TOTP{UserID: u.ID, Secret: "FIRST"}))
+	assert.NoError(t, s.TOTP().Create(&models.TOTP{UserID: u.ID, Secret: "SECOND"}))
+	assert.ErrorIs(t, s.TOTP().UseStep(u.ID, 100), domain.ErrInvalidMFACode)
+
+	assert.NoError(t, s.TOTP().Confirm(u.ID, 100, []string{"code1", "code2"}))
+	got, err := s.TOTP().GetByUserId(u.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "SECOND", got.Secret)
+	assert.NotNil(t, got.ConfirmedAt)
+	assert.ErrorIs(t, s.TOTP().Create(&models.TOTP{UserID: u.ID, Secret: "THIRD"}), domain.ErrMFAAlreadyEnabled)
+
+	// Код подключения и более ранние коды повторно не принимаются
+	assert.ErrorIs(t, s.TOTP().UseStep(u.ID, 100), domain.ErrInvalidMFACode)
+	assert.ErrorIs(t, s.TOTP().UseStep(u.ID, 99), domain.ErrInvalidMFACode)
+	assert.NoError(t, s.TOTP().UseStep(u.ID, 101))
+
+	assert.NoError(t, s.TOTP().UseRecoveryCode(u.ID, "code1"))
+	assert.ErrorIs(t, s.TOTP().UseRecoveryCode(u.ID, "code1"), domain.ErrInvalidMFACode)
+
+	assert.NoError(t, s.TOTP().Delete(u.ID))
+	assert.ErrorIs(t, s.TOTP().UseRecoveryCode(u.ID, "code2"), domain.ErrInvalidMFACode)
+}
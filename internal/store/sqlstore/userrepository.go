@@ -1,17 +1,30 @@
 package sqlstore
 
 import (
+	"database/sql"
+	"fmt"
+	"strings"
+
 	"github.com/DANazavr/RATest/internal/domain/models"
 )
 
+// Колонки, по которым можно сортировать список пользователей
+var userSortColumns = map[string]string{
+	"id":         "id",
+	"username":   "username",
+	"email":      "email",
+	"created_at": "created_at",
+}
+
 type UserRepository struct {
 	store *Store
 }
 
 func (r *UserRepository) Create(user *models.User) error {
+	r.store.assignOrg(&user.OrgID)
 	if err := r.store.db.QueryRow(
-		"INSERT INTO users (username, encrypted_password, email, role) VALUES ($1, $2, $3, $4) RETURNING id, created_at",
-		user.Username, user.EncryptedPassword, user.Email, user.Role,
+		"INSERT INTO users (username, encrypted_password, email, role, org_id) VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at",
+		user.Username, user.EncryptedPassword, user.Email, user.Role, user.OrgID,
 	).Scan(&user.ID, &user.CreatedAt); err != nil {
 		return err
 	}
@@ -21,9 +34,21 @@ func (r *UserRepository) Create(user *models.User) error {
 func (r *UserRepository) GetByUsername(username string) (*models.User, error) {
 	u := &models.User{}
 	if err := r.store.db.QueryRow(
-		"SELECT id, username, encrypted_password, email, role, created_at FROM users WHERE username = $1", username,
+		"SELECT id, username, encrypted_password, email, email_verified_at, role, org_id, disabled_at, created_at FROM users WHERE username = $1 AND ($2::bigint IS NULL OR org_id = $2)", username, r.store.org(),
 	).Scan(
-		&u.ID, &u.Username, &u.EncryptedPassword, &u.Email, &u.Role, &u.CreatedAt,
+		&u.ID, &u.Username, &u.EncryptedPassword, &u.Email, &u.EmailVerifiedAt, &u.Role, &u.OrgID, &u.DisabledAt, &u.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func (r *UserRepository) GetByEmail(email string) (*models.User, error) {
+	u := &models.User{}
+	if err := r.store.db.QueryRow(
+		"SELECT id, username, encrypted_password, email, email_verified_at, role, org_id, disabled_at, created_at FROM users WHERE email = $1 AND ($2::bigint IS NULL OR org_id = $2)", email, r.store.org(),
+	).Scan(
+		&u.ID, &u.Username, &u.EncryptedPassword, &u.Email, &u.EmailVerifiedAt, &u.Role, &u.OrgID, &u.DisabledAt, &u.CreatedAt,
 	); err != nil {
 		return nil, err
 	}
@@ -33,9 +58,9 @@ func (r *UserRepository) GetByUsername(username string) (*models.User, error) {
 func (r *UserRepository) GetById(id int) (*models.User, error) {
 	u := &models.User{}
 	if err := r.store.db.QueryRow(
-		"SELECT id, username, encrypted_password, email, role, created_at FROM users WHERE id = $1", id,
+		"SELECT id, username, encrypted_password, email, email_verified_at, role, org_id, disabled_at, created_at FROM users WHERE id = $1 AND ($2::bigint IS NULL OR org_id = $2)", id, r.store.org(),
 	).Scan(
-		&u.ID, &u.Username, &u.EncryptedPassword, &u.Email, &u.Role, &u.CreatedAt,
+		&u.ID, &u.Username, &u.EncryptedPassword, &u.Email, &u.EmailVerifiedAt, &u.Role, &u.OrgID, &u.DisabledAt, &u.CreatedAt,
 	); err != nil {
 		return nil, err
 	}
@@ -45,7 +70,7 @@ func (r *UserRepository) GetById(id int) (*models.User, error) {
 func (r *UserRepository) Get() ([]*models.User, error) {
 	u := make([]*models.User, 0, 100)
 	rows, err := r.store.db.Query(
-		"SELECT id, username, encrypted_password, email, role, created_at FROM users",
+		"SELECT id, username, encrypted_password, email, email_verified_at, role, org_id, disabled_at, created_at FROM users WHERE ($1::bigint IS NULL OR org_id = $1)", r.store.org(),
 	)
 	if err != nil {
 		return nil, err
@@ -55,7 +80,7 @@ func (r *UserRepository) Get() ([]*models.User, error) {
 	for rows.Next() {
 		user := &models.User{}
 		if err := rows.Scan(
-			&user.ID, &user.Username, &user.EncryptedPassword, &user.Email, &user.Role, &user.CreatedAt,
+			&user.ID, &user.Username, &user.EncryptedPassword, &user.Email, &user.EmailVerifiedAt, &user.Role, &user.OrgID, &user.DisabledAt, &user.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -66,3 +91,197 @@ func (r *UserRepository) Get() ([]*models.User, error) {
 	}
 	return u, nil
 }
+
+// List возвращает до f.Limit пользователей, подходящих под фильтр, в порядке
+// f.SortBy (при равенстве - по id), начиная после ключа f.After.
+func (r *UserRepository) List(f *models.UserFilter) ([]*models.User, error) {
+	col, ok := userSortColumns[f.SortBy]
+	if !ok {
+		col = "id"
+	}
+	dir, cmp := "ASC", ">"
+	if f.Desc {
+		dir, cmp = "DESC", "<"
+	}
+
+	conds, args := userFilterConditions(f, r.store.org())
+	if f.After != nil {
+		if col == "id" {
+			args = append(args, f.After.ID)
+			conds = append(conds, fmt.Sprintf("id %s $%d", cmp, len(args)))
+		} else {
+			args = append(args, f.After.Value, f.After.ID)
+			conds = append(conds, fmt.Sprintf("(%s, id) %s ($%d, $%d)", col, cmp, len(args)-1, len(args)))
+		}
+	}
+	query := "SELECT id, username, encrypted_password, email, email_verified_at, role, org_id, disabled_at, created_at FROM users"
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	args = append(args, f.Limit)
+	query += fmt.Sprintf(" ORDER BY %s %s, id %s LIMIT $%d", col, dir, dir, len(args))
+
+	rows, err := r.store.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	u := make([]*models.User, 0, f.Limit)
+	for rows.Next() {
+		user := &models.User{}
+		if err := rows.Scan(
+			&user.ID, &user.Username, &user.EncryptedPassword, &user.Email, &user.EmailVerifiedAt, &user.Role, &user.OrgID, &user.DisabledAt, &user.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		u = append(u, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// Count считает пользователей, подходящих под фильтр, без учёта f.After и f.Limit.
+func (r *UserRepository) Count(f *models.UserFilter) (int, error) {
+	conds, args := userFilterConditions(f, r.store.org())
+	query := "SELECT COUNT(*) FROM users"
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	var n int
+	if err := r.store.db.QueryRow(query, args...).Scan(&n); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+func userFilterConditions(f *models.UserFilter, org interface{}) ([]string, []interface{}) {
+	var conds []string
+	var args []interface{}
+	if org != nil {
+		args = append(args, org)
+		conds = append(conds, fmt.Sprintf("org_id = $%d", len(args)))
+	}
+	if f.Role != "" {
+		args = append(args, f.Role)
+		conds = append(conds, fmt.Sprintf("role = $%d", len(args)))
+	}
+	if f.CreatedFrom != nil {
+		args = append(args, *f.CreatedFrom)
+		conds = append(conds, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+	if f.CreatedTo != nil {
+		args = append(args, *f.CreatedTo)
+		conds = append(conds, fmt.Sprintf("created_at < $%d", len(args)))
+	}
+	if f.Search != "" {
+		args = append(args, likePrefix(strings.ToLower(f.Search)))
+		conds = append(conds, fmt.Sprintf("(lower(username) LIKE $%d OR lower(email) LIKE $%d)", len(args), len(args)))
+	}
+	if f.EmailVerified != nil {
+		if *f.EmailVerified {
+			conds = append(conds, "email_verified_at IS NOT NULL")
+		} else {
+			conds = append(conds, "email_verified_at IS NULL")
+		}
+	}
+	if f.Active {
+		conds = append(conds, "disabled_at IS NULL")
+	}
+	return conds, args
+}
+
+// likePrefix экранирует спецсимволы LIKE, чтобы s искался как обычный префикс.
+func likePrefix(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s) + "%"
+}
+
+// CountByRole считает незаблокированных пользователей с ролью role.
+func (r *UserRepository) CountByRole(role string) (int, error) {
+	var n int
+	if err := r.store.db.QueryRow(
+		"SELECT COUNT(*) FROM users WHERE role = $1 AND disabled_at IS NULL AND ($2::bigint IS NULL OR org_id = $2)", role, r.store.org(),
+	).Scan(&n); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+func (r *UserRepository) SetEmailVerified(id int) error {
+	_, err := r.store.db.Exec(
+		"UPDATE users SET email_verified_at = NOW() WHERE id = $1 AND ($2::bigint IS NULL OR org_id = $2)", id, r.store.org(),
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *UserRepository) UpdatePassword(id int, encryptedPassword string) error {
+	_, err := r.store.db.Exec(
+		"UPDATE users SET encrypted_password = $2 WHERE id = $1 AND ($3::bigint IS NULL OR org_id = $3)", id, encryptedPassword, r.store.org(),
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// ReplacePassword меняет хеш пароля, только если он всё ещё равен old, и
+// сообщает, была ли строка обновлена. Так пересчёт хеша при входе не
+// перезапишет пароль, сменённый параллельно.
+func (r *UserRepository) ReplacePassword(id int, old string, encryptedPassword string) (bool, error) {
+	res, err := r.store.db.Exec(
+		"UPDATE users SET encrypted_password = $3 WHERE id = $1 AND encrypted_password = $2 AND ($4::bigint IS NULL OR org_id = $4)",
+		id, old, encryptedPassword, r.store.org(),
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// Update сохраняет имя, email, отметку подтверждения email, роль и отметку
+// блокировки пользователя.
+func (r *UserRepository) Update(user *models.User) error {
+	res, err := r.store.db.Exec(
+		"UPDATE users SET username = $2, email = $3, email_verified_at = $4, role = $5, disabled_at = $6 WHERE id = $1 AND ($7::bigint IS NULL OR org_id = $7)",
+		user.ID, user.Username, user.Email, user.EmailVerifiedAt, user.Role, user.DisabledAt, r.store.org(),
+	)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// Delete удаляет пользователя. Его сессии, уведомления, токены и связанные
+// учётные записи удаляются каскадно.
+func (r *UserRepository) Delete(id int) error {
+	res, err := r.store.db.Exec(
+		"DELETE FROM users WHERE id = $1 AND ($2::bigint IS NULL OR org_id = $2)", id, r.store.org(),
+	)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
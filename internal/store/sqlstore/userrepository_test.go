@@ -1,7 +1,9 @@
 package sqlstore_test
 
 import (
+	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/DANazavr/RATest/internal/domain"
 	"github.com/DANazavr/RATest/internal/domain/models"
@@ -22,6 +24,7 @@ func TestUserRepository_Create(t *testing.T) {
 		EncryptedPassword: "encrypted_password",
 		Email:             "email@example.com",
 		Role:              "user",
+		OrgID:             domain.DefaultOrgID,
 	}
 	// Create a new user
 	err := store.User().Create(u)
@@ -43,6 +46,7 @@ func TestUserRepository_GetByUsername(t *testing.T) {
 		EncryptedPassword: "encrypted_password",
 		Email:             "email@example.com",
 		Role:              "user",
+		OrgID:             domain.DefaultOrgID,
 	}
 	u.Username = username
 	// Create a new user
@@ -50,3 +54,114 @@ func TestUserRepository_GetByUsername(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, u)
 }
+
+func TestUserRepository_Delete(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("user_notifications", "sessions", "users")
+
+	s := sqlstore.New(t.Context(), db, log.NewLog(t.Context(), &log.LogConfig{Component: "sqlstore", LogLevel: "debug"}))
+	u := &models.User{
+		Username:          "testuser",
+		EncryptedPassword: "encrypted_password",
+		Email:             "email@example.com",
+		Role:              "user",
+		OrgID:             domain.DefaultOrgID,
+	}
+	assert.NoError(t, s.User().Create(u))
+	assert.NoError(t, s.Session().Create(&models.Session{
+		ID:        "session",
+		FamilyID:  "family",
+		UserID:    u.ID,
+		TokenHash: "hash",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}))
+	un := &models.UserNotification{UserID: u.ID, OrgID: u.OrgID}
+	assert.NoError(t, s.Notification().Create(un, []byte(`{"title":"hello","message":"hello"}`)))
+
+	u.Email = "new@example.com"
+	assert.NoError(t, s.User().Update(u))
+	got, err := s.User().GetById(u.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "new@example.com", got.Email)
+
+	assert.NoError(t, s.User().Delete(u.ID))
+	active, err := s.Session().IsFamilyActive("family")
+	assert.NoError(t, err)
+	assert.False(t, active)
+	_, err = s.Notification().GetById(un.UID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	assert.ErrorIs(t, s.User().Delete(u.ID), sql.ErrNoRows)
+	assert.ErrorIs(t, s.User().Update(u), sql.ErrNoRows)
+}
+
+func TestUserRepository_ReplacePassword(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("users")
+
+	s := sqlstore.New(t.Context(), db, log.NewLog(t.Context(), &log.LogConfig{Component: "sqlstore", LogLevel: "debug"}))
+	u := &models.User{
+		Username:          "testuser",
+		EncryptedPassword: "encrypted_password",
+		Email:             "email@example.com",
+		Role:              "user",
+		OrgID:             domain.DefaultOrgID,
+	}
+	assert.NoError(t, s.User().Create(u))
+
+	// Пароль сменён после чтения хеша
+	assert.NoError(t, s.User().UpdatePassword(u.ID, "changed"))
+	replaced, err := s.User().ReplacePassword(u.ID, "encrypted_password", "rehashed")
+	assert.NoError(t, err)
+	assert.False(t, replaced)
+
+	replaced, err = s.User().ReplacePassword(u.ID, "changed", "rehashed")
+	assert.NoError(t, err)
+	assert.True(t, replaced)
+	got, err := s.User().GetById(u.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "rehashed", got.EncryptedPassword)
+}
+
+func TestUserRepository_List(t *testing.T) {
+	db, teardown := sqlstore.TestDB(t, databaseURL)
+	defer teardown("users")
+
+	s := sqlstore.New(t.Context(), db, log.NewLog(t.Context(), &log.LogConfig{Component: "sqlstore", LogLevel: "debug"}))
+	for _, name := range []string{"carol", "alice", "bob", "al_ex", "dave"} {
+		assert.NoError(t, s.User().Create(&models.User{
+			Username:          name,
+			EncryptedPassword: "encrypted_password",
+			Email:             name + "@example.com",
+			Role:              "user",
+			OrgID:             domain.DefaultOrgID,
+		}))
+	}
+
+	f := &models.UserFilter{SortBy: "username", Limit: 2}
+	first, err := s.User().List(f)
+	assert.NoError(t, err)
+	assert.Len(t, first, 2)
+	assert.Equal(t, "al_ex", first[0].Username)
+	assert.Equal(t, "alice", first[1].Username)
+
+	f.After = &models.UserCursor{Value: first[1].Username, ID: first[1].ID}
+	second, err := s.User().List(f)
+	assert.NoError(t, err)
+	assert.Len(t, second, 2)
+	assert.Equal(t, "bob", second[0].Username)
+	assert.Equal(t, "carol", second[1].Username)
+
+	n, err := s.User().Count(f)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, n)
+
+	// "_" ищется как обычный символ, а не как шаблон LIKE
+	n, err = s.User().Count(&models.UserFilter{Search: "AL_"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	n, err = s.User().Count(&models.UserFilter{Role: "admin"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
@@ -1,6 +1,25 @@
 package store
 
 type Store interface {
+	// Tenant возвращает хранилище, в котором пользователи, уведомления, API
+	// ключи, приглашения, аудитории и шаблоны ограничены организацией orgID.
+	Tenant(orgID int) Store
+	Organization() OrganizationRepository
 	User() UserRepository
 	Notification() NotificationRepository
+	NotificationPreferences() NotificationPreferencesRepository
+	Session() SessionRepository
+	Role() RoleRepository
+	APIKey() APIKeyRepository
+	Invite() InviteRepository
+	PasswordReset() PasswordResetRepository
+	EmailVerification() EmailVerificationRepository
+	LoginAttempt() LoginAttemptRepository
+	TOTP() TOTPRepository
+	ExternalIdentity() ExternalIdentityRepository
+	OIDCState() OIDCStateRepository
+	Group() GroupRepository
+	Segment() SegmentRepository
+	DataExport() DataExportRepository
+	Template() TemplateRepository
 }
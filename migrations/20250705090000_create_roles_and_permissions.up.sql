@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS roles (
+    name VARCHAR(20) NOT NULL PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS permissions (
+    name VARCHAR(50) NOT NULL PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role VARCHAR(20) NOT NULL REFERENCES roles (name) ON DELETE CASCADE ON UPDATE CASCADE,
+    permission VARCHAR(50) NOT NULL REFERENCES permissions (name) ON DELETE CASCADE ON UPDATE CASCADE,
+    PRIMARY KEY (role, permission)
+);
+
+INSERT INTO roles (name, description) VALUES
+    ('admin', 'Full access'),
+    ('user', 'Regular user'),
+    ('publisher', 'Publishes notifications'),
+    ('auditor', 'Read-only access to users'),
+    ('support', 'Customer support')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO permissions (name, description) VALUES
+    ('notification:publish', 'Publish a notification to a single user'),
+    ('notification:broadcast', 'Broadcast a notification to all users'),
+    ('user:read', 'List and read user accounts')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'notification:publish'),
+    ('admin', 'notification:broadcast'),
+    ('admin', 'user:read'),
+    ('publisher', 'notification:publish'),
+    ('publisher', 'notification:broadcast'),
+    ('auditor', 'user:read'),
+    ('support', 'user:read')
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_fkey FOREIGN KEY (role) REFERENCES roles (name) ON UPDATE CASCADE;
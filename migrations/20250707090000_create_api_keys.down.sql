@@ -0,0 +1,3 @@
+DELETE FROM permissions WHERE name = 'apikey:manage';
+ALTER TABLE user_notifications DROP COLUMN IF EXISTS api_key_id;
+DROP TABLE api_keys;
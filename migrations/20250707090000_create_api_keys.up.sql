@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS api_keys (
+    id BIGSERIAL NOT NULL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(16) NOT NULL UNIQUE,
+    key_hash VARCHAR(64) NOT NULL,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    require_signature BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by BIGINT REFERENCES users (id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP
+);
+
+ALTER TABLE user_notifications ADD COLUMN IF NOT EXISTS api_key_id BIGINT REFERENCES api_keys (id) ON DELETE SET NULL;
+
+INSERT INTO permissions (name, description) VALUES
+    ('apikey:manage', 'Create, list and revoke API keys')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'apikey:manage')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,2 @@
+DELETE FROM permissions WHERE name = 'user:invite';
+DROP TABLE invites;
\ No newline at end of file
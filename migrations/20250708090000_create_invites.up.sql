@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS invites (
+    id VARCHAR(64) NOT NULL PRIMARY KEY,
+    role VARCHAR(20) NOT NULL REFERENCES roles (name) ON UPDATE CASCADE,
+    email VARCHAR(255),
+    created_by BIGINT REFERENCES users (id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    used_by BIGINT REFERENCES users (id) ON DELETE SET NULL
+);
+
+INSERT INTO permissions (name, description) VALUES
+    ('user:invite', 'Invite users with an elevated role')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'user:invite')
+ON CONFLICT DO NOTHING;
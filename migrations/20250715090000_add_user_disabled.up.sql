@@ -0,0 +1,9 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS disabled_at TIMESTAMP;
+
+INSERT INTO permissions (name, description) VALUES
+    ('user:manage', 'Change roles, disable, reset passwords and delete users')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'user:manage')
+ON CONFLICT DO NOTHING;
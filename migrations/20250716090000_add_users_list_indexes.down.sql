@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS users_email_lower_idx;
+DROP INDEX IF EXISTS users_username_lower_idx;
+DROP INDEX IF EXISTS users_role_idx;
+DROP INDEX IF EXISTS users_created_at_id_idx;
\ No newline at end of file
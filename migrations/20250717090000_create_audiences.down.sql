@@ -0,0 +1,4 @@
+DELETE FROM permissions WHERE name = 'audience:manage';
+DROP TABLE IF EXISTS segments;
+DROP TABLE IF EXISTS group_members;
+DROP TABLE IF EXISTS groups;
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS user_notifications_deliver_at_idx;
+ALTER TABLE user_notifications DROP COLUMN IF EXISTS deliver_at;
+DROP TABLE IF EXISTS notification_preferences;
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id BIGINT NOT NULL PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    muted_categories TEXT[] NOT NULL DEFAULT '{}',
+    -- Тихие часы в формате HH:MM по времени timezone, NULL - не заданы
+    quiet_start VARCHAR(5),
+    quiet_end VARCHAR(5),
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    realtime BOOLEAN NOT NULL DEFAULT TRUE,
+    email BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Отложенная отправка: уведомление уходит в Centrifugo не раньше deliver_at
+ALTER TABLE user_notifications ADD COLUMN IF NOT EXISTS deliver_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS user_notifications_deliver_at_idx ON user_notifications (deliver_at) WHERE deliver_at IS NOT NULL AND send_at IS NULL;
@@ -0,0 +1,19 @@
+DELETE FROM permissions WHERE name = 'organization:manage';
+UPDATE users SET role = 'admin' WHERE role = 'superadmin';
+UPDATE invites SET role = 'admin' WHERE role = 'superadmin';
+DELETE FROM roles WHERE name = 'superadmin';
+
+ALTER TABLE segments DROP CONSTRAINT IF EXISTS segments_org_id_name_key;
+ALTER TABLE groups DROP CONSTRAINT IF EXISTS groups_org_id_name_key;
+
+ALTER TABLE segments DROP COLUMN IF EXISTS org_id;
+ALTER TABLE groups DROP COLUMN IF EXISTS org_id;
+ALTER TABLE invites DROP COLUMN IF EXISTS org_id;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS org_id;
+ALTER TABLE user_notifications DROP COLUMN IF EXISTS org_id;
+ALTER TABLE users DROP COLUMN IF EXISTS org_id;
+
+ALTER TABLE segments ADD CONSTRAINT segments_name_key UNIQUE (name);
+ALTER TABLE groups ADD CONSTRAINT groups_name_key UNIQUE (name);
+
+DROP TABLE IF EXISTS organizations;
\ No newline at end of file
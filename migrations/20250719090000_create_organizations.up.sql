@@ -0,0 +1,50 @@
+CREATE TABLE IF NOT EXISTS organizations (
+    id BIGSERIAL NOT NULL PRIMARY KEY,
+    slug VARCHAR(50) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Организация по умолчанию: в неё попадают существующие данные и открытая регистрация
+INSERT INTO organizations (id, slug, name) VALUES (1, 'default', 'Default') ON CONFLICT DO NOTHING;
+SELECT setval('organizations_id_seq', (SELECT MAX(id) FROM organizations));
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS org_id BIGINT NOT NULL DEFAULT 1 REFERENCES organizations (id);
+ALTER TABLE user_notifications ADD COLUMN IF NOT EXISTS org_id BIGINT NOT NULL DEFAULT 1 REFERENCES organizations (id);
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS org_id BIGINT NOT NULL DEFAULT 1 REFERENCES organizations (id);
+ALTER TABLE invites ADD COLUMN IF NOT EXISTS org_id BIGINT NOT NULL DEFAULT 1 REFERENCES organizations (id);
+ALTER TABLE groups ADD COLUMN IF NOT EXISTS org_id BIGINT NOT NULL DEFAULT 1 REFERENCES organizations (id);
+ALTER TABLE segments ADD COLUMN IF NOT EXISTS org_id BIGINT NOT NULL DEFAULT 1 REFERENCES organizations (id);
+
+-- Новые строки получают организацию явно
+ALTER TABLE users ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE user_notifications ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE api_keys ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE invites ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE groups ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE segments ALTER COLUMN org_id DROP DEFAULT;
+
+CREATE INDEX IF NOT EXISTS users_org_id_idx ON users (org_id);
+CREATE INDEX IF NOT EXISTS user_notifications_org_id_idx ON user_notifications (org_id);
+
+-- Имена групп и сегментов уникальны внутри организации
+ALTER TABLE groups DROP CONSTRAINT IF EXISTS groups_name_key;
+ALTER TABLE groups ADD CONSTRAINT groups_org_id_name_key UNIQUE (org_id, name);
+ALTER TABLE segments DROP CONSTRAINT IF EXISTS segments_name_key;
+ALTER TABLE segments ADD CONSTRAINT segments_org_id_name_key UNIQUE (org_id, name);
+
+INSERT INTO roles (name, description) VALUES
+    ('superadmin', 'Manages organizations')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO permissions (name, description) VALUES
+    ('organization:manage', 'Create organizations and invite their administrators')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role, permission)
+    SELECT 'superadmin', permission FROM role_permissions WHERE role = 'admin'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('superadmin', 'organization:manage')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS data_exports (
+    id BIGSERIAL NOT NULL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    org_id BIGINT NOT NULL REFERENCES organizations (id),
+    requested_by BIGINT REFERENCES users (id) ON DELETE SET NULL,
+    -- pending, running, ready, failed
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    -- ZIP архив с profile.json и notifications.jsonl, удаляется по expires_at
+    archive BYTEA,
+    error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP,
+    expires_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS data_exports_user_id_idx ON data_exports (user_id);
+CREATE INDEX IF NOT EXISTS data_exports_pending_idx ON data_exports (created_at) WHERE status = 'pending';
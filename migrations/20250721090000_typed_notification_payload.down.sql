@@ -0,0 +1 @@
+ALTER TABLE user_notifications DROP CONSTRAINT IF EXISTS user_notifications_notification_check;
\ No newline at end of file
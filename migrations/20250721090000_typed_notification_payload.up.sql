@@ -0,0 +1,26 @@
+-- Старые записи хранят произвольный объект: неизвестные поля переезжают в metadata,
+-- пропущенные категория и приоритет получают значения по умолчанию
+UPDATE user_notifications SET notification = jsonb_build_object('message', notification #>> '{}')
+WHERE jsonb_typeof(notification) <> 'object';
+
+UPDATE user_notifications n SET notification = jsonb_build_object(
+        'title', COALESCE(n.notification->>'title', ''),
+        'message', COALESCE(n.notification->>'message', ''),
+        'category', COALESCE(NULLIF(lower(n.notification->>'category'), ''), 'general'),
+        'priority', COALESCE(NULLIF(lower(n.notification->>'priority'), ''), 'normal')
+    )
+    || s.optional
+    || CASE WHEN s.metadata = '{}'::jsonb THEN '{}'::jsonb ELSE jsonb_build_object('metadata', s.metadata) END
+FROM (
+    SELECT uid,
+        (SELECT COALESCE(jsonb_object_agg(key, value), '{}'::jsonb) FROM jsonb_each(notification)
+            WHERE key IN ('icon', 'action_url', 'image') AND jsonb_typeof(value) = 'string') AS optional,
+        (CASE WHEN jsonb_typeof(notification->'metadata') = 'object' THEN notification->'metadata' ELSE '{}'::jsonb END)
+        || (SELECT COALESCE(jsonb_object_agg(key, value), '{}'::jsonb) FROM jsonb_each(notification)
+            WHERE key NOT IN ('title', 'message', 'category', 'priority', 'icon', 'action_url', 'image', 'metadata')) AS metadata
+    FROM user_notifications
+) s
+WHERE n.uid = s.uid;
+
+ALTER TABLE user_notifications ADD CONSTRAINT user_notifications_notification_check
+    CHECK (jsonb_typeof(notification) = 'object' AND notification ? 'title');
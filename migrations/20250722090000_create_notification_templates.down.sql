@@ -0,0 +1,3 @@
+DELETE FROM permissions WHERE name = 'template:manage';
+ALTER TABLE notification_preferences DROP COLUMN IF EXISTS locale;
+DROP TABLE IF EXISTS notification_templates;
\ No newline at end of file
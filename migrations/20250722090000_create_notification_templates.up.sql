@@ -0,0 +1,39 @@
+-- Варианты текста по языкам и объявленные переменные хранятся в JSONB:
+-- locales - {"ru": {"title": ..., "message": ..., "action_url": ...}}
+CREATE TABLE IF NOT EXISTS notification_templates (
+    id BIGSERIAL NOT NULL PRIMARY KEY,
+    org_id BIGINT NOT NULL REFERENCES organizations (id),
+    key VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    category VARCHAR(50) NOT NULL DEFAULT 'general',
+    priority VARCHAR(10) NOT NULL DEFAULT 'normal',
+    icon VARCHAR(200) NOT NULL DEFAULT '',
+    image TEXT NOT NULL DEFAULT '',
+    default_locale VARCHAR(16) NOT NULL,
+    variables JSONB NOT NULL DEFAULT '[]',
+    locales JSONB NOT NULL DEFAULT '{}',
+    created_by BIGINT REFERENCES users (id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (org_id, key)
+);
+
+-- Язык уведомлений пользователя, пустая строка - язык шаблона по умолчанию
+ALTER TABLE notification_preferences ADD COLUMN IF NOT EXISTS locale VARCHAR(16) NOT NULL DEFAULT '';
+
+-- Уведомление о регистрации, которое раньше собиралось в коде
+INSERT INTO notification_templates (org_id, key, description, category, default_locale, variables, locales)
+SELECT id, 'user.registered', 'Sent to a user right after registration', 'account', 'en',
+    '[{"name": "username", "type": "string", "required": true}]',
+    '{"en": {"title": "Welcome, {{.username}}!", "message": "Your account has been created."}, "ru": {"title": "Добро пожаловать, {{.username}}!", "message": "Ваша учётная запись создана."}}'
+FROM organizations
+ON CONFLICT DO NOTHING;
+
+INSERT INTO permissions (name, description) VALUES
+    ('template:manage', 'Manage notification templates')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'template:manage'),
+    ('superadmin', 'template:manage')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS user_notifications_scheduled_idx;
+ALTER TABLE user_notifications DROP COLUMN IF EXISTS scheduled;
\ No newline at end of file
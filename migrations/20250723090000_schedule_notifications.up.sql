@@ -0,0 +1,5 @@
+-- Уведомление, запланированное издателем на deliver_at. До отправки получатель
+-- его не видит, а издатель может отменить или перенести отправку
+ALTER TABLE user_notifications ADD COLUMN IF NOT EXISTS scheduled BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS user_notifications_scheduled_idx ON user_notifications (org_id, deliver_at) WHERE scheduled;
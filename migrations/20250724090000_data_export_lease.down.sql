@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS data_exports_running_idx;
+ALTER TABLE data_exports DROP COLUMN IF EXISTS started_at;
\ No newline at end of file
@@ -0,0 +1,8 @@
+-- Время, когда выгрузку забрал обработчик. Выгрузка, которая слишком долго
+-- остаётся в статусе running, забирается снова: её обработчик, скорее всего, упал
+ALTER TABLE data_exports ADD COLUMN IF NOT EXISTS started_at TIMESTAMP;
+
+-- Выгрузки, зависшие до появления колонки, забираются при следующем запуске
+UPDATE data_exports SET started_at = created_at WHERE status = 'running' AND started_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS data_exports_running_idx ON data_exports (started_at) WHERE status = 'running';
@@ -0,0 +1 @@
+ALTER TABLE user_notifications DROP COLUMN IF EXISTS broadcast;
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- Уведомление создано рассылкой всей организации, группе или сегменту. Такие
+-- запланированные уведомления видит и меняет только издатель с правом notification:broadcast
+ALTER TABLE user_notifications ADD COLUMN IF NOT EXISTS broadcast BOOLEAN NOT NULL DEFAULT FALSE;
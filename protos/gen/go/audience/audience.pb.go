@@ -0,0 +1,1131 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.1
+// source: audience/audience.proto
+
+package audience
+
+import (
+	_ "github.com/DANazavr/RATest/protos/gen/go/policy"
+	user "github.com/DANazavr/RATest/protos/gen/go/user"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type EmailVerified int32
+
+const (
+	EmailVerified_EMAIL_VERIFIED_ANY EmailVerified = 0
+	EmailVerified_EMAIL_VERIFIED_YES EmailVerified = 1
+	EmailVerified_EMAIL_VERIFIED_NO  EmailVerified = 2
+)
+
+// Enum value maps for EmailVerified.
+var (
+	EmailVerified_name = map[int32]string{
+		0: "EMAIL_VERIFIED_ANY",
+		1: "EMAIL_VERIFIED_YES",
+		2: "EMAIL_VERIFIED_NO",
+	}
+	EmailVerified_value = map[string]int32{
+		"EMAIL_VERIFIED_ANY": 0,
+		"EMAIL_VERIFIED_YES": 1,
+		"EMAIL_VERIFIED_NO":  2,
+	}
+)
+
+func (x EmailVerified) Enum() *EmailVerified {
+	p := new(EmailVerified)
+	*p = x
+	return p
+}
+
+func (x EmailVerified) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EmailVerified) Descriptor() protoreflect.EnumDescriptor {
+	return file_audience_audience_proto_enumTypes[0].Descriptor()
+}
+
+func (EmailVerified) Type() protoreflect.EnumType {
+	return &file_audience_audience_proto_enumTypes[0]
+}
+
+func (x EmailVerified) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EmailVerified.Descriptor instead.
+func (EmailVerified) EnumDescriptor() ([]byte, []int) {
+	return file_audience_audience_proto_rawDescGZIP(), []int{0}
+}
+
+type Group struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	CreatedBy     int64                  `protobuf:"varint,4,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Members       int64                  `protobuf:"varint,6,opt,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Group) Reset() {
+	*x = Group{}
+	mi := &file_audience_audience_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Group) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Group) ProtoMessage() {}
+
+func (x *Group) ProtoReflect() protoreflect.Message {
+	mi := &file_audience_audience_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Group.ProtoReflect.Descriptor instead.
+func (*Group) Descriptor() ([]byte, []int) {
+	return file_audience_audience_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Group) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Group) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Group) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Group) GetCreatedBy() int64 {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return 0
+}
+
+func (x *Group) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Group) GetMembers() int64 {
+	if x != nil {
+		return x.Members
+	}
+	return 0
+}
+
+type SegmentRules struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	EmailVerified EmailVerified          `protobuf:"varint,2,opt,name=email_verified,json=emailVerified,proto3,enum=ratest.audience.EmailVerified" json:"email_verified,omitempty"`
+	CreatedFrom   string                 `protobuf:"bytes,3,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"` // RFC 3339
+	CreatedTo     string                 `protobuf:"bytes,4,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	Query         string                 `protobuf:"bytes,5,opt,name=query,proto3" json:"query,omitempty"` // префикс имени пользователя или email
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SegmentRules) Reset() {
+	*x = SegmentRules{}
+	mi := &file_audience_audience_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SegmentRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SegmentRules) ProtoMessage() {}
+
+func (x *SegmentRules) ProtoReflect() protoreflect.Message {
+	mi := &file_audience_audience_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SegmentRules.ProtoReflect.Descriptor instead.
+func (*SegmentRules) Descriptor() ([]byte, []int) {
+	return file_audience_audience_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SegmentRules) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *SegmentRules) GetEmailVerified() EmailVerified {
+	if x != nil {
+		return x.EmailVerified
+	}
+	return EmailVerified_EMAIL_VERIFIED_ANY
+}
+
+func (x *SegmentRules) GetCreatedFrom() string {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return ""
+}
+
+func (x *SegmentRules) GetCreatedTo() string {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return ""
+}
+
+func (x *SegmentRules) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+type Segment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Rules         *SegmentRules          `protobuf:"bytes,4,opt,name=rules,proto3" json:"rules,omitempty"`
+	CreatedBy     int64                  `protobuf:"varint,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Segment) Reset() {
+	*x = Segment{}
+	mi := &file_audience_audience_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Segment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Segment) ProtoMessage() {}
+
+func (x *Segment) ProtoReflect() protoreflect.Message {
+	mi := &file_audience_audience_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Segment.ProtoReflect.Descriptor instead.
+func (*Segment) Descriptor() ([]byte, []int) {
+	return file_audience_audience_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Segment) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Segment) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Segment) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Segment) GetRules() *SegmentRules {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *Segment) GetCreatedBy() int64 {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return 0
+}
+
+func (x *Segment) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type IdRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IdRequest) Reset() {
+	*x = IdRequest{}
+	mi := &file_audience_audience_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IdRequest) ProtoMessage() {}
+
+func (x *IdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_audience_audience_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IdRequest.ProtoReflect.Descriptor instead.
+func (*IdRequest) Descriptor() ([]byte, []int) {
+	return file_audience_audience_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *IdRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type MessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageResponse) Reset() {
+	*x = MessageResponse{}
+	mi := &file_audience_audience_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageResponse) ProtoMessage() {}
+
+func (x *MessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_audience_audience_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageResponse.ProtoReflect.Descriptor instead.
+func (*MessageResponse) Descriptor() ([]byte, []int) {
+	return file_audience_audience_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MessageResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type MembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*user.Profile        `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MembersResponse) Reset() {
+	*x = MembersResponse{}
+	mi := &file_audience_audience_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MembersResponse) ProtoMessage() {}
+
+func (x *MembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_audience_audience_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MembersResponse.ProtoReflect.Descriptor instead.
+func (*MembersResponse) Descriptor() ([]byte, []int) {
+	return file_audience_audience_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MembersResponse) GetUsers() []*user.Profile {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type CreateGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGroupRequest) Reset() {
+	*x = CreateGroupRequest{}
+	mi := &file_audience_audience_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGroupRequest) ProtoMessage() {}
+
+func (x *CreateGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_audience_audience_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGroupRequest.ProtoReflect.Descriptor instead.
+func (*CreateGroupRequest) Descriptor() ([]byte, []int) {
+	return file_audience_audience_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateGroupRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateGroupRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type GroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         *Group                 `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupResponse) Reset() {
+	*x = GroupResponse{}
+	mi := &file_audience_audience_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupResponse) ProtoMessage() {}
+
+func (x *GroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_audience_audience_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupResponse.ProtoReflect.Descriptor instead.
+func (*GroupResponse) Descriptor() ([]byte, []int) {
+	return file_audience_audience_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GroupResponse) GetGroup() *Group {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+type ListGroupsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGroupsRequest) Reset() {
+	*x = ListGroupsRequest{}
+	mi := &file_audience_audience_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGroupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGroupsRequest) ProtoMessage() {}
+
+func (x *ListGroupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_audience_audience_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGroupsRequest.ProtoReflect.Descriptor instead.
+func (*ListGroupsRequest) Descriptor() ([]byte, []int) {
+	return file_audience_audience_proto_rawDescGZIP(), []int{8}
+}
+
+type ListGroupsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Groups        []*Group               `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGroupsResponse) Reset() {
+	*x = ListGroupsResponse{}
+	mi := &file_audience_audience_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGroupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGroupsResponse) ProtoMessage() {}
+
+func (x *ListGroupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_audience_audience_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGroupsResponse.ProtoReflect.Descriptor instead.
+func (*ListGroupsResponse) Descriptor() ([]byte, []int) {
+	return file_audience_audience_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListGroupsResponse) GetGroups() []*Group {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+type AddGroupMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserIds       []int64                `protobuf:"varint,2,rep,packed,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddGroupMembersRequest) Reset() {
+	*x = AddGroupMembersRequest{}
+	mi := &file_audience_audience_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddGroupMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddGroupMembersRequest) ProtoMessage() {}
+
+func (x *AddGroupMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_audience_audience_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddGroupMembersRequest.ProtoReflect.Descriptor instead.
+func (*AddGroupMembersRequest) Descriptor() ([]byte, []int) {
+	return file_audience_audience_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AddGroupMembersRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AddGroupMembersRequest) GetUserIds() []int64 {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+type AddGroupMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Added         int64                  `protobuf:"varint,1,opt,name=added,proto3" json:"added,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddGroupMembersResponse) Reset() {
+	*x = AddGroupMembersResponse{}
+	mi := &file_audience_audience_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddGroupMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddGroupMembersResponse) ProtoMessage() {}
+
+func (x *AddGroupMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_audience_audience_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddGroupMembersResponse.ProtoReflect.Descriptor instead.
+func (*AddGroupMembersResponse) Descriptor() ([]byte, []int) {
+	return file_audience_audience_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AddGroupMembersResponse) GetAdded() int64 {
+	if x != nil {
+		return x.Added
+	}
+	return 0
+}
+
+type RemoveGroupMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveGroupMemberRequest) Reset() {
+	*x = RemoveGroupMemberRequest{}
+	mi := &file_audience_audience_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveGroupMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveGroupMemberRequest) ProtoMessage() {}
+
+func (x *RemoveGroupMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_audience_audience_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveGroupMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveGroupMemberRequest) Descriptor() ([]byte, []int) {
+	return file_audience_audience_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RemoveGroupMemberRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RemoveGroupMemberRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type CreateSegmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Rules         *SegmentRules          `protobuf:"bytes,3,opt,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSegmentRequest) Reset() {
+	*x = CreateSegmentRequest{}
+	mi := &file_audience_audience_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSegmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSegmentRequest) ProtoMessage() {}
+
+func (x *CreateSegmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_audience_audience_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSegmentRequest.ProtoReflect.Descriptor instead.
+func (*CreateSegmentRequest) Descriptor() ([]byte, []int) {
+	return file_audience_audience_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateSegmentRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateSegmentRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateSegmentRequest) GetRules() *SegmentRules {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type SegmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Segment       *Segment               `protobuf:"bytes,1,opt,name=segment,proto3" json:"segment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SegmentResponse) Reset() {
+	*x = SegmentResponse{}
+	mi := &file_audience_audience_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SegmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SegmentResponse) ProtoMessage() {}
+
+func (x *SegmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_audience_audience_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SegmentResponse.ProtoReflect.Descriptor instead.
+func (*SegmentResponse) Descriptor() ([]byte, []int) {
+	return file_audience_audience_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SegmentResponse) GetSegment() *Segment {
+	if x != nil {
+		return x.Segment
+	}
+	return nil
+}
+
+type ListSegmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSegmentsRequest) Reset() {
+	*x = ListSegmentsRequest{}
+	mi := &file_audience_audience_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSegmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSegmentsRequest) ProtoMessage() {}
+
+func (x *ListSegmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_audience_audience_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSegmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListSegmentsRequest) Descriptor() ([]byte, []int) {
+	return file_audience_audience_proto_rawDescGZIP(), []int{15}
+}
+
+type ListSegmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Segments      []*Segment             `protobuf:"bytes,1,rep,name=segments,proto3" json:"segments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSegmentsResponse) Reset() {
+	*x = ListSegmentsResponse{}
+	mi := &file_audience_audience_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSegmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSegmentsResponse) ProtoMessage() {}
+
+func (x *ListSegmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_audience_audience_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSegmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListSegmentsResponse) Descriptor() ([]byte, []int) {
+	return file_audience_audience_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListSegmentsResponse) GetSegments() []*Segment {
+	if x != nil {
+		return x.Segments
+	}
+	return nil
+}
+
+var File_audience_audience_proto protoreflect.FileDescriptor
+
+const file_audience_audience_proto_rawDesc = "" +
+	"\n" +
+	"\x17audience/audience.proto\x12\x0fratest.audience\x1a\x13policy/policy.proto\x1a\x0fuser/user.proto\"\xa5\x01\n" +
+	"\x05Group\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x04 \x01(\x03R\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\x12\x18\n" +
+	"\amembers\x18\x06 \x01(\x03R\amembers\"\xc1\x01\n" +
+	"\fSegmentRules\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\x12E\n" +
+	"\x0eemail_verified\x18\x02 \x01(\x0e2\x1e.ratest.audience.EmailVerifiedR\remailVerified\x12!\n" +
+	"\fcreated_from\x18\x03 \x01(\tR\vcreatedFrom\x12\x1d\n" +
+	"\n" +
+	"created_to\x18\x04 \x01(\tR\tcreatedTo\x12\x14\n" +
+	"\x05query\x18\x05 \x01(\tR\x05query\"\xc2\x01\n" +
+	"\aSegment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x123\n" +
+	"\x05rules\x18\x04 \x01(\v2\x1d.ratest.audience.SegmentRulesR\x05rules\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x05 \x01(\x03R\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\"\x1b\n" +
+	"\tIdRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"+\n" +
+	"\x0fMessageResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"=\n" +
+	"\x0fMembersResponse\x12*\n" +
+	"\x05users\x18\x01 \x03(\v2\x14.ratest.user.ProfileR\x05users\"J\n" +
+	"\x12CreateGroupRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\"=\n" +
+	"\rGroupResponse\x12,\n" +
+	"\x05group\x18\x01 \x01(\v2\x16.ratest.audience.GroupR\x05group\"\x13\n" +
+	"\x11ListGroupsRequest\"D\n" +
+	"\x12ListGroupsResponse\x12.\n" +
+	"\x06groups\x18\x01 \x03(\v2\x16.ratest.audience.GroupR\x06groups\"C\n" +
+	"\x16AddGroupMembersRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\buser_ids\x18\x02 \x03(\x03R\auserIds\"/\n" +
+	"\x17AddGroupMembersResponse\x12\x14\n" +
+	"\x05added\x18\x01 \x01(\x03R\x05added\"C\n" +
+	"\x18RemoveGroupMemberRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\"\x81\x01\n" +
+	"\x14CreateSegmentRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x123\n" +
+	"\x05rules\x18\x03 \x01(\v2\x1d.ratest.audience.SegmentRulesR\x05rules\"E\n" +
+	"\x0fSegmentResponse\x122\n" +
+	"\asegment\x18\x01 \x01(\v2\x18.ratest.audience.SegmentR\asegment\"\x15\n" +
+	"\x13ListSegmentsRequest\"L\n" +
+	"\x14ListSegmentsResponse\x124\n" +
+	"\bsegments\x18\x01 \x03(\v2\x18.ratest.audience.SegmentR\bsegments*V\n" +
+	"\rEmailVerified\x12\x16\n" +
+	"\x12EMAIL_VERIFIED_ANY\x10\x00\x12\x16\n" +
+	"\x12EMAIL_VERIFIED_YES\x10\x01\x12\x15\n" +
+	"\x11EMAIL_VERIFIED_NO\x10\x022\xdc\b\n" +
+	"\bAudience\x12i\n" +
+	"\vCreateGroup\x12#.ratest.audience.CreateGroupRequest\x1a\x1e.ratest.audience.GroupResponse\"\x15\x8a\xb5\x18\x11\x1a\x0faudience:manage\x12l\n" +
+	"\n" +
+	"ListGroups\x12\".ratest.audience.ListGroupsRequest\x1a#.ratest.audience.ListGroupsResponse\"\x15\x8a\xb5\x18\x11\x1a\x0faudience:manage\x12b\n" +
+	"\vDeleteGroup\x12\x1a.ratest.audience.IdRequest\x1a .ratest.audience.MessageResponse\"\x15\x8a\xb5\x18\x11\x1a\x0faudience:manage\x12g\n" +
+	"\x10ListGroupMembers\x12\x1a.ratest.audience.IdRequest\x1a .ratest.audience.MembersResponse\"\x15\x8a\xb5\x18\x11\x1a\x0faudience:manage\x12{\n" +
+	"\x0fAddGroupMembers\x12'.ratest.audience.AddGroupMembersRequest\x1a(.ratest.audience.AddGroupMembersResponse\"\x15\x8a\xb5\x18\x11\x1a\x0faudience:manage\x12w\n" +
+	"\x11RemoveGroupMember\x12).ratest.audience.RemoveGroupMemberRequest\x1a .ratest.audience.MessageResponse\"\x15\x8a\xb5\x18\x11\x1a\x0faudience:manage\x12o\n" +
+	"\rCreateSegment\x12%.ratest.audience.CreateSegmentRequest\x1a .ratest.audience.SegmentResponse\"\x15\x8a\xb5\x18\x11\x1a\x0faudience:manage\x12r\n" +
+	"\fListSegments\x12$.ratest.audience.ListSegmentsRequest\x1a%.ratest.audience.ListSegmentsResponse\"\x15\x8a\xb5\x18\x11\x1a\x0faudience:manage\x12d\n" +
+	"\rDeleteSegment\x12\x1a.ratest.audience.IdRequest\x1a .ratest.audience.MessageResponse\"\x15\x8a\xb5\x18\x11\x1a\x0faudience:manage\x12i\n" +
+	"\x12ListSegmentMembers\x12\x1a.ratest.audience.IdRequest\x1a .ratest.audience.MembersResponse\"\x15\x8a\xb5\x18\x11\x1a\x0faudience:manageB<Z:github.com/DANazavr/RATest/protos/gen/go/audience;audienceb\x06proto3"
+
+var (
+	file_audience_audience_proto_rawDescOnce sync.Once
+	file_audience_audience_proto_rawDescData []byte
+)
+
+func file_audience_audience_proto_rawDescGZIP() []byte {
+	file_audience_audience_proto_rawDescOnce.Do(func() {
+		file_audience_audience_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_audience_audience_proto_rawDesc), len(file_audience_audience_proto_rawDesc)))
+	})
+	return file_audience_audience_proto_rawDescData
+}
+
+var file_audience_audience_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_audience_audience_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_audience_audience_proto_goTypes = []any{
+	(EmailVerified)(0),               // 0: ratest.audience.EmailVerified
+	(*Group)(nil),                    // 1: ratest.audience.Group
+	(*SegmentRules)(nil),             // 2: ratest.audience.SegmentRules
+	(*Segment)(nil),                  // 3: ratest.audience.Segment
+	(*IdRequest)(nil),                // 4: ratest.audience.IdRequest
+	(*MessageResponse)(nil),          // 5: ratest.audience.MessageResponse
+	(*MembersResponse)(nil),          // 6: ratest.audience.MembersResponse
+	(*CreateGroupRequest)(nil),       // 7: ratest.audience.CreateGroupRequest
+	(*GroupResponse)(nil),            // 8: ratest.audience.GroupResponse
+	(*ListGroupsRequest)(nil),        // 9: ratest.audience.ListGroupsRequest
+	(*ListGroupsResponse)(nil),       // 10: ratest.audience.ListGroupsResponse
+	(*AddGroupMembersRequest)(nil),   // 11: ratest.audience.AddGroupMembersRequest
+	(*AddGroupMembersResponse)(nil),  // 12: ratest.audience.AddGroupMembersResponse
+	(*RemoveGroupMemberRequest)(nil), // 13: ratest.audience.RemoveGroupMemberRequest
+	(*CreateSegmentRequest)(nil),     // 14: ratest.audience.CreateSegmentRequest
+	(*SegmentResponse)(nil),          // 15: ratest.audience.SegmentResponse
+	(*ListSegmentsRequest)(nil),      // 16: ratest.audience.ListSegmentsRequest
+	(*ListSegmentsResponse)(nil),     // 17: ratest.audience.ListSegmentsResponse
+	(*user.Profile)(nil),             // 18: ratest.user.Profile
+}
+var file_audience_audience_proto_depIdxs = []int32{
+	0,  // 0: ratest.audience.SegmentRules.email_verified:type_name -> ratest.audience.EmailVerified
+	2,  // 1: ratest.audience.Segment.rules:type_name -> ratest.audience.SegmentRules
+	18, // 2: ratest.audience.MembersResponse.users:type_name -> ratest.user.Profile
+	1,  // 3: ratest.audience.GroupResponse.group:type_name -> ratest.audience.Group
+	1,  // 4: ratest.audience.ListGroupsResponse.groups:type_name -> ratest.audience.Group
+	2,  // 5: ratest.audience.CreateSegmentRequest.rules:type_name -> ratest.audience.SegmentRules
+	3,  // 6: ratest.audience.SegmentResponse.segment:type_name -> ratest.audience.Segment
+	3,  // 7: ratest.audience.ListSegmentsResponse.segments:type_name -> ratest.audience.Segment
+	7,  // 8: ratest.audience.Audience.CreateGroup:input_type -> ratest.audience.CreateGroupRequest
+	9,  // 9: ratest.audience.Audience.ListGroups:input_type -> ratest.audience.ListGroupsRequest
+	4,  // 10: ratest.audience.Audience.DeleteGroup:input_type -> ratest.audience.IdRequest
+	4,  // 11: ratest.audience.Audience.ListGroupMembers:input_type -> ratest.audience.IdRequest
+	11, // 12: ratest.audience.Audience.AddGroupMembers:input_type -> ratest.audience.AddGroupMembersRequest
+	13, // 13: ratest.audience.Audience.RemoveGroupMember:input_type -> ratest.audience.RemoveGroupMemberRequest
+	14, // 14: ratest.audience.Audience.CreateSegment:input_type -> ratest.audience.CreateSegmentRequest
+	16, // 15: ratest.audience.Audience.ListSegments:input_type -> ratest.audience.ListSegmentsRequest
+	4,  // 16: ratest.audience.Audience.DeleteSegment:input_type -> ratest.audience.IdRequest
+	4,  // 17: ratest.audience.Audience.ListSegmentMembers:input_type -> ratest.audience.IdRequest
+	8,  // 18: ratest.audience.Audience.CreateGroup:output_type -> ratest.audience.GroupResponse
+	10, // 19: ratest.audience.Audience.ListGroups:output_type -> ratest.audience.ListGroupsResponse
+	5,  // 20: ratest.audience.Audience.DeleteGroup:output_type -> ratest.audience.MessageResponse
+	6,  // 21: ratest.audience.Audience.ListGroupMembers:output_type -> ratest.audience.MembersResponse
+	12, // 22: ratest.audience.Audience.AddGroupMembers:output_type -> ratest.audience.AddGroupMembersResponse
+	5,  // 23: ratest.audience.Audience.RemoveGroupMember:output_type -> ratest.audience.MessageResponse
+	15, // 24: ratest.audience.Audience.CreateSegment:output_type -> ratest.audience.SegmentResponse
+	17, // 25: ratest.audience.Audience.ListSegments:output_type -> ratest.audience.ListSegmentsResponse
+	5,  // 26: ratest.audience.Audience.DeleteSegment:output_type -> ratest.audience.MessageResponse
+	6,  // 27: ratest.audience.Audience.ListSegmentMembers:output_type -> ratest.audience.MembersResponse
+	18, // [18:28] is the sub-list for method output_type
+	8,  // [8:18] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_audience_audience_proto_init() }
+func file_audience_audience_proto_init() {
+	if File_audience_audience_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_audience_audience_proto_rawDesc), len(file_audience_audience_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_audience_audience_proto_goTypes,
+		DependencyIndexes: file_audience_audience_proto_depIdxs,
+		EnumInfos:         file_audience_audience_proto_enumTypes,
+		MessageInfos:      file_audience_audience_proto_msgTypes,
+	}.Build()
+	File_audience_audience_proto = out.File
+	file_audience_audience_proto_goTypes = nil
+	file_audience_audience_proto_depIdxs = nil
+}
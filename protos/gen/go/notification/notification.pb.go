@@ -7,8 +7,10 @@
 package notification
 
 import (
+	_ "github.com/DANazavr/RATest/protos/gen/go/policy"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,10 +23,68 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type Priority int32
+
+const (
+	Priority_PRIORITY_UNSPECIFIED Priority = 0 // normal
+	Priority_PRIORITY_LOW         Priority = 1
+	Priority_PRIORITY_NORMAL      Priority = 2
+	Priority_PRIORITY_HIGH        Priority = 3
+)
+
+// Enum value maps for Priority.
+var (
+	Priority_name = map[int32]string{
+		0: "PRIORITY_UNSPECIFIED",
+		1: "PRIORITY_LOW",
+		2: "PRIORITY_NORMAL",
+		3: "PRIORITY_HIGH",
+	}
+	Priority_value = map[string]int32{
+		"PRIORITY_UNSPECIFIED": 0,
+		"PRIORITY_LOW":         1,
+		"PRIORITY_NORMAL":      2,
+		"PRIORITY_HIGH":        3,
+	}
+)
+
+func (x Priority) Enum() *Priority {
+	p := new(Priority)
+	*p = x
+	return p
+}
+
+func (x Priority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Priority) Descriptor() protoreflect.EnumDescriptor {
+	return file_notification_notification_proto_enumTypes[0].Descriptor()
+}
+
+func (Priority) Type() protoreflect.EnumType {
+	return &file_notification_notification_proto_enumTypes[0]
+}
+
+func (x Priority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Priority.Descriptor instead.
+func (Priority) EnumDescriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{0}
+}
+
 type Data struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Category      string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"` // по умолчанию general
+	Priority      Priority               `protobuf:"varint,4,opt,name=priority,proto3,enum=notification.Priority" json:"priority,omitempty"`
+	Icon          string                 `protobuf:"bytes,5,opt,name=icon,proto3" json:"icon,omitempty"`
+	ActionUrl     string                 `protobuf:"bytes,6,opt,name=action_url,json=actionUrl,proto3" json:"action_url,omitempty"` // открывается по нажатию на уведомление
+	Image         string                 `protobuf:"bytes,7,opt,name=image,proto3" json:"image,omitempty"`
+	Metadata      *structpb.Struct       `protobuf:"bytes,8,opt,name=metadata,proto3" json:"metadata,omitempty"` // произвольные данные издателя, до 4 КБ
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -73,10 +133,60 @@ func (x *Data) GetMessage() string {
 	return ""
 }
 
+func (x *Data) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Data) GetPriority() Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Priority_PRIORITY_UNSPECIFIED
+}
+
+func (x *Data) GetIcon() string {
+	if x != nil {
+		return x.Icon
+	}
+	return ""
+}
+
+func (x *Data) GetActionUrl() string {
+	if x != nil {
+		return x.ActionUrl
+	}
+	return ""
+}
+
+func (x *Data) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *Data) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
 type PublishRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Channel       string                 `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
-	Data          *Data                  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Channel string                 `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	Data    *Data                  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// Вместо channel можно указать группу или сегмент получателей
+	GroupId   int64 `protobuf:"varint,3,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	SegmentId int64 `protobuf:"varint,4,opt,name=segment_id,json=segmentId,proto3" json:"segment_id,omitempty"`
+	// Вместо data можно указать шаблон и значения его переменных
+	TemplateKey   string           `protobuf:"bytes,5,opt,name=template_key,json=templateKey,proto3" json:"template_key,omitempty"`
+	Variables     *structpb.Struct `protobuf:"bytes,6,opt,name=variables,proto3" json:"variables,omitempty"`
+	Locale        string           `protobuf:"bytes,7,opt,name=locale,proto3" json:"locale,omitempty"`                        // по умолчанию язык из настроек получателя
+	DeliverAt     string           `protobuf:"bytes,8,opt,name=deliver_at,json=deliverAt,proto3" json:"deliver_at,omitempty"` // RFC 3339, время отправки по расписанию
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -125,10 +235,59 @@ func (x *PublishRequest) GetData() *Data {
 	return nil
 }
 
+func (x *PublishRequest) GetGroupId() int64 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
+func (x *PublishRequest) GetSegmentId() int64 {
+	if x != nil {
+		return x.SegmentId
+	}
+	return 0
+}
+
+func (x *PublishRequest) GetTemplateKey() string {
+	if x != nil {
+		return x.TemplateKey
+	}
+	return ""
+}
+
+func (x *PublishRequest) GetVariables() *structpb.Struct {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *PublishRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *PublishRequest) GetDeliverAt() string {
+	if x != nil {
+		return x.DeliverAt
+	}
+	return ""
+}
+
 type PublishResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Offset        uint64                 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
-	Epoch         string                 `protobuf:"bytes,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Offset uint64                 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Epoch  string                 `protobuf:"bytes,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	// Заполняются при публикации группе или сегменту
+	Recipients int64 `protobuf:"varint,3,opt,name=recipients,proto3" json:"recipients,omitempty"`
+	Pushed     int64 `protobuf:"varint,4,opt,name=pushed,proto3" json:"pushed,omitempty"`
+	// Заполняется при публикации одному пользователю
+	Uid int64 `protobuf:"varint,5,opt,name=uid,proto3" json:"uid,omitempty"`
+	// Сколько участников группы или сегмента не получили уведомление из-за ошибки
+	Failed        int64 `protobuf:"varint,6,opt,name=failed,proto3" json:"failed,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -177,9 +336,41 @@ func (x *PublishResponse) GetEpoch() string {
 	return ""
 }
 
+func (x *PublishResponse) GetRecipients() int64 {
+	if x != nil {
+		return x.Recipients
+	}
+	return 0
+}
+
+func (x *PublishResponse) GetPushed() int64 {
+	if x != nil {
+		return x.Pushed
+	}
+	return 0
+}
+
+func (x *PublishResponse) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *PublishResponse) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
 type BroadcastRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Data          *Data                  `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	TemplateKey   string                 `protobuf:"bytes,2,opt,name=template_key,json=templateKey,proto3" json:"template_key,omitempty"`
+	Variables     *structpb.Struct       `protobuf:"bytes,3,opt,name=variables,proto3" json:"variables,omitempty"`
+	Locale        string                 `protobuf:"bytes,4,opt,name=locale,proto3" json:"locale,omitempty"`
+	DeliverAt     string                 `protobuf:"bytes,5,opt,name=deliver_at,json=deliverAt,proto3" json:"deliver_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -221,6 +412,34 @@ func (x *BroadcastRequest) GetData() *Data {
 	return nil
 }
 
+func (x *BroadcastRequest) GetTemplateKey() string {
+	if x != nil {
+		return x.TemplateKey
+	}
+	return ""
+}
+
+func (x *BroadcastRequest) GetVariables() *structpb.Struct {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *BroadcastRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *BroadcastRequest) GetDeliverAt() string {
+	if x != nil {
+		return x.DeliverAt
+	}
+	return ""
+}
+
 type BroadcastResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
@@ -405,6 +624,7 @@ type Notification struct {
 	SendAt        string                 `protobuf:"bytes,4,opt,name=send_at,json=sendAt,proto3" json:"send_at,omitempty"`
 	ReadAt        string                 `protobuf:"bytes,5,opt,name=read_at,json=readAt,proto3" json:"read_at,omitempty"`
 	Data          *Data                  `protobuf:"bytes,6,opt,name=data,proto3" json:"data,omitempty"`
+	DeliverAt     string                 `protobuf:"bytes,7,opt,name=deliver_at,json=deliverAt,proto3" json:"deliver_at,omitempty"` // у запланированных уведомлений
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -481,6 +701,13 @@ func (x *Notification) GetData() *Data {
 	return nil
 }
 
+func (x *Notification) GetDeliverAt() string {
+	if x != nil {
+		return x.DeliverAt
+	}
+	return ""
+}
+
 type GetNotificationsByFilterResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Notifications []*Notification        `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
@@ -525,46 +752,781 @@ func (x *GetNotificationsByFilterResponse) GetNotifications() []*Notification {
 	return nil
 }
 
-var File_notification_notification_proto protoreflect.FileDescriptor
+type CentrifugoConnectionTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_notification_notification_proto_rawDesc = "" +
-	"\n" +
-	"\x1fnotification/notification.proto\x12\fnotification\"6\n" +
-	"\x04data\x12\x14\n" +
-	"\x05title\x18\x01 \x01(\tR\x05title\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"R\n" +
-	"\x0ePublishRequest\x12\x18\n" +
-	"\achannel\x18\x01 \x01(\tR\achannel\x12&\n" +
-	"\x04data\x18\x02 \x01(\v2\x12.notification.dataR\x04data\"?\n" +
-	"\x0fPublishResponse\x12\x16\n" +
-	"\x06offset\x18\x01 \x01(\x04R\x06offset\x12\x14\n" +
-	"\x05epoch\x18\x02 \x01(\tR\x05epoch\":\n" +
-	"\x10BroadcastRequest\x12&\n" +
-	"\x04data\x18\x01 \x01(\v2\x12.notification.dataR\x04data\"-\n" +
-	"\x11BroadcastResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\"<\n" +
-	"\x11MarkAsReadRequest\x12'\n" +
-	"\x0fnotification_id\x18\x01 \x01(\tR\x0enotificationId\".\n" +
-	"\x12MarkAsReadResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\"9\n" +
-	"\x1fGetNotificationsByFilterRequest\x12\x16\n" +
-	"\x06filter\x18\x01 \x01(\tR\x06filter\"\xb1\x01\n" +
-	"\fnotification\x12\x10\n" +
-	"\x03uid\x18\x01 \x01(\x03R\x03uid\x12\x16\n" +
-	"\x06userid\x18\x02 \x01(\x03R\x06userid\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\x03 \x01(\tR\tcreatedAt\x12\x17\n" +
-	"\asend_at\x18\x04 \x01(\tR\x06sendAt\x12\x17\n" +
-	"\aread_at\x18\x05 \x01(\tR\x06readAt\x12&\n" +
-	"\x04data\x18\x06 \x01(\v2\x12.notification.dataR\x04data\"d\n" +
-	" GetNotificationsByFilterResponse\x12@\n" +
-	"\rnotifications\x18\x01 \x03(\v2\x1a.notification.notificationR\rnotifications2\xf0\x02\n" +
-	"\fNotification\x12F\n" +
-	"\aPublish\x12\x1c.notification.PublishRequest\x1a\x1d.notification.PublishResponse\x12L\n" +
-	"\tBroadcast\x12\x1e.notification.BroadcastRequest\x1a\x1f.notification.BroadcastResponse\x12O\n" +
+func (x *CentrifugoConnectionTokenRequest) Reset() {
+	*x = CentrifugoConnectionTokenRequest{}
+	mi := &file_notification_notification_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CentrifugoConnectionTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CentrifugoConnectionTokenRequest) ProtoMessage() {}
+
+func (x *CentrifugoConnectionTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CentrifugoConnectionTokenRequest.ProtoReflect.Descriptor instead.
+func (*CentrifugoConnectionTokenRequest) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{10}
+}
+
+type CentrifugoSubscriptionTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Channel       string                 `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CentrifugoSubscriptionTokenRequest) Reset() {
+	*x = CentrifugoSubscriptionTokenRequest{}
+	mi := &file_notification_notification_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CentrifugoSubscriptionTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CentrifugoSubscriptionTokenRequest) ProtoMessage() {}
+
+func (x *CentrifugoSubscriptionTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CentrifugoSubscriptionTokenRequest.ProtoReflect.Descriptor instead.
+func (*CentrifugoSubscriptionTokenRequest) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CentrifugoSubscriptionTokenRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+type CentrifugoTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CentrifugoTokenResponse) Reset() {
+	*x = CentrifugoTokenResponse{}
+	mi := &file_notification_notification_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CentrifugoTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CentrifugoTokenResponse) ProtoMessage() {}
+
+func (x *CentrifugoTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CentrifugoTokenResponse.ProtoReflect.Descriptor instead.
+func (*CentrifugoTokenResponse) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CentrifugoTokenResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type QuietHours struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         string                 `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"` // HH:MM
+	End           string                 `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuietHours) Reset() {
+	*x = QuietHours{}
+	mi := &file_notification_notification_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuietHours) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuietHours) ProtoMessage() {}
+
+func (x *QuietHours) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuietHours.ProtoReflect.Descriptor instead.
+func (*QuietHours) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *QuietHours) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *QuietHours) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+type Preferences struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	MutedCategories []string               `protobuf:"bytes,1,rep,name=muted_categories,json=mutedCategories,proto3" json:"muted_categories,omitempty"`
+	QuietHours      *QuietHours            `protobuf:"bytes,2,opt,name=quiet_hours,json=quietHours,proto3" json:"quiet_hours,omitempty"`
+	Timezone        string                 `protobuf:"bytes,3,opt,name=timezone,proto3" json:"timezone,omitempty"` // IANA, например Europe/Moscow
+	Realtime        bool                   `protobuf:"varint,4,opt,name=realtime,proto3" json:"realtime,omitempty"`
+	Email           bool                   `protobuf:"varint,5,opt,name=email,proto3" json:"email,omitempty"`
+	UpdatedAt       string                 `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Locale          string                 `protobuf:"bytes,7,opt,name=locale,proto3" json:"locale,omitempty"` // язык уведомлений из шаблонов, например ru
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Preferences) Reset() {
+	*x = Preferences{}
+	mi := &file_notification_notification_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Preferences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Preferences) ProtoMessage() {}
+
+func (x *Preferences) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Preferences.ProtoReflect.Descriptor instead.
+func (*Preferences) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Preferences) GetMutedCategories() []string {
+	if x != nil {
+		return x.MutedCategories
+	}
+	return nil
+}
+
+func (x *Preferences) GetQuietHours() *QuietHours {
+	if x != nil {
+		return x.QuietHours
+	}
+	return nil
+}
+
+func (x *Preferences) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *Preferences) GetRealtime() bool {
+	if x != nil {
+		return x.Realtime
+	}
+	return false
+}
+
+func (x *Preferences) GetEmail() bool {
+	if x != nil {
+		return x.Email
+	}
+	return false
+}
+
+func (x *Preferences) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Preferences) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type GetPreferencesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPreferencesRequest) Reset() {
+	*x = GetPreferencesRequest{}
+	mi := &file_notification_notification_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPreferencesRequest) ProtoMessage() {}
+
+func (x *GetPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*GetPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{15}
+}
+
+// UpdatePreferencesRequest заменяет все настройки пользователя
+type UpdatePreferencesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Preferences   *Preferences           `protobuf:"bytes,1,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePreferencesRequest) Reset() {
+	*x = UpdatePreferencesRequest{}
+	mi := &file_notification_notification_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePreferencesRequest) ProtoMessage() {}
+
+func (x *UpdatePreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePreferencesRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdatePreferencesRequest) GetPreferences() *Preferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+type PreferencesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Preferences   *Preferences           `protobuf:"bytes,1,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreferencesResponse) Reset() {
+	*x = PreferencesResponse{}
+	mi := &file_notification_notification_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreferencesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreferencesResponse) ProtoMessage() {}
+
+func (x *PreferencesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreferencesResponse.ProtoReflect.Descriptor instead.
+func (*PreferencesResponse) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PreferencesResponse) GetPreferences() *Preferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+type ListScheduledRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduledRequest) Reset() {
+	*x = ListScheduledRequest{}
+	mi := &file_notification_notification_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledRequest) ProtoMessage() {}
+
+func (x *ListScheduledRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledRequest.ProtoReflect.Descriptor instead.
+func (*ListScheduledRequest) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{18}
+}
+
+type ListScheduledResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notifications []*Notification        `protobuf:"bytes,1,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduledResponse) Reset() {
+	*x = ListScheduledResponse{}
+	mi := &file_notification_notification_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledResponse) ProtoMessage() {}
+
+func (x *ListScheduledResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledResponse.ProtoReflect.Descriptor instead.
+func (*ListScheduledResponse) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListScheduledResponse) GetNotifications() []*Notification {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+type RescheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	DeliverAt     string                 `protobuf:"bytes,2,opt,name=deliver_at,json=deliverAt,proto3" json:"deliver_at,omitempty"` // RFC 3339
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RescheduleRequest) Reset() {
+	*x = RescheduleRequest{}
+	mi := &file_notification_notification_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RescheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RescheduleRequest) ProtoMessage() {}
+
+func (x *RescheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RescheduleRequest.ProtoReflect.Descriptor instead.
+func (*RescheduleRequest) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RescheduleRequest) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *RescheduleRequest) GetDeliverAt() string {
+	if x != nil {
+		return x.DeliverAt
+	}
+	return ""
+}
+
+type RescheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notification  *Notification          `protobuf:"bytes,1,opt,name=notification,proto3" json:"notification,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RescheduleResponse) Reset() {
+	*x = RescheduleResponse{}
+	mi := &file_notification_notification_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RescheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RescheduleResponse) ProtoMessage() {}
+
+func (x *RescheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RescheduleResponse.ProtoReflect.Descriptor instead.
+func (*RescheduleResponse) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RescheduleResponse) GetNotification() *Notification {
+	if x != nil {
+		return x.Notification
+	}
+	return nil
+}
+
+type CancelScheduledRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelScheduledRequest) Reset() {
+	*x = CancelScheduledRequest{}
+	mi := &file_notification_notification_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduledRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledRequest) ProtoMessage() {}
+
+func (x *CancelScheduledRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledRequest.ProtoReflect.Descriptor instead.
+func (*CancelScheduledRequest) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CancelScheduledRequest) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+type CancelScheduledResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelScheduledResponse) Reset() {
+	*x = CancelScheduledResponse{}
+	mi := &file_notification_notification_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduledResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledResponse) ProtoMessage() {}
+
+func (x *CancelScheduledResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notification_notification_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledResponse.ProtoReflect.Descriptor instead.
+func (*CancelScheduledResponse) Descriptor() ([]byte, []int) {
+	return file_notification_notification_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CancelScheduledResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+var File_notification_notification_proto protoreflect.FileDescriptor
+
+const file_notification_notification_proto_rawDesc = "" +
+	"\n" +
+	"\x1fnotification/notification.proto\x12\fnotification\x1a\x1cgoogle/protobuf/struct.proto\x1a\x13policy/policy.proto\"\x84\x02\n" +
+	"\x04data\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\x122\n" +
+	"\bpriority\x18\x04 \x01(\x0e2\x16.notification.PriorityR\bpriority\x12\x12\n" +
+	"\x04icon\x18\x05 \x01(\tR\x04icon\x12\x1d\n" +
+	"\n" +
+	"action_url\x18\x06 \x01(\tR\tactionUrl\x12\x14\n" +
+	"\x05image\x18\a \x01(\tR\x05image\x123\n" +
+	"\bmetadata\x18\b \x01(\v2\x17.google.protobuf.StructR\bmetadata\"\x9d\x02\n" +
+	"\x0ePublishRequest\x12\x18\n" +
+	"\achannel\x18\x01 \x01(\tR\achannel\x12&\n" +
+	"\x04data\x18\x02 \x01(\v2\x12.notification.dataR\x04data\x12\x19\n" +
+	"\bgroup_id\x18\x03 \x01(\x03R\agroupId\x12\x1d\n" +
+	"\n" +
+	"segment_id\x18\x04 \x01(\x03R\tsegmentId\x12!\n" +
+	"\ftemplate_key\x18\x05 \x01(\tR\vtemplateKey\x125\n" +
+	"\tvariables\x18\x06 \x01(\v2\x17.google.protobuf.StructR\tvariables\x12\x16\n" +
+	"\x06locale\x18\a \x01(\tR\x06locale\x12\x1d\n" +
+	"\n" +
+	"deliver_at\x18\b \x01(\tR\tdeliverAt\"\xa1\x01\n" +
+	"\x0fPublishResponse\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x04R\x06offset\x12\x14\n" +
+	"\x05epoch\x18\x02 \x01(\tR\x05epoch\x12\x1e\n" +
+	"\n" +
+	"recipients\x18\x03 \x01(\x03R\n" +
+	"recipients\x12\x16\n" +
+	"\x06pushed\x18\x04 \x01(\x03R\x06pushed\x12\x10\n" +
+	"\x03uid\x18\x05 \x01(\x03R\x03uid\x12\x16\n" +
+	"\x06failed\x18\x06 \x01(\x03R\x06failed\"\xcb\x01\n" +
+	"\x10BroadcastRequest\x12&\n" +
+	"\x04data\x18\x01 \x01(\v2\x12.notification.dataR\x04data\x12!\n" +
+	"\ftemplate_key\x18\x02 \x01(\tR\vtemplateKey\x125\n" +
+	"\tvariables\x18\x03 \x01(\v2\x17.google.protobuf.StructR\tvariables\x12\x16\n" +
+	"\x06locale\x18\x04 \x01(\tR\x06locale\x12\x1d\n" +
+	"\n" +
+	"deliver_at\x18\x05 \x01(\tR\tdeliverAt\"-\n" +
+	"\x11BroadcastResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"<\n" +
+	"\x11MarkAsReadRequest\x12'\n" +
+	"\x0fnotification_id\x18\x01 \x01(\tR\x0enotificationId\".\n" +
+	"\x12MarkAsReadResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"9\n" +
+	"\x1fGetNotificationsByFilterRequest\x12\x16\n" +
+	"\x06filter\x18\x01 \x01(\tR\x06filter\"\xd0\x01\n" +
+	"\fnotification\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\x03R\x03uid\x12\x16\n" +
+	"\x06userid\x18\x02 \x01(\x03R\x06userid\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\tR\tcreatedAt\x12\x17\n" +
+	"\asend_at\x18\x04 \x01(\tR\x06sendAt\x12\x17\n" +
+	"\aread_at\x18\x05 \x01(\tR\x06readAt\x12&\n" +
+	"\x04data\x18\x06 \x01(\v2\x12.notification.dataR\x04data\x12\x1d\n" +
+	"\n" +
+	"deliver_at\x18\a \x01(\tR\tdeliverAt\"d\n" +
+	" GetNotificationsByFilterResponse\x12@\n" +
+	"\rnotifications\x18\x01 \x03(\v2\x1a.notification.notificationR\rnotifications\"\"\n" +
+	" CentrifugoConnectionTokenRequest\">\n" +
+	"\"CentrifugoSubscriptionTokenRequest\x12\x18\n" +
+	"\achannel\x18\x01 \x01(\tR\achannel\"/\n" +
+	"\x17CentrifugoTokenResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"4\n" +
+	"\n" +
+	"QuietHours\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\tR\x03end\"\xf8\x01\n" +
+	"\vPreferences\x12)\n" +
+	"\x10muted_categories\x18\x01 \x03(\tR\x0fmutedCategories\x129\n" +
+	"\vquiet_hours\x18\x02 \x01(\v2\x18.notification.QuietHoursR\n" +
+	"quietHours\x12\x1a\n" +
+	"\btimezone\x18\x03 \x01(\tR\btimezone\x12\x1a\n" +
+	"\brealtime\x18\x04 \x01(\bR\brealtime\x12\x14\n" +
+	"\x05email\x18\x05 \x01(\bR\x05email\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\tR\tupdatedAt\x12\x16\n" +
+	"\x06locale\x18\a \x01(\tR\x06locale\"\x17\n" +
+	"\x15GetPreferencesRequest\"W\n" +
+	"\x18UpdatePreferencesRequest\x12;\n" +
+	"\vpreferences\x18\x01 \x01(\v2\x19.notification.PreferencesR\vpreferences\"R\n" +
+	"\x13PreferencesResponse\x12;\n" +
+	"\vpreferences\x18\x01 \x01(\v2\x19.notification.PreferencesR\vpreferences\"\x16\n" +
+	"\x14ListScheduledRequest\"Y\n" +
+	"\x15ListScheduledResponse\x12@\n" +
+	"\rnotifications\x18\x01 \x03(\v2\x1a.notification.notificationR\rnotifications\"D\n" +
+	"\x11RescheduleRequest\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\x03R\x03uid\x12\x1d\n" +
+	"\n" +
+	"deliver_at\x18\x02 \x01(\tR\tdeliverAt\"T\n" +
+	"\x12RescheduleResponse\x12>\n" +
+	"\fnotification\x18\x01 \x01(\v2\x1a.notification.notificationR\fnotification\"*\n" +
+	"\x16CancelScheduledRequest\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\x03R\x03uid\"3\n" +
+	"\x17CancelScheduledResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage*^\n" +
+	"\bPriority\x12\x18\n" +
+	"\x14PRIORITY_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fPRIORITY_LOW\x10\x01\x12\x13\n" +
+	"\x0fPRIORITY_NORMAL\x10\x02\x12\x11\n" +
+	"\rPRIORITY_HIGH\x10\x032\xe0\t\n" +
+	"\fNotification\x12b\n" +
+	"\aPublish\x12\x1c.notification.PublishRequest\x1a\x1d.notification.PublishResponse\"\x1a\x8a\xb5\x18\x16\x1a\x14notification:publish\x12j\n" +
+	"\tBroadcast\x12\x1e.notification.BroadcastRequest\x1a\x1f.notification.BroadcastResponse\"\x1c\x8a\xb5\x18\x18\x1a\x16notification:broadcast\x12W\n" +
+	"\n" +
+	"MarkAsRead\x12\x1f.notification.MarkAsReadRequest\x1a .notification.MarkAsReadResponse\"\x06\x8a\xb5\x18\x02\x10\x01\x12\x81\x01\n" +
+	"\x18GetNotificationsByFilter\x12-.notification.GetNotificationsByFilterRequest\x1a..notification.GetNotificationsByFilterResponse\"\x06\x8a\xb5\x18\x02\x10\x01\x12z\n" +
+	"\x19CentrifugoConnectionToken\x12..notification.CentrifugoConnectionTokenRequest\x1a%.notification.CentrifugoTokenResponse\"\x06\x8a\xb5\x18\x02\x10\x01\x12~\n" +
+	"\x1bCentrifugoSubscriptionToken\x120.notification.CentrifugoSubscriptionTokenRequest\x1a%.notification.CentrifugoTokenResponse\"\x06\x8a\xb5\x18\x02\x10\x01\x12`\n" +
+	"\x0eGetPreferences\x12#.notification.GetPreferencesRequest\x1a!.notification.PreferencesResponse\"\x06\x8a\xb5\x18\x02\x10\x01\x12f\n" +
+	"\x11UpdatePreferences\x12&.notification.UpdatePreferencesRequest\x1a!.notification.PreferencesResponse\"\x06\x8a\xb5\x18\x02\x10\x01\x12t\n" +
+	"\rListScheduled\x12\".notification.ListScheduledRequest\x1a#.notification.ListScheduledResponse\"\x1a\x8a\xb5\x18\x16\x1a\x14notification:publish\x12k\n" +
 	"\n" +
-	"MarkAsRead\x12\x1f.notification.MarkAsReadRequest\x1a .notification.MarkAsReadResponse\x12y\n" +
-	"\x18GetNotificationsByFilter\x12-.notification.GetNotificationsByFilterRequest\x1a..notification.GetNotificationsByFilterResponseBKZIgithub.com/DANazavr/RATest/protos/gen/go/ratest/notification;notificationb\x06proto3"
+	"Reschedule\x12\x1f.notification.RescheduleRequest\x1a .notification.RescheduleResponse\"\x1a\x8a\xb5\x18\x16\x1a\x14notification:publish\x12z\n" +
+	"\x0fCancelScheduled\x12$.notification.CancelScheduledRequest\x1a%.notification.CancelScheduledResponse\"\x1a\x8a\xb5\x18\x16\x1a\x14notification:publishBKZIgithub.com/DANazavr/RATest/protos/gen/go/ratest/notification;notificationb\x06proto3"
 
 var (
 	file_notification_notification_proto_rawDescOnce sync.Once
@@ -578,37 +1540,77 @@ func file_notification_notification_proto_rawDescGZIP() []byte {
 	return file_notification_notification_proto_rawDescData
 }
 
-var file_notification_notification_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_notification_notification_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_notification_notification_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_notification_notification_proto_goTypes = []any{
-	(*Data)(nil),                             // 0: notification.data
-	(*PublishRequest)(nil),                   // 1: notification.PublishRequest
-	(*PublishResponse)(nil),                  // 2: notification.PublishResponse
-	(*BroadcastRequest)(nil),                 // 3: notification.BroadcastRequest
-	(*BroadcastResponse)(nil),                // 4: notification.BroadcastResponse
-	(*MarkAsReadRequest)(nil),                // 5: notification.MarkAsReadRequest
-	(*MarkAsReadResponse)(nil),               // 6: notification.MarkAsReadResponse
-	(*GetNotificationsByFilterRequest)(nil),  // 7: notification.GetNotificationsByFilterRequest
-	(*Notification)(nil),                     // 8: notification.notification
-	(*GetNotificationsByFilterResponse)(nil), // 9: notification.GetNotificationsByFilterResponse
+	(Priority)(0),                              // 0: notification.Priority
+	(*Data)(nil),                               // 1: notification.data
+	(*PublishRequest)(nil),                     // 2: notification.PublishRequest
+	(*PublishResponse)(nil),                    // 3: notification.PublishResponse
+	(*BroadcastRequest)(nil),                   // 4: notification.BroadcastRequest
+	(*BroadcastResponse)(nil),                  // 5: notification.BroadcastResponse
+	(*MarkAsReadRequest)(nil),                  // 6: notification.MarkAsReadRequest
+	(*MarkAsReadResponse)(nil),                 // 7: notification.MarkAsReadResponse
+	(*GetNotificationsByFilterRequest)(nil),    // 8: notification.GetNotificationsByFilterRequest
+	(*Notification)(nil),                       // 9: notification.notification
+	(*GetNotificationsByFilterResponse)(nil),   // 10: notification.GetNotificationsByFilterResponse
+	(*CentrifugoConnectionTokenRequest)(nil),   // 11: notification.CentrifugoConnectionTokenRequest
+	(*CentrifugoSubscriptionTokenRequest)(nil), // 12: notification.CentrifugoSubscriptionTokenRequest
+	(*CentrifugoTokenResponse)(nil),            // 13: notification.CentrifugoTokenResponse
+	(*QuietHours)(nil),                         // 14: notification.QuietHours
+	(*Preferences)(nil),                        // 15: notification.Preferences
+	(*GetPreferencesRequest)(nil),              // 16: notification.GetPreferencesRequest
+	(*UpdatePreferencesRequest)(nil),           // 17: notification.UpdatePreferencesRequest
+	(*PreferencesResponse)(nil),                // 18: notification.PreferencesResponse
+	(*ListScheduledRequest)(nil),               // 19: notification.ListScheduledRequest
+	(*ListScheduledResponse)(nil),              // 20: notification.ListScheduledResponse
+	(*RescheduleRequest)(nil),                  // 21: notification.RescheduleRequest
+	(*RescheduleResponse)(nil),                 // 22: notification.RescheduleResponse
+	(*CancelScheduledRequest)(nil),             // 23: notification.CancelScheduledRequest
+	(*CancelScheduledResponse)(nil),            // 24: notification.CancelScheduledResponse
+	(*structpb.Struct)(nil),                    // 25: google.protobuf.Struct
 }
 var file_notification_notification_proto_depIdxs = []int32{
-	0, // 0: notification.PublishRequest.data:type_name -> notification.data
-	0, // 1: notification.BroadcastRequest.data:type_name -> notification.data
-	0, // 2: notification.notification.data:type_name -> notification.data
-	8, // 3: notification.GetNotificationsByFilterResponse.notifications:type_name -> notification.notification
-	1, // 4: notification.Notification.Publish:input_type -> notification.PublishRequest
-	3, // 5: notification.Notification.Broadcast:input_type -> notification.BroadcastRequest
-	5, // 6: notification.Notification.MarkAsRead:input_type -> notification.MarkAsReadRequest
-	7, // 7: notification.Notification.GetNotificationsByFilter:input_type -> notification.GetNotificationsByFilterRequest
-	2, // 8: notification.Notification.Publish:output_type -> notification.PublishResponse
-	4, // 9: notification.Notification.Broadcast:output_type -> notification.BroadcastResponse
-	6, // 10: notification.Notification.MarkAsRead:output_type -> notification.MarkAsReadResponse
-	9, // 11: notification.Notification.GetNotificationsByFilter:output_type -> notification.GetNotificationsByFilterResponse
-	8, // [8:12] is the sub-list for method output_type
-	4, // [4:8] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	0,  // 0: notification.data.priority:type_name -> notification.Priority
+	25, // 1: notification.data.metadata:type_name -> google.protobuf.Struct
+	1,  // 2: notification.PublishRequest.data:type_name -> notification.data
+	25, // 3: notification.PublishRequest.variables:type_name -> google.protobuf.Struct
+	1,  // 4: notification.BroadcastRequest.data:type_name -> notification.data
+	25, // 5: notification.BroadcastRequest.variables:type_name -> google.protobuf.Struct
+	1,  // 6: notification.notification.data:type_name -> notification.data
+	9,  // 7: notification.GetNotificationsByFilterResponse.notifications:type_name -> notification.notification
+	14, // 8: notification.Preferences.quiet_hours:type_name -> notification.QuietHours
+	15, // 9: notification.UpdatePreferencesRequest.preferences:type_name -> notification.Preferences
+	15, // 10: notification.PreferencesResponse.preferences:type_name -> notification.Preferences
+	9,  // 11: notification.ListScheduledResponse.notifications:type_name -> notification.notification
+	9,  // 12: notification.RescheduleResponse.notification:type_name -> notification.notification
+	2,  // 13: notification.Notification.Publish:input_type -> notification.PublishRequest
+	4,  // 14: notification.Notification.Broadcast:input_type -> notification.BroadcastRequest
+	6,  // 15: notification.Notification.MarkAsRead:input_type -> notification.MarkAsReadRequest
+	8,  // 16: notification.Notification.GetNotificationsByFilter:input_type -> notification.GetNotificationsByFilterRequest
+	11, // 17: notification.Notification.CentrifugoConnectionToken:input_type -> notification.CentrifugoConnectionTokenRequest
+	12, // 18: notification.Notification.CentrifugoSubscriptionToken:input_type -> notification.CentrifugoSubscriptionTokenRequest
+	16, // 19: notification.Notification.GetPreferences:input_type -> notification.GetPreferencesRequest
+	17, // 20: notification.Notification.UpdatePreferences:input_type -> notification.UpdatePreferencesRequest
+	19, // 21: notification.Notification.ListScheduled:input_type -> notification.ListScheduledRequest
+	21, // 22: notification.Notification.Reschedule:input_type -> notification.RescheduleRequest
+	23, // 23: notification.Notification.CancelScheduled:input_type -> notification.CancelScheduledRequest
+	3,  // 24: notification.Notification.Publish:output_type -> notification.PublishResponse
+	5,  // 25: notification.Notification.Broadcast:output_type -> notification.BroadcastResponse
+	7,  // 26: notification.Notification.MarkAsRead:output_type -> notification.MarkAsReadResponse
+	10, // 27: notification.Notification.GetNotificationsByFilter:output_type -> notification.GetNotificationsByFilterResponse
+	13, // 28: notification.Notification.CentrifugoConnectionToken:output_type -> notification.CentrifugoTokenResponse
+	13, // 29: notification.Notification.CentrifugoSubscriptionToken:output_type -> notification.CentrifugoTokenResponse
+	18, // 30: notification.Notification.GetPreferences:output_type -> notification.PreferencesResponse
+	18, // 31: notification.Notification.UpdatePreferences:output_type -> notification.PreferencesResponse
+	20, // 32: notification.Notification.ListScheduled:output_type -> notification.ListScheduledResponse
+	22, // 33: notification.Notification.Reschedule:output_type -> notification.RescheduleResponse
+	24, // 34: notification.Notification.CancelScheduled:output_type -> notification.CancelScheduledResponse
+	24, // [24:35] is the sub-list for method output_type
+	13, // [13:24] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_notification_notification_proto_init() }
@@ -621,13 +1623,14 @@ func file_notification_notification_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_notification_notification_proto_rawDesc), len(file_notification_notification_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   10,
+			NumEnums:      1,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_notification_notification_proto_goTypes,
 		DependencyIndexes: file_notification_notification_proto_depIdxs,
+		EnumInfos:         file_notification_notification_proto_enumTypes,
 		MessageInfos:      file_notification_notification_proto_msgTypes,
 	}.Build()
 	File_notification_notification_proto = out.File
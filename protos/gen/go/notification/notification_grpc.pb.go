@@ -19,10 +19,17 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Notification_Publish_FullMethodName                  = "/notification.Notification/Publish"
-	Notification_Broadcast_FullMethodName                = "/notification.Notification/Broadcast"
-	Notification_MarkAsRead_FullMethodName               = "/notification.Notification/MarkAsRead"
-	Notification_GetNotificationsByFilter_FullMethodName = "/notification.Notification/GetNotificationsByFilter"
+	Notification_Publish_FullMethodName                     = "/notification.Notification/Publish"
+	Notification_Broadcast_FullMethodName                   = "/notification.Notification/Broadcast"
+	Notification_MarkAsRead_FullMethodName                  = "/notification.Notification/MarkAsRead"
+	Notification_GetNotificationsByFilter_FullMethodName    = "/notification.Notification/GetNotificationsByFilter"
+	Notification_CentrifugoConnectionToken_FullMethodName   = "/notification.Notification/CentrifugoConnectionToken"
+	Notification_CentrifugoSubscriptionToken_FullMethodName = "/notification.Notification/CentrifugoSubscriptionToken"
+	Notification_GetPreferences_FullMethodName              = "/notification.Notification/GetPreferences"
+	Notification_UpdatePreferences_FullMethodName           = "/notification.Notification/UpdatePreferences"
+	Notification_ListScheduled_FullMethodName               = "/notification.Notification/ListScheduled"
+	Notification_Reschedule_FullMethodName                  = "/notification.Notification/Reschedule"
+	Notification_CancelScheduled_FullMethodName             = "/notification.Notification/CancelScheduled"
 )
 
 // NotificationClient is the client API for Notification service.
@@ -33,6 +40,13 @@ type NotificationClient interface {
 	Broadcast(ctx context.Context, in *BroadcastRequest, opts ...grpc.CallOption) (*BroadcastResponse, error)
 	MarkAsRead(ctx context.Context, in *MarkAsReadRequest, opts ...grpc.CallOption) (*MarkAsReadResponse, error)
 	GetNotificationsByFilter(ctx context.Context, in *GetNotificationsByFilterRequest, opts ...grpc.CallOption) (*GetNotificationsByFilterResponse, error)
+	CentrifugoConnectionToken(ctx context.Context, in *CentrifugoConnectionTokenRequest, opts ...grpc.CallOption) (*CentrifugoTokenResponse, error)
+	CentrifugoSubscriptionToken(ctx context.Context, in *CentrifugoSubscriptionTokenRequest, opts ...grpc.CallOption) (*CentrifugoTokenResponse, error)
+	GetPreferences(ctx context.Context, in *GetPreferencesRequest, opts ...grpc.CallOption) (*PreferencesResponse, error)
+	UpdatePreferences(ctx context.Context, in *UpdatePreferencesRequest, opts ...grpc.CallOption) (*PreferencesResponse, error)
+	ListScheduled(ctx context.Context, in *ListScheduledRequest, opts ...grpc.CallOption) (*ListScheduledResponse, error)
+	Reschedule(ctx context.Context, in *RescheduleRequest, opts ...grpc.CallOption) (*RescheduleResponse, error)
+	CancelScheduled(ctx context.Context, in *CancelScheduledRequest, opts ...grpc.CallOption) (*CancelScheduledResponse, error)
 }
 
 type notificationClient struct {
@@ -83,6 +97,76 @@ func (c *notificationClient) GetNotificationsByFilter(ctx context.Context, in *G
 	return out, nil
 }
 
+func (c *notificationClient) CentrifugoConnectionToken(ctx context.Context, in *CentrifugoConnectionTokenRequest, opts ...grpc.CallOption) (*CentrifugoTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CentrifugoTokenResponse)
+	err := c.cc.Invoke(ctx, Notification_CentrifugoConnectionToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationClient) CentrifugoSubscriptionToken(ctx context.Context, in *CentrifugoSubscriptionTokenRequest, opts ...grpc.CallOption) (*CentrifugoTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CentrifugoTokenResponse)
+	err := c.cc.Invoke(ctx, Notification_CentrifugoSubscriptionToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationClient) GetPreferences(ctx context.Context, in *GetPreferencesRequest, opts ...grpc.CallOption) (*PreferencesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreferencesResponse)
+	err := c.cc.Invoke(ctx, Notification_GetPreferences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationClient) UpdatePreferences(ctx context.Context, in *UpdatePreferencesRequest, opts ...grpc.CallOption) (*PreferencesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreferencesResponse)
+	err := c.cc.Invoke(ctx, Notification_UpdatePreferences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationClient) ListScheduled(ctx context.Context, in *ListScheduledRequest, opts ...grpc.CallOption) (*ListScheduledResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListScheduledResponse)
+	err := c.cc.Invoke(ctx, Notification_ListScheduled_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationClient) Reschedule(ctx context.Context, in *RescheduleRequest, opts ...grpc.CallOption) (*RescheduleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RescheduleResponse)
+	err := c.cc.Invoke(ctx, Notification_Reschedule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *notificationClient) CancelScheduled(ctx context.Context, in *CancelScheduledRequest, opts ...grpc.CallOption) (*CancelScheduledResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelScheduledResponse)
+	err := c.cc.Invoke(ctx, Notification_CancelScheduled_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NotificationServer is the server API for Notification service.
 // All implementations must embed UnimplementedNotificationServer
 // for forward compatibility.
@@ -91,6 +175,13 @@ type NotificationServer interface {
 	Broadcast(context.Context, *BroadcastRequest) (*BroadcastResponse, error)
 	MarkAsRead(context.Context, *MarkAsReadRequest) (*MarkAsReadResponse, error)
 	GetNotificationsByFilter(context.Context, *GetNotificationsByFilterRequest) (*GetNotificationsByFilterResponse, error)
+	CentrifugoConnectionToken(context.Context, *CentrifugoConnectionTokenRequest) (*CentrifugoTokenResponse, error)
+	CentrifugoSubscriptionToken(context.Context, *CentrifugoSubscriptionTokenRequest) (*CentrifugoTokenResponse, error)
+	GetPreferences(context.Context, *GetPreferencesRequest) (*PreferencesResponse, error)
+	UpdatePreferences(context.Context, *UpdatePreferencesRequest) (*PreferencesResponse, error)
+	ListScheduled(context.Context, *ListScheduledRequest) (*ListScheduledResponse, error)
+	Reschedule(context.Context, *RescheduleRequest) (*RescheduleResponse, error)
+	CancelScheduled(context.Context, *CancelScheduledRequest) (*CancelScheduledResponse, error)
 	mustEmbedUnimplementedNotificationServer()
 }
 
@@ -113,6 +204,27 @@ func (UnimplementedNotificationServer) MarkAsRead(context.Context, *MarkAsReadRe
 func (UnimplementedNotificationServer) GetNotificationsByFilter(context.Context, *GetNotificationsByFilterRequest) (*GetNotificationsByFilterResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetNotificationsByFilter not implemented")
 }
+func (UnimplementedNotificationServer) CentrifugoConnectionToken(context.Context, *CentrifugoConnectionTokenRequest) (*CentrifugoTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CentrifugoConnectionToken not implemented")
+}
+func (UnimplementedNotificationServer) CentrifugoSubscriptionToken(context.Context, *CentrifugoSubscriptionTokenRequest) (*CentrifugoTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CentrifugoSubscriptionToken not implemented")
+}
+func (UnimplementedNotificationServer) GetPreferences(context.Context, *GetPreferencesRequest) (*PreferencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPreferences not implemented")
+}
+func (UnimplementedNotificationServer) UpdatePreferences(context.Context, *UpdatePreferencesRequest) (*PreferencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePreferences not implemented")
+}
+func (UnimplementedNotificationServer) ListScheduled(context.Context, *ListScheduledRequest) (*ListScheduledResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScheduled not implemented")
+}
+func (UnimplementedNotificationServer) Reschedule(context.Context, *RescheduleRequest) (*RescheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reschedule not implemented")
+}
+func (UnimplementedNotificationServer) CancelScheduled(context.Context, *CancelScheduledRequest) (*CancelScheduledResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelScheduled not implemented")
+}
 func (UnimplementedNotificationServer) mustEmbedUnimplementedNotificationServer() {}
 func (UnimplementedNotificationServer) testEmbeddedByValue()                      {}
 
@@ -206,6 +318,132 @@ func _Notification_GetNotificationsByFilter_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Notification_CentrifugoConnectionToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CentrifugoConnectionTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServer).CentrifugoConnectionToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notification_CentrifugoConnectionToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServer).CentrifugoConnectionToken(ctx, req.(*CentrifugoConnectionTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notification_CentrifugoSubscriptionToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CentrifugoSubscriptionTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServer).CentrifugoSubscriptionToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notification_CentrifugoSubscriptionToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServer).CentrifugoSubscriptionToken(ctx, req.(*CentrifugoSubscriptionTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notification_GetPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPreferencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServer).GetPreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notification_GetPreferences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServer).GetPreferences(ctx, req.(*GetPreferencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notification_UpdatePreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePreferencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServer).UpdatePreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notification_UpdatePreferences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServer).UpdatePreferences(ctx, req.(*UpdatePreferencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notification_ListScheduled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScheduledRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServer).ListScheduled(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notification_ListScheduled_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServer).ListScheduled(ctx, req.(*ListScheduledRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notification_Reschedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RescheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServer).Reschedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notification_Reschedule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServer).Reschedule(ctx, req.(*RescheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Notification_CancelScheduled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelScheduledRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServer).CancelScheduled(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Notification_CancelScheduled_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServer).CancelScheduled(ctx, req.(*CancelScheduledRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Notification_ServiceDesc is the grpc.ServiceDesc for Notification service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,6 +467,34 @@ var Notification_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetNotificationsByFilter",
 			Handler:    _Notification_GetNotificationsByFilter_Handler,
 		},
+		{
+			MethodName: "CentrifugoConnectionToken",
+			Handler:    _Notification_CentrifugoConnectionToken_Handler,
+		},
+		{
+			MethodName: "CentrifugoSubscriptionToken",
+			Handler:    _Notification_CentrifugoSubscriptionToken_Handler,
+		},
+		{
+			MethodName: "GetPreferences",
+			Handler:    _Notification_GetPreferences_Handler,
+		},
+		{
+			MethodName: "UpdatePreferences",
+			Handler:    _Notification_UpdatePreferences_Handler,
+		},
+		{
+			MethodName: "ListScheduled",
+			Handler:    _Notification_ListScheduled_Handler,
+		},
+		{
+			MethodName: "Reschedule",
+			Handler:    _Notification_Reschedule_Handler,
+		},
+		{
+			MethodName: "CancelScheduled",
+			Handler:    _Notification_CancelScheduled_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "notification/notification.proto",
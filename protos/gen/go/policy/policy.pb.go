@@ -0,0 +1,214 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.1
+// source: policy/policy.proto
+
+package policy
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Policy - правило доступа к RPC. Каждый метод сервера обязан его объявить,
+// иначе сервер не запустится.
+type Policy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Rule:
+	//
+	//	*Policy_Public
+	//	*Policy_Authenticated
+	//	*Policy_Permission
+	Rule          isPolicy_Rule `protobuf_oneof:"rule"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Policy) Reset() {
+	*x = Policy{}
+	mi := &file_policy_policy_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Policy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Policy) ProtoMessage() {}
+
+func (x *Policy) ProtoReflect() protoreflect.Message {
+	mi := &file_policy_policy_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Policy.ProtoReflect.Descriptor instead.
+func (*Policy) Descriptor() ([]byte, []int) {
+	return file_policy_policy_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Policy) GetRule() isPolicy_Rule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+func (x *Policy) GetPublic() bool {
+	if x != nil {
+		if x, ok := x.Rule.(*Policy_Public); ok {
+			return x.Public
+		}
+	}
+	return false
+}
+
+func (x *Policy) GetAuthenticated() bool {
+	if x != nil {
+		if x, ok := x.Rule.(*Policy_Authenticated); ok {
+			return x.Authenticated
+		}
+	}
+	return false
+}
+
+func (x *Policy) GetPermission() string {
+	if x != nil {
+		if x, ok := x.Rule.(*Policy_Permission); ok {
+			return x.Permission
+		}
+	}
+	return ""
+}
+
+type isPolicy_Rule interface {
+	isPolicy_Rule()
+}
+
+type Policy_Public struct {
+	// Вызов без аутентификации
+	Public bool `protobuf:"varint,1,opt,name=public,proto3,oneof"`
+}
+
+type Policy_Authenticated struct {
+	// Любой пользователь с действующим access токеном
+	Authenticated bool `protobuf:"varint,2,opt,name=authenticated,proto3,oneof"`
+}
+
+type Policy_Permission struct {
+	// Право роли пользователя, scope API ключа или право принципала mTLS
+	Permission string `protobuf:"bytes,3,opt,name=permission,proto3,oneof"`
+}
+
+func (*Policy_Public) isPolicy_Rule() {}
+
+func (*Policy_Authenticated) isPolicy_Rule() {}
+
+func (*Policy_Permission) isPolicy_Rule() {}
+
+var file_policy_policy_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*Policy)(nil),
+		Field:         50001,
+		Name:          "ratest.policy.policy",
+		Tag:           "bytes,50001,opt,name=policy",
+		Filename:      "policy/policy.proto",
+	},
+}
+
+// Extension fields to descriptorpb.MethodOptions.
+var (
+	// optional ratest.policy.Policy policy = 50001;
+	E_Policy = &file_policy_policy_proto_extTypes[0]
+)
+
+var File_policy_policy_proto protoreflect.FileDescriptor
+
+const file_policy_policy_proto_rawDesc = "" +
+	"\n" +
+	"\x13policy/policy.proto\x12\rratest.policy\x1a google/protobuf/descriptor.proto\"t\n" +
+	"\x06Policy\x12\x18\n" +
+	"\x06public\x18\x01 \x01(\bH\x00R\x06public\x12&\n" +
+	"\rauthenticated\x18\x02 \x01(\bH\x00R\rauthenticated\x12 \n" +
+	"\n" +
+	"permission\x18\x03 \x01(\tH\x00R\n" +
+	"permissionB\x06\n" +
+	"\x04rule:O\n" +
+	"\x06policy\x12\x1e.google.protobuf.MethodOptions\x18ц\x03 \x01(\v2\x15.ratest.policy.PolicyR\x06policyB8Z6github.com/DANazavr/RATest/protos/gen/go/policy;policyb\x06proto3"
+
+var (
+	file_policy_policy_proto_rawDescOnce sync.Once
+	file_policy_policy_proto_rawDescData []byte
+)
+
+func file_policy_policy_proto_rawDescGZIP() []byte {
+	file_policy_policy_proto_rawDescOnce.Do(func() {
+		file_policy_policy_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_policy_policy_proto_rawDesc), len(file_policy_policy_proto_rawDesc)))
+	})
+	return file_policy_policy_proto_rawDescData
+}
+
+var file_policy_policy_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_policy_policy_proto_goTypes = []any{
+	(*Policy)(nil),                     // 0: ratest.policy.Policy
+	(*descriptorpb.MethodOptions)(nil), // 1: google.protobuf.MethodOptions
+}
+var file_policy_policy_proto_depIdxs = []int32{
+	1, // 0: ratest.policy.policy:extendee -> google.protobuf.MethodOptions
+	0, // 1: ratest.policy.policy:type_name -> ratest.policy.Policy
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	1, // [1:2] is the sub-list for extension type_name
+	0, // [0:1] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_policy_policy_proto_init() }
+func file_policy_policy_proto_init() {
+	if File_policy_policy_proto != nil {
+		return
+	}
+	file_policy_policy_proto_msgTypes[0].OneofWrappers = []any{
+		(*Policy_Public)(nil),
+		(*Policy_Authenticated)(nil),
+		(*Policy_Permission)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_policy_policy_proto_rawDesc), len(file_policy_policy_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_policy_policy_proto_goTypes,
+		DependencyIndexes: file_policy_policy_proto_depIdxs,
+		MessageInfos:      file_policy_policy_proto_msgTypes,
+		ExtensionInfos:    file_policy_policy_proto_extTypes,
+	}.Build()
+	File_policy_policy_proto = out.File
+	file_policy_policy_proto_goTypes = nil
+	file_policy_policy_proto_depIdxs = nil
+}